@@ -2,93 +2,159 @@ package main
 
 import (
 	"context"
-	"flag"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
 	"time"
 
 	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
 	"github.com/cepmap/otus-system-monitoring/internal/logger"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"github.com/cepmap/otus-system-monitoring/internal/output"
+	"github.com/cepmap/otus-system-monitoring/internal/tui"
+	"github.com/cepmap/otus-system-monitoring/pkg/statsclient"
+	"github.com/spf13/pflag"
 )
 
 var (
-	host            = flag.String("host", "localhost", "Server host")
-	port            = flag.String("port", "8088", "Server port")
-	interval        = flag.Int("interval", 1, "Interval between requests in seconds")
-	averagingPeriod = flag.Int("averaging-period", 2, "Averaging period in seconds")
-	loadAvg         = flag.Bool("load-avg", true, "Include load average metrics")
-	cpuStats        = flag.Bool("cpu", true, "Include CPU stats metrics")
-	disksLoad       = flag.Bool("disks-load", true, "Include disks load metrics")
-	diskUsage       = flag.Bool("disk-usage", true, "Include disk usage metrics")
+	duration   = pflag.Duration("duration", 0, "Stop after this duration, 0 means run until interrupted")
+	tuiMode    = pflag.Bool("tui", false, "Show a full-screen live dashboard instead of printing responses")
+	listStats  = pflag.Bool("list-stats", false, "List stat types and whether the server has them enabled, then exit")
+	maxBackoff = pflag.Duration("max-backoff", 30*time.Second, "Maximum delay between reconnection attempts")
 )
 
-// ./client -load-avg=false -disk-usage=false
+// ./client --stats cpu --output csv
 
 func main() {
-	flag.Parse()
+	// Статистика выводится в stdout для конвейеров, журнал не должен её портить.
+	logger.SetWriter(os.Stderr)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	addr := fmt.Sprintf("%s:%s", *host, *port)
-	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	cfg, err := config.InitClientConfig()
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to %s: %v", addr, err))
+		logger.Error(err.Error())
 		return
 	}
-	defer conn.Close()
-
-	client := pb.NewStatsServiceClient(conn)
+	settings := cfg.Client
 
-	var statTypes []pb.StatType
-	if *loadAvg {
-		statTypes = append(statTypes, pb.StatType_LOAD_AVERAGE)
-	}
-	if *cpuStats {
-		statTypes = append(statTypes, pb.StatType_CPU_STATS)
-	}
-	if *disksLoad {
-		statTypes = append(statTypes, pb.StatType_DISKS_LOAD)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *duration)
+		defer cancel()
 	}
-	if *diskUsage {
-		statTypes = append(statTypes, pb.StatType_DISK_USAGE)
+
+	addr := cfg.Server.Addr()
+	if *listStats {
+		if err := printStatTypes(ctx, addr); err != nil {
+			logger.Error(fmt.Sprintf("Failed to list stat types: %v", err))
+		}
+		return
 	}
 
+	statTypes, err := statsclient.ParseStatTypes(settings.Stats)
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
 	if len(statTypes) == 0 {
 		logger.Error("No stat types selected")
 		return
 	}
 
+	agg, ok := pb.Aggregation_value[strings.ToUpper(settings.Aggregation)]
+	if !ok {
+		logger.Error(fmt.Sprintf("Unknown aggregation: %s", settings.Aggregation))
+		return
+	}
+
 	//nolint:gosec
 	req := &pb.StatsRequest{
-		IntervalN:        int32(*interval),
-		AveragingPeriodM: int32(*averagingPeriod),
+		IntervalN:        int32(settings.Interval),
+		AveragingPeriodM: int32(settings.AveragingPeriod),
 		StatTypes:        statTypes,
+		Aggregation:      pb.Aggregation(agg),
+		TopN:             int32(settings.TopN),
 	}
 
-	stream, err := client.GetStats(ctx, req)
+	out, err := output.New(settings.Output, os.Stdout)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to get stats: %v", err))
+		logger.Error(err.Error())
+		return
+	}
+
+	if err := checkRequest(ctx, addr, req); err != nil {
+		logger.Error(err.Error())
 		return
 	}
 
-	ticker := time.NewTicker(time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			log.Printf("Context cancelled")
-			return
-		case <-ticker.C:
-			resp, err := stream.Recv()
-			if err != nil {
-				log.Printf("Stream closed: %v", err)
-				return
-			}
-			fmt.Printf("[%s] Received stats: %+v\n", time.Now().Format(time.RFC3339), resp)
+	if *tuiMode {
+		if err := tui.Run(ctx, addr, req, statsclient.WithBackoff(500*time.Millisecond, *maxBackoff)); err != nil {
+			logger.Error(fmt.Sprintf("Dashboard failed: %v", err))
 		}
+		return
+	}
+
+	client, err := statsclient.New(addr,
+		statsclient.WithBackoff(500*time.Millisecond, *maxBackoff),
+		statsclient.OnRetry(func(err error, delay time.Duration) {
+			log.Printf("Stream interrupted: %v, reconnecting in %s", err, delay.Round(time.Millisecond))
+		}),
+	)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to %s: %v", addr, err))
+		return
+	}
+	defer client.Close()
+
+	err = client.Stream(ctx, req, out.Write)
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		log.Printf("Context cancelled")
+	case err != nil:
+		log.Printf("Stream closed: %v", err)
+	}
+}
+
+// checkRequest сверяет запрос с возможностями сервера до подписки. Если сервер
+// пока недоступен, проверка пропускается: поток сам дождётся сервера.
+func checkRequest(ctx context.Context, addr string, req *pb.StatsRequest) error {
+	client, err := statsclient.New(addr, statsclient.WithTimeout(10*time.Second))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	capabilities, err := client.Capabilities(ctx)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Failed to get server capabilities, request is not checked: %v", err))
+		return nil
+	}
+	return capabilities.Check(req)
+}
+
+// printStatTypes печатает сервер и все его типы статистики с признаком включения.
+func printStatTypes(ctx context.Context, addr string) error {
+	client, err := statsclient.New(addr, statsclient.WithTimeout(10*time.Second))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	capabilities, err := client.Capabilities(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Server %s, version %s\n", capabilities.Host.Hostname, capabilities.Version.Release)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STAT TYPE\tNAME\tENABLED")
+	for _, t := range capabilities.StatTypes {
+		fmt.Fprintf(w, "%s\t%s\t%t\n", strings.ToLower(t.Type.String()), t.Name, t.Enabled)
 	}
+	return w.Flush()
 }
@@ -2,30 +2,64 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/cepmap/otus-system-monitoring/internal/alerts"
+	"github.com/cepmap/otus-system-monitoring/internal/collector"
 	"github.com/cepmap/otus-system-monitoring/internal/config"
 	"github.com/cepmap/otus-system-monitoring/internal/logger"
 	"github.com/cepmap/otus-system-monitoring/internal/network/server"
 )
 
 func main() {
-	if err := config.InitConfig(); err != nil {
-		logger.Fatal(err.Error())
+	cfg, err := config.InitConfig()
+	err = validate(cfg, err)
+	if config.CheckOnly() {
+		checkConfig(err)
+	}
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("Invalid config: %v", err))
 	}
 
-	logger.Info(fmt.Sprintf("Current config: %+v", config.DaemonConfig))
+	logger.SetLogLevel(cfg.Log.Level)
+	logger.Info(fmt.Sprintf("Current config: %+v", cfg))
 
 	ctx, stop := signal.NotifyContext(context.Background(),
-		syscall.SIGHUP,
 		syscall.SIGINT,
 		syscall.SIGTERM,
 		syscall.SIGQUIT)
 	defer stop()
 
-	srv := server.NewStatsDaemonServer(ctx)
+	srv := server.NewStatsDaemonServer(ctx, cfg)
+	srv.SetVersion(server.Version{Release: release, BuildDate: buildDate, GitHash: gitHash})
+
+	// Конфигурация перечитывается только в основном цикле: viper не потокобезопасен.
+	reload := func() {
+		next, err := config.Reload()
+		if err = validate(next, err); err != nil {
+			logger.Error(fmt.Sprintf("Failed to reload config, keeping current: %v", err))
+			return
+		}
+		logger.SetLogLevel(next.Log.Level)
+		srv.ApplyConfig(next)
+		logger.Info(fmt.Sprintf("Config reloaded: %+v", next))
+	}
+
+	var changes <-chan struct{}
+	if config.WatchEnabled() {
+		changes, err = config.Watch(ctx)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Config watching disabled: %v", err))
+		}
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
 
 	go func() {
 		if err := srv.Start(); err != nil {
@@ -33,6 +67,36 @@ func main() {
 		}
 	}()
 
-	<-ctx.Done()
-	logger.Info("Received shutdown signal")
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Received shutdown signal")
+			return
+		case <-hup:
+			logger.Info("Received SIGHUP, reloading config")
+			reload()
+		case <-changes:
+			logger.Info("Config file changed, reloading config")
+			reload()
+		}
+	}
+}
+
+// validate дополняет ошибку загрузки проверками сборщиков и правил алертов,
+// которые config не может выполнить сам.
+func validate(cfg *config.Config, err error) error {
+	if cfg == nil {
+		return err
+	}
+	return errors.Join(err, collector.ValidateConfig(cfg.Stats), alerts.ValidateRules(cfg.Alerts.Rules))
+}
+
+// checkConfig печатает найденные в конфигурации проблемы и завершает процесс.
+func checkConfig(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Config is invalid:\n%v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("Config is valid")
+	os.Exit(0)
 }
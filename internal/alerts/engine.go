@@ -0,0 +1,241 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/collector"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/logger"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+)
+
+const (
+	defaultInterval = time.Second
+
+	subscriberBuffer = 64
+)
+
+// Engine периодически собирает нужные правилам метрики, проверяет правила
+// и рассылает подписчикам смену состояний алертов.
+type Engine struct {
+	mu          sync.RWMutex
+	metrics     *metrics.Storage
+	config      *config.Store
+	collector   *collector.Collector
+	rules       []rule
+	interval    time.Duration
+	alerts      map[string]*models.Alert
+	subscribers map[chan models.Alert]struct{}
+}
+
+// opts передаются коллектору, который собирает метрики правил.
+func New(
+	m *metrics.Storage,
+	cfg *config.Store,
+	interval time.Duration,
+	ruleConfigs []config.AlertRule,
+	opts ...collector.Option,
+) (*Engine, error) {
+	rules, err := newRules(ruleConfigs)
+	if err != nil {
+		return nil, err
+	}
+
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	var window time.Duration
+	statTypes := make([]pb.StatType, 0)
+	seen := make(map[pb.StatType]struct{})
+	for _, r := range rules {
+		window = max(window, r.Window)
+		if _, ok := seen[r.source.statType]; !ok {
+			seen[r.source.statType] = struct{}{}
+			statTypes = append(statTypes, r.source.statType)
+		}
+	}
+
+	return &Engine{
+		metrics:     m,
+		config:      cfg,
+		collector:   collector.New(m, cfg, statTypes, window, metrics.AggregationMean, stats.Options{}, opts...),
+		rules:       rules,
+		interval:    interval,
+		alerts:      make(map[string]*models.Alert),
+		subscribers: make(map[chan models.Alert]struct{}),
+	}, nil
+}
+
+func (e *Engine) Start(ctx context.Context) {
+	if len(e.rules) == 0 {
+		return
+	}
+	go e.loop(ctx)
+}
+
+func (e *Engine) loop(ctx context.Context) {
+	e.collector.Start(ctx)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	logger.Info(fmt.Sprintf("Alerts engine started with %d rules", len(e.rules)))
+	defer logger.Info("Alerts engine stopped")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			e.Evaluate(now)
+		}
+	}
+}
+
+// Evaluate проверяет все правила по данным хранилища на момент now.
+func (e *Engine) Evaluate(now time.Time) {
+	cfg := e.config.Get().Stats
+	for _, r := range e.rules {
+		values := r.source.values(e.metrics, cfg, r.Window)
+		for target, value := range values {
+			if r.Target != "" && r.Target != target {
+				continue
+			}
+			e.evaluateTarget(r, target, value, now)
+		}
+		// Без замеров за окно состояние не меняется, иначе алерты сбрасывались бы при каждом пропуске сбора.
+		if values != nil {
+			e.dropMissing(r, values, now)
+		}
+	}
+}
+
+// dropMissing снимает алерты правила по целям, пропавшим из данных,
+// например по отмонтированной файловой системе.
+func (e *Engine) dropMissing(r rule, values map[string]float64, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for key, alert := range e.alerts {
+		if alert.Rule != r.Name {
+			continue
+		}
+		if _, ok := values[alert.Target]; ok {
+			continue
+		}
+		if alert.State == models.AlertFiring {
+			alert.State = models.AlertResolved
+			logger.Info(fmt.Sprintf("Alert %s resolved for %q: target is gone", r.Name, alert.Target))
+		} else {
+			alert.State = models.AlertInactive
+		}
+		alert.Timestamp = now
+		delete(e.alerts, key)
+		e.publish(*alert)
+	}
+}
+
+func (e *Engine) evaluateTarget(r rule, target string, value float64, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	key := r.Name + "/" + target
+	alert, ok := e.alerts[key]
+	if !ok {
+		if !r.matches(value) {
+			return
+		}
+		alert = &models.Alert{
+			Rule:        r.Name,
+			Metric:      r.Metric,
+			Target:      target,
+			State:       models.AlertInactive,
+			Operator:    r.Operator,
+			Threshold:   r.Threshold,
+			ActiveSince: now,
+		}
+		e.alerts[key] = alert
+	}
+
+	prevState := alert.State
+	alert.Value = value
+	alert.Timestamp = now
+
+	switch alert.State {
+	case models.AlertInactive, models.AlertPending:
+		switch {
+		case !r.matches(value):
+			alert.State = models.AlertInactive
+		case now.Sub(alert.ActiveSince) >= r.For:
+			alert.State = models.AlertFiring
+			logger.Warn(fmt.Sprintf("Alert %s firing for %q: %s %s %v (value %v)",
+				r.Name, target, r.Metric, r.Operator, r.Threshold, value))
+		default:
+			alert.State = models.AlertPending
+		}
+	case models.AlertFiring:
+		if r.resolved(value) {
+			alert.State = models.AlertResolved
+			logger.Info(fmt.Sprintf("Alert %s resolved for %q (value %v)", r.Name, target, value))
+		}
+	case models.AlertResolved:
+	}
+
+	if alert.State == models.AlertInactive || alert.State == models.AlertResolved {
+		delete(e.alerts, key)
+	}
+	if alert.State != prevState {
+		e.publish(*alert)
+	}
+}
+
+// publish рассылает событие подписчикам; медленный подписчик теряет события, а не блокирует движок.
+func (e *Engine) publish(alert models.Alert) {
+	for ch := range e.subscribers {
+		select {
+		case ch <- alert:
+		default:
+			logger.Warn(fmt.Sprintf("Alert subscriber is too slow, dropping %s event for %s", alert.State, alert.Rule))
+		}
+	}
+}
+
+func (e *Engine) Subscribe() (<-chan models.Alert, func()) {
+	ch := make(chan models.Alert, subscriberBuffer)
+
+	e.mu.Lock()
+	e.subscribers[ch] = struct{}{}
+	e.mu.Unlock()
+
+	return ch, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		delete(e.subscribers, ch)
+	}
+}
+
+// Active возвращает ожидающие и сработавшие алерты, отсортированные по правилу и цели.
+func (e *Engine) Active() []models.Alert {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	result := make([]models.Alert, 0, len(e.alerts))
+	for _, alert := range e.alerts {
+		result = append(result, *alert)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Rule != result[j].Rule {
+			return result[i].Rule < result[j].Rule
+		}
+		return result[i].Target < result[j].Target
+	})
+	return result
+}
@@ -0,0 +1,202 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule config.AlertRule
+		err  error
+	}{
+		{
+			name: "valid rule",
+			rule: config.AlertRule{Name: "idle", Metric: "cpu.idle", Operator: "<", Threshold: 10},
+		},
+		{
+			name: "empty name",
+			rule: config.AlertRule{Metric: "cpu.idle", Operator: "<"},
+			err:  ErrEmptyRuleName,
+		},
+		{
+			name: "unknown metric",
+			rule: config.AlertRule{Name: "idle", Metric: "cpu.steal", Operator: "<"},
+			err:  ErrUnknownMetric,
+		},
+		{
+			name: "unknown operator",
+			rule: config.AlertRule{Name: "idle", Metric: "cpu.idle", Operator: "=="},
+			err:  ErrUnknownOperator,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := newRules([]config.AlertRule{tt.rule})
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, rules, 1)
+			require.Equal(t, defaultRuleWindow, rules[0].Window)
+		})
+	}
+
+	t.Run("duplicate names", func(t *testing.T) {
+		rule := config.AlertRule{Name: "idle", Metric: "cpu.idle", Operator: "<"}
+		_, err := newRules([]config.AlertRule{rule, rule})
+		require.ErrorIs(t, err, ErrDuplicateRuleName)
+	})
+}
+
+func TestValidateRules(t *testing.T) {
+	err := ValidateRules([]config.AlertRule{
+		{Name: "idle", Metric: "cpu.steal", Operator: "<"},
+		{Name: "load", Metric: "load_average.load1", Operator: ">"},
+		{Name: "load", Metric: "load_average.load5", Operator: "=="},
+		{Name: "load", Metric: "load_average.load5", Operator: ">"},
+	})
+	require.ErrorIs(t, err, ErrUnknownMetric)
+	require.ErrorIs(t, err, ErrUnknownOperator)
+	require.ErrorIs(t, err, ErrDuplicateRuleName)
+	require.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 3)
+
+	require.NoError(t, ValidateRules(nil))
+}
+
+func TestRuleHysteresis(t *testing.T) {
+	r := rule{AlertRule: config.AlertRule{Operator: "<", Threshold: 10, Hysteresis: 5}}
+
+	require.True(t, r.matches(9))
+	require.False(t, r.matches(10))
+	require.False(t, r.resolved(12))
+	require.True(t, r.resolved(15))
+
+	r = rule{AlertRule: config.AlertRule{Operator: ">=", Threshold: 90}}
+	require.True(t, r.matches(90))
+	require.False(t, r.resolved(90))
+	require.True(t, r.resolved(89.9))
+}
+
+func TestEngine(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{}
+	cfg.Stats.Limit = 100
+
+	newEngine := func(t *testing.T, rules ...config.AlertRule) (*Engine, *metrics.Storage) {
+		t.Helper()
+		storage := metrics.New(cfg.Stats)
+		engine, err := New(storage, config.NewStore(cfg), time.Second, rules)
+		require.NoError(t, err)
+		return engine, storage
+	}
+
+	t.Run("pending, firing and resolved", func(t *testing.T) {
+		engine, _ := newEngine(t, config.AlertRule{
+			Name: "idle_low", Metric: "cpu.idle", Operator: "<", Threshold: 10, For: time.Minute, Hysteresis: 5,
+		})
+		r := engine.rules[0]
+		events, unsubscribe := engine.Subscribe()
+		defer unsubscribe()
+
+		start := time.Now()
+		engine.evaluateTarget(r, "", 5, start)
+		require.Equal(t, models.AlertPending, (<-events).State)
+
+		engine.evaluateTarget(r, "", 6, start.Add(30*time.Second))
+		require.Empty(t, events, "state did not change")
+
+		engine.evaluateTarget(r, "", 7, start.Add(time.Minute))
+		alert := <-events
+		require.Equal(t, models.AlertFiring, alert.State)
+		require.Equal(t, start, alert.ActiveSince)
+		require.Equal(t, 7.0, alert.Value)
+		require.Len(t, engine.Active(), 1)
+
+		engine.evaluateTarget(r, "", 12, start.Add(2*time.Minute))
+		require.Empty(t, events, "value within hysteresis must not resolve the alert")
+
+		engine.evaluateTarget(r, "", 15, start.Add(3*time.Minute))
+		require.Equal(t, models.AlertResolved, (<-events).State)
+		require.Empty(t, engine.Active())
+	})
+
+	t.Run("pending alert clears without firing", func(t *testing.T) {
+		engine, _ := newEngine(t, config.AlertRule{
+			Name: "load_high", Metric: "load_average.load1", Operator: ">", Threshold: 4, For: time.Minute,
+		})
+		r := engine.rules[0]
+		events, unsubscribe := engine.Subscribe()
+		defer unsubscribe()
+
+		engine.evaluateTarget(r, "", 8, time.Now())
+		require.Equal(t, models.AlertPending, (<-events).State)
+
+		engine.evaluateTarget(r, "", 1, time.Now())
+		require.Equal(t, models.AlertInactive, (<-events).State)
+		require.Empty(t, engine.Active())
+	})
+
+	t.Run("target filter", func(t *testing.T) {
+		engine, storage := newEngine(t, config.AlertRule{
+			Name: "var_full", Metric: "filesystem.usage_percent", Target: "/var", Operator: ">", Threshold: 90,
+		})
+
+		storage.Store(config.StatDiskInfo, &models.DiskStats{DiskStats: []models.DiskStat{
+			{FileSystem: "/dev/sda1", MountPoint: "/", Usage: models.DiskUsage{UsagePercent: 95}},
+			{FileSystem: "/dev/sda2", MountPoint: "/var", Usage: models.DiskUsage{UsagePercent: 97}},
+		}}, time.Now())
+		engine.Evaluate(time.Now())
+
+		active := engine.Active()
+		require.Len(t, active, 1)
+		require.Equal(t, "/var", active[0].Target)
+		require.Equal(t, models.AlertFiring, active[0].State)
+	})
+
+	t.Run("forecast stops filling", func(t *testing.T) {
+		engine, _ := newEngine(t, config.AlertRule{
+			Name: "var_filling", Metric: "filesystem.seconds_to_full", Operator: "<", Threshold: 3600,
+		})
+		r := engine.rules[0]
+		events, unsubscribe := engine.Subscribe()
+		defer unsubscribe()
+
+		engine.evaluateTarget(r, "/var", 600, time.Now())
+		require.Equal(t, models.AlertFiring, (<-events).State)
+
+		engine.evaluateTarget(r, "/var", 0, time.Now())
+		require.Equal(t, models.AlertResolved, (<-events).State)
+		require.Empty(t, engine.Active())
+
+		engine.evaluateTarget(r, "/var", 0, time.Now())
+		require.Empty(t, events, "zero forecast must not fire")
+	})
+
+	t.Run("target disappears", func(t *testing.T) {
+		engine, _ := newEngine(t, config.AlertRule{
+			Name: "var_full", Metric: "filesystem.usage_percent", Operator: ">", Threshold: 90,
+		})
+		r := engine.rules[0]
+		events, unsubscribe := engine.Subscribe()
+		defer unsubscribe()
+
+		engine.evaluateTarget(r, "/mnt/usb", 95, time.Now())
+		require.Equal(t, models.AlertFiring, (<-events).State)
+
+		engine.dropMissing(r, map[string]float64{"/": 10}, time.Now())
+		alert := <-events
+		require.Equal(t, models.AlertResolved, alert.State)
+		require.Equal(t, "/mnt/usb", alert.Target)
+		require.Empty(t, engine.Active())
+	})
+}
@@ -0,0 +1,260 @@
+package alerts
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+)
+
+const defaultRuleWindow = 5 * time.Second
+
+var (
+	ErrEmptyRuleName     = errors.New("rule name is empty")
+	ErrDuplicateRuleName = errors.New("duplicate rule name")
+	ErrUnknownMetric     = errors.New("unknown metric")
+	ErrUnknownOperator   = errors.New("unknown operator")
+)
+
+// metricSource достаёт значения метрики из хранилища по целям: диск, точка монтирования
+// или пустая строка для метрик без разбивки.
+type metricSource struct {
+	statType pb.StatType
+	values   func(m *metrics.Storage, cfg config.Stats, window time.Duration) map[string]float64
+	// idle отмечает значения, при которых правило не срабатывает при любом пороге,
+	// например нулевой прогноз: файловая система не заполняется.
+	idle func(value float64) bool
+}
+
+var metricSources = map[string]metricSource{
+	"load_average.load1":  loadAverageSource(func(v float64, _, _ float64) float64 { return v }),
+	"load_average.load5":  loadAverageSource(func(_, v, _ float64) float64 { return v }),
+	"load_average.load15": loadAverageSource(func(_, _, v float64) float64 { return v }),
+
+	"cpu.user":   cpuSource(func(user, _, _ float64) float64 { return user }),
+	"cpu.system": cpuSource(func(_, system, _ float64) float64 { return system }),
+	"cpu.idle":   cpuSource(func(_, _, idle float64) float64 { return idle }),
+
+	"disk_load.tps": disksLoadSource(func(tps, _ float64) float64 { return tps }),
+	"disk_load.kps": disksLoadSource(func(_, kps float64) float64 { return kps }),
+
+	"filesystem.usage_percent":  filesystemSource(func(fs fsValues) float64 { return fs.usagePercent }),
+	"filesystem.inodes_percent": filesystemSource(func(fs fsValues) float64 { return fs.inodesPercent }),
+	// Нулевой прогноз означает, что файловая система не заполняется.
+	"filesystem.seconds_to_full": forecastSource(filesystemSource(func(fs fsValues) float64 {
+		return fs.secondsToFull
+	})),
+	"filesystem.inodes_seconds_to_full": forecastSource(filesystemSource(func(fs fsValues) float64 {
+		return fs.inodesSecondsToFull
+	})),
+}
+
+func MetricNames() []string {
+	names := make([]string, 0, len(metricSources))
+	for name := range metricSources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// aggregate усредняет замеры статистики name за окно правила.
+func aggregate[T any](m *metrics.Storage, cfg config.Stats, name string, window time.Duration) (T, bool) {
+	var zero T
+	sc, ok := stats.ByName(name)
+	if !ok {
+		return zero, false
+	}
+	value, _ := m.Aggregate(name, sc, metrics.Query{
+		Period:      window,
+		Aggregation: metrics.AggregationMean,
+		Interval:    cfg.CollectionFor(name).Interval,
+	})
+	result, ok := value.(T)
+	return result, ok
+}
+
+func loadAverageSource(pick func(load1, load5, load15 float64) float64) metricSource {
+	return metricSource{
+		statType: pb.StatType_LOAD_AVERAGE,
+		values: func(m *metrics.Storage, cfg config.Stats, window time.Duration) map[string]float64 {
+			stats, ok := aggregate[*models.LoadAverage](m, cfg, config.StatLoadAverage, window)
+			if !ok {
+				return nil
+			}
+			return map[string]float64{"": pick(stats.Load1Min, stats.Load5Min, stats.Load15Min)}
+		},
+	}
+}
+
+func cpuSource(pick func(user, system, idle float64) float64) metricSource {
+	return metricSource{
+		statType: pb.StatType_CPU_STATS,
+		values: func(m *metrics.Storage, cfg config.Stats, window time.Duration) map[string]float64 {
+			stats, ok := aggregate[*models.CPUStat](m, cfg, config.StatCPU, window)
+			if !ok {
+				return nil
+			}
+			return map[string]float64{"": pick(stats.User, stats.System, stats.Idle)}
+		},
+	}
+}
+
+func disksLoadSource(pick func(tps, kps float64) float64) metricSource {
+	return metricSource{
+		statType: pb.StatType_DISKS_LOAD,
+		values: func(m *metrics.Storage, cfg config.Stats, window time.Duration) map[string]float64 {
+			stats, ok := aggregate[*models.DisksLoad](m, cfg, config.StatDiskLoad, window)
+			if !ok {
+				return nil
+			}
+			values := make(map[string]float64, len(stats.DisksLoad))
+			for _, disk := range stats.DisksLoad {
+				values[disk.FSName] = pick(disk.Tps, disk.Kps)
+			}
+			return values
+		},
+	}
+}
+
+type fsValues struct {
+	usagePercent        float64
+	inodesPercent       float64
+	secondsToFull       float64
+	inodesSecondsToFull float64
+}
+
+func filesystemSource(pick func(fs fsValues) float64) metricSource {
+	return metricSource{
+		statType: pb.StatType_DISK_USAGE,
+		values: func(m *metrics.Storage, cfg config.Stats, window time.Duration) map[string]float64 {
+			stats, ok := aggregate[*models.DiskStats](m, cfg, config.StatDiskInfo, window)
+			if !ok {
+				return nil
+			}
+			values := make(map[string]float64, len(stats.DiskStats))
+			for _, disk := range stats.DiskStats {
+				values[disk.MountPoint] = pick(fsValues{
+					usagePercent:        disk.Usage.UsagePercent,
+					inodesPercent:       disk.Inodes.UsagePercent,
+					secondsToFull:       disk.Usage.SecondsToFull,
+					inodesSecondsToFull: disk.Inodes.SecondsToFull,
+				})
+			}
+			return values
+		},
+	}
+}
+
+// forecastSource помечает нулевой прогноз как значение, не задевающее правило.
+func forecastSource(source metricSource) metricSource {
+	source.idle = func(value float64) bool { return value == 0 }
+	return source
+}
+
+type rule struct {
+	config.AlertRule
+	source metricSource
+}
+
+func newRule(cfg config.AlertRule) (rule, error) {
+	if cfg.Name == "" {
+		return rule{}, ErrEmptyRuleName
+	}
+
+	source, ok := metricSources[cfg.Metric]
+	if !ok {
+		return rule{}, fmt.Errorf("rule %s: %w: %s", cfg.Name, ErrUnknownMetric, cfg.Metric)
+	}
+
+	switch cfg.Operator {
+	case ">", ">=", "<", "<=":
+	default:
+		return rule{}, fmt.Errorf("rule %s: %w: %s", cfg.Name, ErrUnknownOperator, cfg.Operator)
+	}
+
+	if cfg.Window <= 0 {
+		cfg.Window = defaultRuleWindow
+	}
+
+	return rule{AlertRule: cfg, source: source}, nil
+}
+
+func newRules(cfgs []config.AlertRule) ([]rule, error) {
+	rules := make([]rule, 0, len(cfgs))
+	names := make(map[string]struct{}, len(cfgs))
+	for _, cfg := range cfgs {
+		r, err := newRule(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := names[r.Name]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateRuleName, r.Name)
+		}
+		names[r.Name] = struct{}{}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// ValidateRules проверяет все правила и возвращает все найденные ошибки сразу.
+func ValidateRules(cfgs []config.AlertRule) error {
+	var errs []error
+	names := make(map[string]struct{}, len(cfgs))
+	for i, cfg := range cfgs {
+		if _, err := newRule(cfg); err != nil {
+			errs = append(errs, fmt.Errorf("alerts.rules[%d]: %w", i, err))
+			continue
+		}
+		if _, ok := names[cfg.Name]; ok {
+			errs = append(errs, fmt.Errorf("alerts.rules[%d]: %w: %s", i, ErrDuplicateRuleName, cfg.Name))
+		}
+		names[cfg.Name] = struct{}{}
+	}
+	return errors.Join(errs...)
+}
+
+// matches проверяет, выполняется ли условие правила.
+func (r rule) matches(value float64) bool {
+	if r.source.idle != nil && r.source.idle(value) {
+		return false
+	}
+	switch r.Operator {
+	case ">":
+		return value > r.Threshold
+	case ">=":
+		return value >= r.Threshold
+	case "<":
+		return value < r.Threshold
+	case "<=":
+		return value <= r.Threshold
+	default:
+		return false
+	}
+}
+
+// resolved проверяет, что значение отошло от порога на величину гистерезиса,
+// чтобы сработавшее правило не "дребезжало" около порога.
+func (r rule) resolved(value float64) bool {
+	if r.source.idle != nil && r.source.idle(value) {
+		return true
+	}
+	switch r.Operator {
+	case ">":
+		return value <= r.Threshold-r.Hysteresis
+	case ">=":
+		return value < r.Threshold-r.Hysteresis
+	case "<":
+		return value >= r.Threshold+r.Hysteresis
+	case "<=":
+		return value > r.Threshold+r.Hysteresis
+	default:
+		return true
+	}
+}
@@ -28,6 +28,13 @@ const (
 	StatType_CPU_STATS    StatType = 1
 	StatType_DISKS_LOAD   StatType = 2
 	StatType_DISK_USAGE   StatType = 3
+	// Метрики внешних плагинов из конфигурации.
+	StatType_CUSTOM        StatType = 4
+	StatType_TOP_PROCESSES StatType = 5
+	StatType_PRESSURE      StatType = 6
+	StatType_CGROUPS       StatType = 7
+	// Состояние самого демона.
+	StatType_DAEMON_SELF StatType = 8
 )
 
 // Enum value maps for StatType.
@@ -37,12 +44,22 @@ var (
 		1: "CPU_STATS",
 		2: "DISKS_LOAD",
 		3: "DISK_USAGE",
+		4: "CUSTOM",
+		5: "TOP_PROCESSES",
+		6: "PRESSURE",
+		7: "CGROUPS",
+		8: "DAEMON_SELF",
 	}
 	StatType_value = map[string]int32{
-		"LOAD_AVERAGE": 0,
-		"CPU_STATS":    1,
-		"DISKS_LOAD":   2,
-		"DISK_USAGE":   3,
+		"LOAD_AVERAGE":  0,
+		"CPU_STATS":     1,
+		"DISKS_LOAD":    2,
+		"DISK_USAGE":    3,
+		"CUSTOM":        4,
+		"TOP_PROCESSES": 5,
+		"PRESSURE":      6,
+		"CGROUPS":       7,
+		"DAEMON_SELF":   8,
 	}
 )
 
@@ -73,13 +90,132 @@ func (StatType) EnumDescriptor() ([]byte, []int) {
 	return file_stats_proto_rawDescGZIP(), []int{0}
 }
 
+type Aggregation int32
+
+const (
+	Aggregation_MEAN Aggregation = 0
+	Aggregation_MIN  Aggregation = 1
+	Aggregation_MAX  Aggregation = 2
+	Aggregation_P50  Aggregation = 3
+	Aggregation_P95  Aggregation = 4
+	Aggregation_P99  Aggregation = 5
+	Aggregation_LAST Aggregation = 6
+)
+
+// Enum value maps for Aggregation.
+var (
+	Aggregation_name = map[int32]string{
+		0: "MEAN",
+		1: "MIN",
+		2: "MAX",
+		3: "P50",
+		4: "P95",
+		5: "P99",
+		6: "LAST",
+	}
+	Aggregation_value = map[string]int32{
+		"MEAN": 0,
+		"MIN":  1,
+		"MAX":  2,
+		"P50":  3,
+		"P95":  4,
+		"P99":  5,
+		"LAST": 6,
+	}
+)
+
+func (x Aggregation) Enum() *Aggregation {
+	p := new(Aggregation)
+	*p = x
+	return p
+}
+
+func (x Aggregation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Aggregation) Descriptor() protoreflect.EnumDescriptor {
+	return file_stats_proto_enumTypes[1].Descriptor()
+}
+
+func (Aggregation) Type() protoreflect.EnumType {
+	return &file_stats_proto_enumTypes[1]
+}
+
+func (x Aggregation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Aggregation.Descriptor instead.
+func (Aggregation) EnumDescriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{1}
+}
+
+type AlertState int32
+
+const (
+	AlertState_INACTIVE AlertState = 0
+	AlertState_PENDING  AlertState = 1
+	AlertState_FIRING   AlertState = 2
+	AlertState_RESOLVED AlertState = 3
+)
+
+// Enum value maps for AlertState.
+var (
+	AlertState_name = map[int32]string{
+		0: "INACTIVE",
+		1: "PENDING",
+		2: "FIRING",
+		3: "RESOLVED",
+	}
+	AlertState_value = map[string]int32{
+		"INACTIVE": 0,
+		"PENDING":  1,
+		"FIRING":   2,
+		"RESOLVED": 3,
+	}
+)
+
+func (x AlertState) Enum() *AlertState {
+	p := new(AlertState)
+	*p = x
+	return p
+}
+
+func (x AlertState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertState) Descriptor() protoreflect.EnumDescriptor {
+	return file_stats_proto_enumTypes[2].Descriptor()
+}
+
+func (AlertState) Type() protoreflect.EnumType {
+	return &file_stats_proto_enumTypes[2]
+}
+
+func (x AlertState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertState.Descriptor instead.
+func (AlertState) EnumDescriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{2}
+}
+
 type StatsRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	IntervalN        int32                  `protobuf:"varint,1,opt,name=interval_n,json=intervalN,proto3" json:"interval_n,omitempty"`
 	AveragingPeriodM int32                  `protobuf:"varint,2,opt,name=averaging_period_m,json=averagingPeriodM,proto3" json:"averaging_period_m,omitempty"`
 	StatTypes        []StatType             `protobuf:"varint,3,rep,packed,name=stat_types,json=statTypes,proto3,enum=stats_service.StatType" json:"stat_types,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	Aggregation      Aggregation            `protobuf:"varint,4,opt,name=aggregation,proto3,enum=stats_service.Aggregation" json:"aggregation,omitempty"`
+	// Количество процессов в TOP_PROCESSES, 0 — значение по умолчанию.
+	TopN int32 `protobuf:"varint,5,opt,name=top_n,json=topN,proto3" json:"top_n,omitempty"`
+	// Время последнего полученного ответа (unix, секунды). Если задано, сервер
+	// сначала досылает пропущенные с этого момента ответы по сохранённым замерам.
+	Since         int64 `protobuf:"varint,6,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StatsRequest) Reset() {
@@ -133,13 +269,47 @@ func (x *StatsRequest) GetStatTypes() []StatType {
 	return nil
 }
 
+func (x *StatsRequest) GetAggregation() Aggregation {
+	if x != nil {
+		return x.Aggregation
+	}
+	return Aggregation_MEAN
+}
+
+func (x *StatsRequest) GetTopN() int32 {
+	if x != nil {
+		return x.TopN
+	}
+	return 0
+}
+
+func (x *StatsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
 type StatsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	LoadAverage   *LoadAverage           `protobuf:"bytes,2,opt,name=load_average,json=loadAverage,proto3" json:"load_average,omitempty"`
-	CpuStats      *CPUStat               `protobuf:"bytes,3,opt,name=cpu_stats,json=cpuStats,proto3" json:"cpu_stats,omitempty"`
-	DisksLoad     *DisksLoad             `protobuf:"bytes,4,opt,name=disks_load,json=disksLoad,proto3" json:"disks_load,omitempty"`
-	DiskStats     *DiskStats             `protobuf:"bytes,5,opt,name=disk_stats,json=diskStats,proto3" json:"disk_stats,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp   int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	LoadAverage *LoadAverage           `protobuf:"bytes,2,opt,name=load_average,json=loadAverage,proto3" json:"load_average,omitempty"`
+	CpuStats    *CPUStat               `protobuf:"bytes,3,opt,name=cpu_stats,json=cpuStats,proto3" json:"cpu_stats,omitempty"`
+	DisksLoad   *DisksLoad             `protobuf:"bytes,4,opt,name=disks_load,json=disksLoad,proto3" json:"disks_load,omitempty"`
+	DiskStats   *DiskStats             `protobuf:"bytes,5,opt,name=disk_stats,json=diskStats,proto3" json:"disk_stats,omitempty"`
+	Aggregation Aggregation            `protobuf:"varint,6,opt,name=aggregation,proto3,enum=stats_service.Aggregation" json:"aggregation,omitempty"`
+	Coverage    []*StatCoverage        `protobuf:"bytes,7,rep,name=coverage,proto3" json:"coverage,omitempty"`
+	// Запрошенные типы статистики, выключенные в конфигурации после начала подписки.
+	DisabledStatTypes []StatType      `protobuf:"varint,8,rep,packed,name=disabled_stat_types,json=disabledStatTypes,proto3,enum=stats_service.StatType" json:"disabled_stat_types,omitempty"`
+	CustomMetrics     []*CustomMetric `protobuf:"bytes,9,rep,name=custom_metrics,json=customMetrics,proto3" json:"custom_metrics,omitempty"`
+	TopProcesses      *TopProcesses   `protobuf:"bytes,10,opt,name=top_processes,json=topProcesses,proto3" json:"top_processes,omitempty"`
+	Pressure          *Pressure       `protobuf:"bytes,11,opt,name=pressure,proto3" json:"pressure,omitempty"`
+	Cgroups           *Cgroups        `protobuf:"bytes,12,opt,name=cgroups,proto3" json:"cgroups,omitempty"`
+	// Запрошенные типы статистики, последний сбор которых завершился ошибкой.
+	Errors     []*CollectionError `protobuf:"bytes,13,rep,name=errors,proto3" json:"errors,omitempty"`
+	DaemonSelf *DaemonSelf        `protobuf:"bytes,14,opt,name=daemon_self,json=daemonSelf,proto3" json:"daemon_self,omitempty"`
+	// Ответ восстановлен по истории после переподключения клиента.
+	Backfill      bool `protobuf:"varint,15,opt,name=backfill,proto3" json:"backfill,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -209,29 +379,99 @@ func (x *StatsResponse) GetDiskStats() *DiskStats {
 	return nil
 }
 
-type LoadAverage struct {
+func (x *StatsResponse) GetAggregation() Aggregation {
+	if x != nil {
+		return x.Aggregation
+	}
+	return Aggregation_MEAN
+}
+
+func (x *StatsResponse) GetCoverage() []*StatCoverage {
+	if x != nil {
+		return x.Coverage
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetDisabledStatTypes() []StatType {
+	if x != nil {
+		return x.DisabledStatTypes
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetCustomMetrics() []*CustomMetric {
+	if x != nil {
+		return x.CustomMetrics
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetTopProcesses() *TopProcesses {
+	if x != nil {
+		return x.TopProcesses
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetPressure() *Pressure {
+	if x != nil {
+		return x.Pressure
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetCgroups() *Cgroups {
+	if x != nil {
+		return x.Cgroups
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetErrors() []*CollectionError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetDaemonSelf() *DaemonSelf {
+	if x != nil {
+		return x.DaemonSelf
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetBackfill() bool {
+	if x != nil {
+		return x.Backfill
+	}
+	return false
+}
+
+type StatCoverage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Load1Min      float64                `protobuf:"fixed64,1,opt,name=load1min,proto3" json:"load1min,omitempty"`
-	Load5Min      float64                `protobuf:"fixed64,2,opt,name=load5min,proto3" json:"load5min,omitempty"`
-	Load15Min     float64                `protobuf:"fixed64,3,opt,name=load15min,proto3" json:"load15min,omitempty"`
+	StatType      StatType               `protobuf:"varint,1,opt,name=stat_type,json=statType,proto3,enum=stats_service.StatType" json:"stat_type,omitempty"`
+	SampleCount   int32                  `protobuf:"varint,2,opt,name=sample_count,json=sampleCount,proto3" json:"sample_count,omitempty"`
+	Coverage      float64                `protobuf:"fixed64,3,opt,name=coverage,proto3" json:"coverage,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoadAverage) Reset() {
-	*x = LoadAverage{}
+func (x *StatCoverage) Reset() {
+	*x = StatCoverage{}
 	mi := &file_stats_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoadAverage) String() string {
+func (x *StatCoverage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoadAverage) ProtoMessage() {}
+func (*StatCoverage) ProtoMessage() {}
 
-func (x *LoadAverage) ProtoReflect() protoreflect.Message {
+func (x *StatCoverage) ProtoReflect() protoreflect.Message {
 	mi := &file_stats_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -243,55 +483,60 @@ func (x *LoadAverage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoadAverage.ProtoReflect.Descriptor instead.
-func (*LoadAverage) Descriptor() ([]byte, []int) {
+// Deprecated: Use StatCoverage.ProtoReflect.Descriptor instead.
+func (*StatCoverage) Descriptor() ([]byte, []int) {
 	return file_stats_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *LoadAverage) GetLoad1Min() float64 {
+func (x *StatCoverage) GetStatType() StatType {
 	if x != nil {
-		return x.Load1Min
+		return x.StatType
 	}
-	return 0
+	return StatType_LOAD_AVERAGE
 }
 
-func (x *LoadAverage) GetLoad5Min() float64 {
+func (x *StatCoverage) GetSampleCount() int32 {
 	if x != nil {
-		return x.Load5Min
+		return x.SampleCount
 	}
 	return 0
 }
 
-func (x *LoadAverage) GetLoad15Min() float64 {
+func (x *StatCoverage) GetCoverage() float64 {
 	if x != nil {
-		return x.Load15Min
+		return x.Coverage
 	}
 	return 0
 }
 
-type CPUStat struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          float64                `protobuf:"fixed64,1,opt,name=user,proto3" json:"user,omitempty"`
-	System        float64                `protobuf:"fixed64,2,opt,name=system,proto3" json:"system,omitempty"`
-	Idle          float64                `protobuf:"fixed64,3,opt,name=idle,proto3" json:"idle,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type DaemonSelf struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Goroutines int32                  `protobuf:"varint,1,opt,name=goroutines,proto3" json:"goroutines,omitempty"`
+	HeapBytes  uint64                 `protobuf:"varint,2,opt,name=heap_bytes,json=heapBytes,proto3" json:"heap_bytes,omitempty"`
+	// Количество циклов GC и суммарная пауза на них с запуска демона.
+	GcCycles       uint64             `protobuf:"varint,3,opt,name=gc_cycles,json=gcCycles,proto3" json:"gc_cycles,omitempty"`
+	GcPauseSeconds float64            `protobuf:"fixed64,4,opt,name=gc_pause_seconds,json=gcPauseSeconds,proto3" json:"gc_pause_seconds,omitempty"`
+	ActiveStreams  int32              `protobuf:"varint,5,opt,name=active_streams,json=activeStreams,proto3" json:"active_streams,omitempty"`
+	CleanerRuns    int64              `protobuf:"varint,6,opt,name=cleaner_runs,json=cleanerRuns,proto3" json:"cleaner_runs,omitempty"`
+	Collectors     []*DaemonCollector `protobuf:"bytes,7,rep,name=collectors,proto3" json:"collectors,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *CPUStat) Reset() {
-	*x = CPUStat{}
+func (x *DaemonSelf) Reset() {
+	*x = DaemonSelf{}
 	mi := &file_stats_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CPUStat) String() string {
+func (x *DaemonSelf) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CPUStat) ProtoMessage() {}
+func (*DaemonSelf) ProtoMessage() {}
 
-func (x *CPUStat) ProtoReflect() protoreflect.Message {
+func (x *DaemonSelf) ProtoReflect() protoreflect.Message {
 	mi := &file_stats_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -303,53 +548,87 @@ func (x *CPUStat) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CPUStat.ProtoReflect.Descriptor instead.
-func (*CPUStat) Descriptor() ([]byte, []int) {
+// Deprecated: Use DaemonSelf.ProtoReflect.Descriptor instead.
+func (*DaemonSelf) Descriptor() ([]byte, []int) {
 	return file_stats_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *CPUStat) GetUser() float64 {
+func (x *DaemonSelf) GetGoroutines() int32 {
 	if x != nil {
-		return x.User
+		return x.Goroutines
 	}
 	return 0
 }
 
-func (x *CPUStat) GetSystem() float64 {
+func (x *DaemonSelf) GetHeapBytes() uint64 {
 	if x != nil {
-		return x.System
+		return x.HeapBytes
 	}
 	return 0
 }
 
-func (x *CPUStat) GetIdle() float64 {
+func (x *DaemonSelf) GetGcCycles() uint64 {
 	if x != nil {
-		return x.Idle
+		return x.GcCycles
 	}
 	return 0
 }
 
-type DisksLoad struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DisksLoad     []*DiskLoad            `protobuf:"bytes,1,rep,name=disks_load,json=disksLoad,proto3" json:"disks_load,omitempty"`
+func (x *DaemonSelf) GetGcPauseSeconds() float64 {
+	if x != nil {
+		return x.GcPauseSeconds
+	}
+	return 0
+}
+
+func (x *DaemonSelf) GetActiveStreams() int32 {
+	if x != nil {
+		return x.ActiveStreams
+	}
+	return 0
+}
+
+func (x *DaemonSelf) GetCleanerRuns() int64 {
+	if x != nil {
+		return x.CleanerRuns
+	}
+	return 0
+}
+
+func (x *DaemonSelf) GetCollectors() []*DaemonCollector {
+	if x != nil {
+		return x.Collectors
+	}
+	return nil
+}
+
+type DaemonCollector struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	StatType            StatType               `protobuf:"varint,1,opt,name=stat_type,json=statType,proto3,enum=stats_service.StatType" json:"stat_type,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	LastDurationSeconds float64                `protobuf:"fixed64,3,opt,name=last_duration_seconds,json=lastDurationSeconds,proto3" json:"last_duration_seconds,omitempty"`
+	Runs                int64                  `protobuf:"varint,4,opt,name=runs,proto3" json:"runs,omitempty"`
+	Errors              int64                  `protobuf:"varint,5,opt,name=errors,proto3" json:"errors,omitempty"`
+	// Количество замеров этой статистики в хранилище.
+	StoredSamples int64 `protobuf:"varint,6,opt,name=stored_samples,json=storedSamples,proto3" json:"stored_samples,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DisksLoad) Reset() {
-	*x = DisksLoad{}
+func (x *DaemonCollector) Reset() {
+	*x = DaemonCollector{}
 	mi := &file_stats_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DisksLoad) String() string {
+func (x *DaemonCollector) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DisksLoad) ProtoMessage() {}
+func (*DaemonCollector) ProtoMessage() {}
 
-func (x *DisksLoad) ProtoReflect() protoreflect.Message {
+func (x *DaemonCollector) ProtoReflect() protoreflect.Message {
 	mi := &file_stats_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -361,41 +640,78 @@ func (x *DisksLoad) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DisksLoad.ProtoReflect.Descriptor instead.
-func (*DisksLoad) Descriptor() ([]byte, []int) {
+// Deprecated: Use DaemonCollector.ProtoReflect.Descriptor instead.
+func (*DaemonCollector) Descriptor() ([]byte, []int) {
 	return file_stats_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *DisksLoad) GetDisksLoad() []*DiskLoad {
+func (x *DaemonCollector) GetStatType() StatType {
 	if x != nil {
-		return x.DisksLoad
+		return x.StatType
 	}
-	return nil
+	return StatType_LOAD_AVERAGE
 }
 
-type DiskLoad struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FsName        string                 `protobuf:"bytes,1,opt,name=fs_name,json=fsName,proto3" json:"fs_name,omitempty"`
-	Tps           float64                `protobuf:"fixed64,2,opt,name=tps,proto3" json:"tps,omitempty"`
-	Kps           float64                `protobuf:"fixed64,3,opt,name=kps,proto3" json:"kps,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *DaemonCollector) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *DiskLoad) Reset() {
-	*x = DiskLoad{}
+func (x *DaemonCollector) GetLastDurationSeconds() float64 {
+	if x != nil {
+		return x.LastDurationSeconds
+	}
+	return 0
+}
+
+func (x *DaemonCollector) GetRuns() int64 {
+	if x != nil {
+		return x.Runs
+	}
+	return 0
+}
+
+func (x *DaemonCollector) GetErrors() int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *DaemonCollector) GetStoredSamples() int64 {
+	if x != nil {
+		return x.StoredSamples
+	}
+	return 0
+}
+
+type CollectionError struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	StatType StatType               `protobuf:"varint,1,opt,name=stat_type,json=statType,proto3,enum=stats_service.StatType" json:"stat_type,omitempty"`
+	Message  string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Время первой из идущих подряд ошибок.
+	Since               int64 `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`
+	ConsecutiveFailures int32 `protobuf:"varint,4,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CollectionError) Reset() {
+	*x = CollectionError{}
 	mi := &file_stats_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DiskLoad) String() string {
+func (x *CollectionError) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DiskLoad) ProtoMessage() {}
+func (*CollectionError) ProtoMessage() {}
 
-func (x *DiskLoad) ProtoReflect() protoreflect.Message {
+func (x *CollectionError) ProtoReflect() protoreflect.Message {
 	mi := &file_stats_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -407,53 +723,62 @@ func (x *DiskLoad) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DiskLoad.ProtoReflect.Descriptor instead.
-func (*DiskLoad) Descriptor() ([]byte, []int) {
+// Deprecated: Use CollectionError.ProtoReflect.Descriptor instead.
+func (*CollectionError) Descriptor() ([]byte, []int) {
 	return file_stats_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *DiskLoad) GetFsName() string {
+func (x *CollectionError) GetStatType() StatType {
 	if x != nil {
-		return x.FsName
+		return x.StatType
+	}
+	return StatType_LOAD_AVERAGE
+}
+
+func (x *CollectionError) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *DiskLoad) GetTps() float64 {
+func (x *CollectionError) GetSince() int64 {
 	if x != nil {
-		return x.Tps
+		return x.Since
 	}
 	return 0
 }
 
-func (x *DiskLoad) GetKps() float64 {
+func (x *CollectionError) GetConsecutiveFailures() int32 {
 	if x != nil {
-		return x.Kps
+		return x.ConsecutiveFailures
 	}
 	return 0
 }
 
-type DiskStats struct {
+type CustomMetric struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DiskStats     []*DiskStat            `protobuf:"bytes,1,rep,name=disk_stats,json=diskStats,proto3" json:"disk_stats,omitempty"`
+	Plugin        string                 `protobuf:"bytes,1,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Value         float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DiskStats) Reset() {
-	*x = DiskStats{}
+func (x *CustomMetric) Reset() {
+	*x = CustomMetric{}
 	mi := &file_stats_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DiskStats) String() string {
+func (x *CustomMetric) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DiskStats) ProtoMessage() {}
+func (*CustomMetric) ProtoMessage() {}
 
-func (x *DiskStats) ProtoReflect() protoreflect.Message {
+func (x *CustomMetric) ProtoReflect() protoreflect.Message {
 	mi := &file_stats_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -465,41 +790,54 @@ func (x *DiskStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DiskStats.ProtoReflect.Descriptor instead.
-func (*DiskStats) Descriptor() ([]byte, []int) {
+// Deprecated: Use CustomMetric.ProtoReflect.Descriptor instead.
+func (*CustomMetric) Descriptor() ([]byte, []int) {
 	return file_stats_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *DiskStats) GetDiskStats() []*DiskStat {
+func (x *CustomMetric) GetPlugin() string {
 	if x != nil {
-		return x.DiskStats
+		return x.Plugin
 	}
-	return nil
-}
-
-type DiskStat struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filesystem    string                 `protobuf:"bytes,1,opt,name=filesystem,proto3" json:"filesystem,omitempty"`
-	Usage         *DiskUsage             `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
-	Inodes        *InodeUsage            `protobuf:"bytes,3,opt,name=inodes,proto3" json:"inodes,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return ""
 }
 
-func (x *DiskStat) Reset() {
-	*x = DiskStat{}
+func (x *CustomMetric) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomMetric) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type TopProcesses struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ByCpu         []*Process             `protobuf:"bytes,1,rep,name=by_cpu,json=byCpu,proto3" json:"by_cpu,omitempty"`
+	ByMemory      []*Process             `protobuf:"bytes,2,rep,name=by_memory,json=byMemory,proto3" json:"by_memory,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopProcesses) Reset() {
+	*x = TopProcesses{}
 	mi := &file_stats_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DiskStat) String() string {
+func (x *TopProcesses) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DiskStat) ProtoMessage() {}
+func (*TopProcesses) ProtoMessage() {}
 
-func (x *DiskStat) ProtoReflect() protoreflect.Message {
+func (x *TopProcesses) ProtoReflect() protoreflect.Message {
 	mi := &file_stats_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -511,54 +849,51 @@ func (x *DiskStat) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DiskStat.ProtoReflect.Descriptor instead.
-func (*DiskStat) Descriptor() ([]byte, []int) {
+// Deprecated: Use TopProcesses.ProtoReflect.Descriptor instead.
+func (*TopProcesses) Descriptor() ([]byte, []int) {
 	return file_stats_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *DiskStat) GetFilesystem() string {
-	if x != nil {
-		return x.Filesystem
-	}
-	return ""
-}
-
-func (x *DiskStat) GetUsage() *DiskUsage {
+func (x *TopProcesses) GetByCpu() []*Process {
 	if x != nil {
-		return x.Usage
+		return x.ByCpu
 	}
 	return nil
 }
 
-func (x *DiskStat) GetInodes() *InodeUsage {
+func (x *TopProcesses) GetByMemory() []*Process {
 	if x != nil {
-		return x.Inodes
+		return x.ByMemory
 	}
 	return nil
 }
 
-type DiskUsage struct {
+type Process struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Used          uint64                 `protobuf:"varint,1,opt,name=used,proto3" json:"used,omitempty"`
-	Usage         string                 `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
+	Pid           int32                  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	User          string                 `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	Threads       int32                  `protobuf:"varint,4,opt,name=threads,proto3" json:"threads,omitempty"`
+	CpuPercent    float64                `protobuf:"fixed64,5,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	RssBytes      uint64                 `protobuf:"varint,6,opt,name=rss_bytes,json=rssBytes,proto3" json:"rss_bytes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DiskUsage) Reset() {
-	*x = DiskUsage{}
+func (x *Process) Reset() {
+	*x = Process{}
 	mi := &file_stats_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DiskUsage) String() string {
+func (x *Process) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DiskUsage) ProtoMessage() {}
+func (*Process) ProtoMessage() {}
 
-func (x *DiskUsage) ProtoReflect() protoreflect.Message {
+func (x *Process) ProtoReflect() protoreflect.Message {
 	mi := &file_stats_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -570,47 +905,79 @@ func (x *DiskUsage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DiskUsage.ProtoReflect.Descriptor instead.
-func (*DiskUsage) Descriptor() ([]byte, []int) {
+// Deprecated: Use Process.ProtoReflect.Descriptor instead.
+func (*Process) Descriptor() ([]byte, []int) {
 	return file_stats_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *DiskUsage) GetUsed() uint64 {
+func (x *Process) GetPid() int32 {
 	if x != nil {
-		return x.Used
+		return x.Pid
 	}
 	return 0
 }
 
-func (x *DiskUsage) GetUsage() string {
+func (x *Process) GetCommand() string {
 	if x != nil {
-		return x.Usage
+		return x.Command
 	}
 	return ""
 }
 
-type InodeUsage struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Used          uint64                 `protobuf:"varint,1,opt,name=used,proto3" json:"used,omitempty"`
-	Usage         string                 `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
+func (x *Process) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *Process) GetThreads() int32 {
+	if x != nil {
+		return x.Threads
+	}
+	return 0
+}
+
+func (x *Process) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *Process) GetRssBytes() uint64 {
+	if x != nil {
+		return x.RssBytes
+	}
+	return 0
+}
+
+// Pressure Stall Information из /proc/pressure.
+type Pressure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// false, если ядро собрано без PSI или PSI выключен.
+	Supported     bool              `protobuf:"varint,1,opt,name=supported,proto3" json:"supported,omitempty"`
+	Cpu           *PressureResource `protobuf:"bytes,2,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Memory        *PressureResource `protobuf:"bytes,3,opt,name=memory,proto3" json:"memory,omitempty"`
+	Io            *PressureResource `protobuf:"bytes,4,opt,name=io,proto3" json:"io,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InodeUsage) Reset() {
-	*x = InodeUsage{}
+func (x *Pressure) Reset() {
+	*x = Pressure{}
 	mi := &file_stats_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InodeUsage) String() string {
+func (x *Pressure) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InodeUsage) ProtoMessage() {}
+func (*Pressure) ProtoMessage() {}
 
-func (x *InodeUsage) ProtoReflect() protoreflect.Message {
+func (x *Pressure) ProtoReflect() protoreflect.Message {
 	mi := &file_stats_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -622,30 +989,1433 @@ func (x *InodeUsage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InodeUsage.ProtoReflect.Descriptor instead.
-func (*InodeUsage) Descriptor() ([]byte, []int) {
+// Deprecated: Use Pressure.ProtoReflect.Descriptor instead.
+func (*Pressure) Descriptor() ([]byte, []int) {
 	return file_stats_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *InodeUsage) GetUsed() uint64 {
+func (x *Pressure) GetSupported() bool {
 	if x != nil {
-		return x.Used
+		return x.Supported
+	}
+	return false
+}
+
+func (x *Pressure) GetCpu() *PressureResource {
+	if x != nil {
+		return x.Cpu
+	}
+	return nil
+}
+
+func (x *Pressure) GetMemory() *PressureResource {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *Pressure) GetIo() *PressureResource {
+	if x != nil {
+		return x.Io
+	}
+	return nil
+}
+
+type PressureResource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Some          *PressureStall         `protobuf:"bytes,1,opt,name=some,proto3" json:"some,omitempty"`
+	Full          *PressureStall         `protobuf:"bytes,2,opt,name=full,proto3" json:"full,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PressureResource) Reset() {
+	*x = PressureResource{}
+	mi := &file_stats_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PressureResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PressureResource) ProtoMessage() {}
+
+func (x *PressureResource) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PressureResource.ProtoReflect.Descriptor instead.
+func (*PressureResource) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PressureResource) GetSome() *PressureStall {
+	if x != nil {
+		return x.Some
+	}
+	return nil
+}
+
+func (x *PressureResource) GetFull() *PressureStall {
+	if x != nil {
+		return x.Full
+	}
+	return nil
+}
+
+type PressureStall struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Avg10  float64                `protobuf:"fixed64,1,opt,name=avg10,proto3" json:"avg10,omitempty"`
+	Avg60  float64                `protobuf:"fixed64,2,opt,name=avg60,proto3" json:"avg60,omitempty"`
+	Avg300 float64                `protobuf:"fixed64,3,opt,name=avg300,proto3" json:"avg300,omitempty"`
+	// Время простоя за окно усреднения в микросекундах.
+	TotalDeltaUs  uint64 `protobuf:"varint,4,opt,name=total_delta_us,json=totalDeltaUs,proto3" json:"total_delta_us,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PressureStall) Reset() {
+	*x = PressureStall{}
+	mi := &file_stats_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PressureStall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PressureStall) ProtoMessage() {}
+
+func (x *PressureStall) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PressureStall.ProtoReflect.Descriptor instead.
+func (*PressureStall) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PressureStall) GetAvg10() float64 {
+	if x != nil {
+		return x.Avg10
 	}
 	return 0
 }
 
-func (x *InodeUsage) GetUsage() string {
+func (x *PressureStall) GetAvg60() float64 {
 	if x != nil {
-		return x.Usage
+		return x.Avg60
+	}
+	return 0
+}
+
+func (x *PressureStall) GetAvg300() float64 {
+	if x != nil {
+		return x.Avg300
+	}
+	return 0
+}
+
+func (x *PressureStall) GetTotalDeltaUs() uint64 {
+	if x != nil {
+		return x.TotalDeltaUs
+	}
+	return 0
+}
+
+type Cgroups struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cgroups       []*Cgroup              `protobuf:"bytes,1,rep,name=cgroups,proto3" json:"cgroups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cgroups) Reset() {
+	*x = Cgroups{}
+	mi := &file_stats_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cgroups) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cgroups) ProtoMessage() {}
+
+func (x *Cgroups) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cgroups.ProtoReflect.Descriptor instead.
+func (*Cgroups) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Cgroups) GetCgroups() []*Cgroup {
+	if x != nil {
+		return x.Cgroups
+	}
+	return nil
+}
+
+type Cgroup struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Path               string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	CpuPercent         float64                `protobuf:"fixed64,2,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	MemoryCurrentBytes uint64                 `protobuf:"varint,3,opt,name=memory_current_bytes,json=memoryCurrentBytes,proto3" json:"memory_current_bytes,omitempty"`
+	// 0, если лимит памяти не задан.
+	MemoryMaxBytes uint64 `protobuf:"varint,4,opt,name=memory_max_bytes,json=memoryMaxBytes,proto3" json:"memory_max_bytes,omitempty"`
+	// Прочитано и записано за окно усреднения.
+	IoReadBytes   uint64 `protobuf:"varint,5,opt,name=io_read_bytes,json=ioReadBytes,proto3" json:"io_read_bytes,omitempty"`
+	IoWriteBytes  uint64 `protobuf:"varint,6,opt,name=io_write_bytes,json=ioWriteBytes,proto3" json:"io_write_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cgroup) Reset() {
+	*x = Cgroup{}
+	mi := &file_stats_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cgroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cgroup) ProtoMessage() {}
+
+func (x *Cgroup) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cgroup.ProtoReflect.Descriptor instead.
+func (*Cgroup) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Cgroup) GetPath() string {
+	if x != nil {
+		return x.Path
 	}
 	return ""
 }
 
+func (x *Cgroup) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *Cgroup) GetMemoryCurrentBytes() uint64 {
+	if x != nil {
+		return x.MemoryCurrentBytes
+	}
+	return 0
+}
+
+func (x *Cgroup) GetMemoryMaxBytes() uint64 {
+	if x != nil {
+		return x.MemoryMaxBytes
+	}
+	return 0
+}
+
+func (x *Cgroup) GetIoReadBytes() uint64 {
+	if x != nil {
+		return x.IoReadBytes
+	}
+	return 0
+}
+
+func (x *Cgroup) GetIoWriteBytes() uint64 {
+	if x != nil {
+		return x.IoWriteBytes
+	}
+	return 0
+}
+
+type LoadAverage struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Load1Min  float64                `protobuf:"fixed64,1,opt,name=load1min,proto3" json:"load1min,omitempty"`
+	Load5Min  float64                `protobuf:"fixed64,2,opt,name=load5min,proto3" json:"load5min,omitempty"`
+	Load15Min float64                `protobuf:"fixed64,3,opt,name=load15min,proto3" json:"load15min,omitempty"`
+	// Из /proc/loadavg; при чтении через sysinfo runnable_tasks и last_pid нулевые.
+	RunnableTasks int32 `protobuf:"varint,4,opt,name=runnable_tasks,json=runnableTasks,proto3" json:"runnable_tasks,omitempty"`
+	TotalTasks    int32 `protobuf:"varint,5,opt,name=total_tasks,json=totalTasks,proto3" json:"total_tasks,omitempty"`
+	LastPid       int32 `protobuf:"varint,6,opt,name=last_pid,json=lastPid,proto3" json:"last_pid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadAverage) Reset() {
+	*x = LoadAverage{}
+	mi := &file_stats_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadAverage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadAverage) ProtoMessage() {}
+
+func (x *LoadAverage) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadAverage.ProtoReflect.Descriptor instead.
+func (*LoadAverage) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *LoadAverage) GetLoad1Min() float64 {
+	if x != nil {
+		return x.Load1Min
+	}
+	return 0
+}
+
+func (x *LoadAverage) GetLoad5Min() float64 {
+	if x != nil {
+		return x.Load5Min
+	}
+	return 0
+}
+
+func (x *LoadAverage) GetLoad15Min() float64 {
+	if x != nil {
+		return x.Load15Min
+	}
+	return 0
+}
+
+func (x *LoadAverage) GetRunnableTasks() int32 {
+	if x != nil {
+		return x.RunnableTasks
+	}
+	return 0
+}
+
+func (x *LoadAverage) GetTotalTasks() int32 {
+	if x != nil {
+		return x.TotalTasks
+	}
+	return 0
+}
+
+func (x *LoadAverage) GetLastPid() int32 {
+	if x != nil {
+		return x.LastPid
+	}
+	return 0
+}
+
+type CPUStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          float64                `protobuf:"fixed64,1,opt,name=user,proto3" json:"user,omitempty"`
+	System        float64                `protobuf:"fixed64,2,opt,name=system,proto3" json:"system,omitempty"`
+	Idle          float64                `protobuf:"fixed64,3,opt,name=idle,proto3" json:"idle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CPUStat) Reset() {
+	*x = CPUStat{}
+	mi := &file_stats_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CPUStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CPUStat) ProtoMessage() {}
+
+func (x *CPUStat) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CPUStat.ProtoReflect.Descriptor instead.
+func (*CPUStat) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CPUStat) GetUser() float64 {
+	if x != nil {
+		return x.User
+	}
+	return 0
+}
+
+func (x *CPUStat) GetSystem() float64 {
+	if x != nil {
+		return x.System
+	}
+	return 0
+}
+
+func (x *CPUStat) GetIdle() float64 {
+	if x != nil {
+		return x.Idle
+	}
+	return 0
+}
+
+type DisksLoad struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisksLoad     []*DiskLoad            `protobuf:"bytes,1,rep,name=disks_load,json=disksLoad,proto3" json:"disks_load,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisksLoad) Reset() {
+	*x = DisksLoad{}
+	mi := &file_stats_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisksLoad) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisksLoad) ProtoMessage() {}
+
+func (x *DisksLoad) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisksLoad.ProtoReflect.Descriptor instead.
+func (*DisksLoad) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DisksLoad) GetDisksLoad() []*DiskLoad {
+	if x != nil {
+		return x.DisksLoad
+	}
+	return nil
+}
+
+type DiskLoad struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FsName        string                 `protobuf:"bytes,1,opt,name=fs_name,json=fsName,proto3" json:"fs_name,omitempty"`
+	Tps           float64                `protobuf:"fixed64,2,opt,name=tps,proto3" json:"tps,omitempty"`
+	Kps           float64                `protobuf:"fixed64,3,opt,name=kps,proto3" json:"kps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiskLoad) Reset() {
+	*x = DiskLoad{}
+	mi := &file_stats_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiskLoad) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskLoad) ProtoMessage() {}
+
+func (x *DiskLoad) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskLoad.ProtoReflect.Descriptor instead.
+func (*DiskLoad) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DiskLoad) GetFsName() string {
+	if x != nil {
+		return x.FsName
+	}
+	return ""
+}
+
+func (x *DiskLoad) GetTps() float64 {
+	if x != nil {
+		return x.Tps
+	}
+	return 0
+}
+
+func (x *DiskLoad) GetKps() float64 {
+	if x != nil {
+		return x.Kps
+	}
+	return 0
+}
+
+type DiskStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DiskStats     []*DiskStat            `protobuf:"bytes,1,rep,name=disk_stats,json=diskStats,proto3" json:"disk_stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiskStats) Reset() {
+	*x = DiskStats{}
+	mi := &file_stats_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiskStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskStats) ProtoMessage() {}
+
+func (x *DiskStats) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskStats.ProtoReflect.Descriptor instead.
+func (*DiskStats) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DiskStats) GetDiskStats() []*DiskStat {
+	if x != nil {
+		return x.DiskStats
+	}
+	return nil
+}
+
+type DiskStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filesystem    string                 `protobuf:"bytes,1,opt,name=filesystem,proto3" json:"filesystem,omitempty"`
+	Usage         *DiskUsage             `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
+	Inodes        *InodeUsage            `protobuf:"bytes,3,opt,name=inodes,proto3" json:"inodes,omitempty"`
+	MountPoint    string                 `protobuf:"bytes,4,opt,name=mount_point,json=mountPoint,proto3" json:"mount_point,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiskStat) Reset() {
+	*x = DiskStat{}
+	mi := &file_stats_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiskStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskStat) ProtoMessage() {}
+
+func (x *DiskStat) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskStat.ProtoReflect.Descriptor instead.
+func (*DiskStat) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DiskStat) GetFilesystem() string {
+	if x != nil {
+		return x.Filesystem
+	}
+	return ""
+}
+
+func (x *DiskStat) GetUsage() *DiskUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *DiskStat) GetInodes() *InodeUsage {
+	if x != nil {
+		return x.Inodes
+	}
+	return nil
+}
+
+func (x *DiskStat) GetMountPoint() string {
+	if x != nil {
+		return x.MountPoint
+	}
+	return ""
+}
+
+type DiskUsage struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Used         uint64                 `protobuf:"varint,1,opt,name=used,proto3" json:"used,omitempty"`
+	Usage        string                 `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
+	Available    uint64                 `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
+	UsagePercent float64                `protobuf:"fixed64,4,opt,name=usage_percent,json=usagePercent,proto3" json:"usage_percent,omitempty"`
+	// bytes per second
+	GrowthRate float64 `protobuf:"fixed64,5,opt,name=growth_rate,json=growthRate,proto3" json:"growth_rate,omitempty"`
+	// 0 if the filesystem is not filling up
+	SecondsToFull float64 `protobuf:"fixed64,6,opt,name=seconds_to_full,json=secondsToFull,proto3" json:"seconds_to_full,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiskUsage) Reset() {
+	*x = DiskUsage{}
+	mi := &file_stats_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiskUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskUsage) ProtoMessage() {}
+
+func (x *DiskUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskUsage.ProtoReflect.Descriptor instead.
+func (*DiskUsage) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DiskUsage) GetUsed() uint64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *DiskUsage) GetUsage() string {
+	if x != nil {
+		return x.Usage
+	}
+	return ""
+}
+
+func (x *DiskUsage) GetAvailable() uint64 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *DiskUsage) GetUsagePercent() float64 {
+	if x != nil {
+		return x.UsagePercent
+	}
+	return 0
+}
+
+func (x *DiskUsage) GetGrowthRate() float64 {
+	if x != nil {
+		return x.GrowthRate
+	}
+	return 0
+}
+
+func (x *DiskUsage) GetSecondsToFull() float64 {
+	if x != nil {
+		return x.SecondsToFull
+	}
+	return 0
+}
+
+type InodeUsage struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Used         uint64                 `protobuf:"varint,1,opt,name=used,proto3" json:"used,omitempty"`
+	Usage        string                 `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
+	Available    uint64                 `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
+	UsagePercent float64                `protobuf:"fixed64,4,opt,name=usage_percent,json=usagePercent,proto3" json:"usage_percent,omitempty"`
+	// inodes per second
+	GrowthRate float64 `protobuf:"fixed64,5,opt,name=growth_rate,json=growthRate,proto3" json:"growth_rate,omitempty"`
+	// 0 if the inode table is not filling up
+	SecondsToFull float64 `protobuf:"fixed64,6,opt,name=seconds_to_full,json=secondsToFull,proto3" json:"seconds_to_full,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InodeUsage) Reset() {
+	*x = InodeUsage{}
+	mi := &file_stats_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InodeUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InodeUsage) ProtoMessage() {}
+
+func (x *InodeUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InodeUsage.ProtoReflect.Descriptor instead.
+func (*InodeUsage) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *InodeUsage) GetUsed() uint64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *InodeUsage) GetUsage() string {
+	if x != nil {
+		return x.Usage
+	}
+	return ""
+}
+
+func (x *InodeUsage) GetAvailable() uint64 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *InodeUsage) GetUsagePercent() float64 {
+	if x != nil {
+		return x.UsagePercent
+	}
+	return 0
+}
+
+func (x *InodeUsage) GetGrowthRate() float64 {
+	if x != nil {
+		return x.GrowthRate
+	}
+	return 0
+}
+
+func (x *InodeUsage) GetSecondsToFull() float64 {
+	if x != nil {
+		return x.SecondsToFull
+	}
+	return 0
+}
+
+type ListStatTypesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStatTypesRequest) Reset() {
+	*x = ListStatTypesRequest{}
+	mi := &file_stats_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStatTypesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStatTypesRequest) ProtoMessage() {}
+
+func (x *ListStatTypesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStatTypesRequest.ProtoReflect.Descriptor instead.
+func (*ListStatTypesRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{22}
+}
+
+type StatTypeInfo struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	StatType StatType               `protobuf:"varint,1,opt,name=stat_type,json=statType,proto3,enum=stats_service.StatType" json:"stat_type,omitempty"`
+	// Имя статистики в конфигурации сервера, например "cpu".
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled       bool   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatTypeInfo) Reset() {
+	*x = StatTypeInfo{}
+	mi := &file_stats_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatTypeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatTypeInfo) ProtoMessage() {}
+
+func (x *StatTypeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatTypeInfo.ProtoReflect.Descriptor instead.
+func (*StatTypeInfo) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StatTypeInfo) GetStatType() StatType {
+	if x != nil {
+		return x.StatType
+	}
+	return StatType_LOAD_AVERAGE
+}
+
+func (x *StatTypeInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StatTypeInfo) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type ListStatTypesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StatTypes     []*StatTypeInfo        `protobuf:"bytes,1,rep,name=stat_types,json=statTypes,proto3" json:"stat_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStatTypesResponse) Reset() {
+	*x = ListStatTypesResponse{}
+	mi := &file_stats_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStatTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStatTypesResponse) ProtoMessage() {}
+
+func (x *ListStatTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStatTypesResponse.ProtoReflect.Descriptor instead.
+func (*ListStatTypesResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListStatTypesResponse) GetStatTypes() []*StatTypeInfo {
+	if x != nil {
+		return x.StatTypes
+	}
+	return nil
+}
+
+type GetCapabilitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCapabilitiesRequest) Reset() {
+	*x = GetCapabilitiesRequest{}
+	mi := &file_stats_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapabilitiesRequest) ProtoMessage() {}
+
+func (x *GetCapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{25}
+}
+
+type Capabilities struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StatTypes     []*StatTypeInfo        `protobuf:"bytes,1,rep,name=stat_types,json=statTypes,proto3" json:"stat_types,omitempty"`
+	Limits        *Limits                `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+	Aggregations  []Aggregation          `protobuf:"varint,3,rep,packed,name=aggregations,proto3,enum=stats_service.Aggregation" json:"aggregations,omitempty"`
+	Host          *HostInfo              `protobuf:"bytes,4,opt,name=host,proto3" json:"host,omitempty"`
+	Version       *DaemonVersion         `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Capabilities) Reset() {
+	*x = Capabilities{}
+	mi := &file_stats_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Capabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capabilities) ProtoMessage() {}
+
+func (x *Capabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capabilities.ProtoReflect.Descriptor instead.
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Capabilities) GetStatTypes() []*StatTypeInfo {
+	if x != nil {
+		return x.StatTypes
+	}
+	return nil
+}
+
+func (x *Capabilities) GetLimits() *Limits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *Capabilities) GetAggregations() []Aggregation {
+	if x != nil {
+		return x.Aggregations
+	}
+	return nil
+}
+
+func (x *Capabilities) GetHost() *HostInfo {
+	if x != nil {
+		return x.Host
+	}
+	return nil
+}
+
+func (x *Capabilities) GetVersion() *DaemonVersion {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+type Limits struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Наибольший период усреднения в секундах, stats.limit.
+	MaxAveragingPeriod int64 `protobuf:"varint,1,opt,name=max_averaging_period,json=maxAveragingPeriod,proto3" json:"max_averaging_period,omitempty"`
+	// Наименьший интервал отправки в секундах.
+	MinInterval int32 `protobuf:"varint,2,opt,name=min_interval,json=minInterval,proto3" json:"min_interval,omitempty"`
+	// Наибольшее число открытых потоков, 0 — без ограничения.
+	MaxStreams    int32 `protobuf:"varint,3,opt,name=max_streams,json=maxStreams,proto3" json:"max_streams,omitempty"`
+	MaxTopN       int32 `protobuf:"varint,4,opt,name=max_top_n,json=maxTopN,proto3" json:"max_top_n,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Limits) Reset() {
+	*x = Limits{}
+	mi := &file_stats_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Limits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Limits) ProtoMessage() {}
+
+func (x *Limits) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Limits.ProtoReflect.Descriptor instead.
+func (*Limits) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Limits) GetMaxAveragingPeriod() int64 {
+	if x != nil {
+		return x.MaxAveragingPeriod
+	}
+	return 0
+}
+
+func (x *Limits) GetMinInterval() int32 {
+	if x != nil {
+		return x.MinInterval
+	}
+	return 0
+}
+
+func (x *Limits) GetMaxStreams() int32 {
+	if x != nil {
+		return x.MaxStreams
+	}
+	return 0
+}
+
+func (x *Limits) GetMaxTopN() int32 {
+	if x != nil {
+		return x.MaxTopN
+	}
+	return 0
+}
+
+type HostInfo struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Hostname string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Kernel   string                 `protobuf:"bytes,2,opt,name=kernel,proto3" json:"kernel,omitempty"`
+	CpuCount int32                  `protobuf:"varint,3,opt,name=cpu_count,json=cpuCount,proto3" json:"cpu_count,omitempty"`
+	// Время загрузки (unix, секунды), 0 — неизвестно.
+	BootTime      int64 `protobuf:"varint,4,opt,name=boot_time,json=bootTime,proto3" json:"boot_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostInfo) Reset() {
+	*x = HostInfo{}
+	mi := &file_stats_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostInfo) ProtoMessage() {}
+
+func (x *HostInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostInfo.ProtoReflect.Descriptor instead.
+func (*HostInfo) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *HostInfo) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *HostInfo) GetKernel() string {
+	if x != nil {
+		return x.Kernel
+	}
+	return ""
+}
+
+func (x *HostInfo) GetCpuCount() int32 {
+	if x != nil {
+		return x.CpuCount
+	}
+	return 0
+}
+
+func (x *HostInfo) GetBootTime() int64 {
+	if x != nil {
+		return x.BootTime
+	}
+	return 0
+}
+
+type DaemonVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Release       string                 `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
+	BuildDate     string                 `protobuf:"bytes,2,opt,name=build_date,json=buildDate,proto3" json:"build_date,omitempty"`
+	GitHash       string                 `protobuf:"bytes,3,opt,name=git_hash,json=gitHash,proto3" json:"git_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DaemonVersion) Reset() {
+	*x = DaemonVersion{}
+	mi := &file_stats_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DaemonVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DaemonVersion) ProtoMessage() {}
+
+func (x *DaemonVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DaemonVersion.ProtoReflect.Descriptor instead.
+func (*DaemonVersion) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DaemonVersion) GetRelease() string {
+	if x != nil {
+		return x.Release
+	}
+	return ""
+}
+
+func (x *DaemonVersion) GetBuildDate() string {
+	if x != nil {
+		return x.BuildDate
+	}
+	return ""
+}
+
+func (x *DaemonVersion) GetGitHash() string {
+	if x != nil {
+		return x.GitHash
+	}
+	return ""
+}
+
+type WatchAlertsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IncludePending bool                   `protobuf:"varint,1,opt,name=include_pending,json=includePending,proto3" json:"include_pending,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WatchAlertsRequest) Reset() {
+	*x = WatchAlertsRequest{}
+	mi := &file_stats_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAlertsRequest) ProtoMessage() {}
+
+func (x *WatchAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAlertsRequest.ProtoReflect.Descriptor instead.
+func (*WatchAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *WatchAlertsRequest) GetIncludePending() bool {
+	if x != nil {
+		return x.IncludePending
+	}
+	return false
+}
+
+type Alert struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          string                 `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Metric        string                 `protobuf:"bytes,2,opt,name=metric,proto3" json:"metric,omitempty"`
+	Target        string                 `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	State         AlertState             `protobuf:"varint,4,opt,name=state,proto3,enum=stats_service.AlertState" json:"state,omitempty"`
+	Operator      string                 `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
+	Threshold     float64                `protobuf:"fixed64,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Value         float64                `protobuf:"fixed64,7,opt,name=value,proto3" json:"value,omitempty"`
+	ActiveSince   int64                  `protobuf:"varint,8,opt,name=active_since,json=activeSince,proto3" json:"active_since,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_stats_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Alert) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *Alert) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *Alert) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Alert) GetState() AlertState {
+	if x != nil {
+		return x.State
+	}
+	return AlertState_INACTIVE
+}
+
+func (x *Alert) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *Alert) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *Alert) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Alert) GetActiveSince() int64 {
+	if x != nil {
+		return x.ActiveSince
+	}
+	return 0
+}
+
+func (x *Alert) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
 var File_stats_proto protoreflect.FileDescriptor
 
 var file_stats_proto_rawDesc = string([]byte{
 	0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x73,
-	0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x22, 0x93, 0x01, 0x0a,
+	0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x22, 0xfc, 0x01, 0x0a,
 	0x0c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
 	0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x05, 0x52, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4e, 0x12, 0x2c, 0x0a, 0x12,
@@ -655,77 +2425,374 @@ var file_stats_proto_rawDesc = string([]byte{
 	0x61, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x17,
 	0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53,
 	0x74, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70,
-	0x65, 0x73, 0x22, 0x93, 0x02, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x61, 0x76, 0x65, 0x72, 0x61,
-	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73,
-	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x41, 0x76, 0x65,
-	0x72, 0x61, 0x67, 0x65, 0x52, 0x0b, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67,
-	0x65, 0x12, 0x33, 0x0a, 0x09, 0x63, 0x70, 0x75, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x50, 0x55, 0x53, 0x74, 0x61, 0x74, 0x52, 0x08, 0x63, 0x70,
-	0x75, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x5f,
-	0x6c, 0x6f, 0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x74, 0x61,
-	0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x73,
-	0x4c, 0x6f, 0x61, 0x64, 0x52, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x4c, 0x6f, 0x61, 0x64, 0x12,
-	0x37, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x05, 0x20,
+	0x65, 0x73, 0x12, 0x3c, 0x0a, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x13, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x5f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x74, 0x6f, 0x70, 0x4e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x22, 0xd0, 0x06, 0x0a, 0x0d,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x3d, 0x0a, 0x0c, 0x6c,
+	0x6f, 0x61, 0x64, 0x5f, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x0b, 0x6c,
+	0x6f, 0x61, 0x64, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x63, 0x70,
+	0x75, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x50,
+	0x55, 0x53, 0x74, 0x61, 0x74, 0x52, 0x08, 0x63, 0x70, 0x75, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x37, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x5f, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x04, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x09, 0x64,
-	0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x63, 0x0a, 0x0b, 0x4c, 0x6f, 0x61, 0x64,
-	0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x61, 0x64, 0x31,
-	0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x6f, 0x61, 0x64, 0x31,
-	0x6d, 0x69, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x61, 0x64, 0x35, 0x6d, 0x69, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x6f, 0x61, 0x64, 0x35, 0x6d, 0x69, 0x6e, 0x12,
-	0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x61, 0x64, 0x31, 0x35, 0x6d, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x61, 0x64, 0x31, 0x35, 0x6d, 0x69, 0x6e, 0x22, 0x49, 0x0a,
-	0x07, 0x43, 0x50, 0x55, 0x53, 0x74, 0x61, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x73, 0x79,
-	0x73, 0x74, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x22, 0x43, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x6b,
-	0x73, 0x4c, 0x6f, 0x61, 0x64, 0x12, 0x36, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x5f, 0x6c,
-	0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x74, 0x61, 0x74,
-	0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x4c, 0x6f,
-	0x61, 0x64, 0x52, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x4c, 0x6f, 0x61, 0x64, 0x22, 0x47, 0x0a,
-	0x08, 0x44, 0x69, 0x73, 0x6b, 0x4c, 0x6f, 0x61, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x73, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x73, 0x4e, 0x61,
-	0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x03, 0x74, 0x70, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x03, 0x6b, 0x70, 0x73, 0x22, 0x43, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x74,
-	0x61, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74,
-	0x52, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x8d, 0x01, 0x0a, 0x08,
-	0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65,
-	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x69,
-	0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x2e, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x55, 0x73, 0x61, 0x67,
-	0x65, 0x52, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x69, 0x6e, 0x6f, 0x64,
-	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73,
-	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x49, 0x6e, 0x6f, 0x64, 0x65, 0x55, 0x73,
-	0x61, 0x67, 0x65, 0x52, 0x06, 0x69, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x35, 0x0a, 0x09, 0x44,
-	0x69, 0x73, 0x6b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x75, 0x73, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05,
-	0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x75, 0x73, 0x61,
-	0x67, 0x65, 0x22, 0x36, 0x0a, 0x0a, 0x49, 0x6e, 0x6f, 0x64, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
-	0x75, 0x73, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x2a, 0x4b, 0x0a, 0x08, 0x53, 0x74,
-	0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x41,
-	0x56, 0x45, 0x52, 0x41, 0x47, 0x45, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x50, 0x55, 0x5f,
-	0x53, 0x54, 0x41, 0x54, 0x53, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x49, 0x53, 0x4b, 0x53,
-	0x5f, 0x4c, 0x4f, 0x41, 0x44, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x49, 0x53, 0x4b, 0x5f,
-	0x55, 0x53, 0x41, 0x47, 0x45, 0x10, 0x03, 0x32, 0x59, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x73,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x49, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74,
-	0x61, 0x74, 0x73, 0x12, 0x1b, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1c, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x30, 0x01, 0x42, 0x1f, 0x5a, 0x1d, 0x2e, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x3b, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x69, 0x63, 0x65, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x73, 0x4c, 0x6f, 0x61, 0x64, 0x52, 0x09, 0x64,
+	0x69, 0x73, 0x6b, 0x73, 0x4c, 0x6f, 0x61, 0x64, 0x12, 0x37, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x69, 0x73,
+	0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x3c, 0x0a, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x37, 0x0a, 0x08, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x08,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x47, 0x0a, 0x13, 0x64, 0x69, 0x73, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x11,
+	0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x73, 0x12, 0x42, 0x0a, 0x0e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x0d, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x40, 0x0a, 0x0d, 0x74, 0x6f, 0x70, 0x5f, 0x70, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x54, 0x6f, 0x70,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x0c, 0x74, 0x6f, 0x70, 0x50, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x75, 0x72, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x72, 0x65, 0x73, 0x73, 0x75,
+	0x72, 0x65, 0x52, 0x08, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x12, 0x30, 0x0a, 0x07,
+	0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x07, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x36,
+	0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x06,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x3a, 0x0a, 0x0b, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e,
+	0x5f, 0x73, 0x65, 0x6c, 0x66, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x61, 0x65, 0x6d,
+	0x6f, 0x6e, 0x53, 0x65, 0x6c, 0x66, 0x52, 0x0a, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53, 0x65,
+	0x6c, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x22, 0x83,
+	0x01, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12,
+	0x34, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x17, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x73, 0x74, 0x61,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x61, 0x67, 0x65, 0x22, 0x9c, 0x02, 0x0a, 0x0a, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53,
+	0x65, 0x6c, 0x66, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x67, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x65, 0x61, 0x70, 0x5f, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x68, 0x65, 0x61, 0x70, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x63, 0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x67, 0x63, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x12,
+	0x28, 0x0a, 0x10, 0x67, 0x63, 0x5f, 0x70, 0x61, 0x75, 0x73, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x67, 0x63, 0x50, 0x61, 0x75,
+	0x73, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0d, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x65, 0x72, 0x5f, 0x72, 0x75, 0x6e, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x65, 0x72, 0x52,
+	0x75, 0x6e, 0x73, 0x12, 0x3e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x22, 0xe2, 0x01, 0x0a, 0x0f, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x34, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x32, 0x0a, 0x15, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6e, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x72, 0x75, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x64, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x22, 0xaa, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x34, 0x0a, 0x09,
+	0x73, 0x74, 0x61, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x17, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x69, 0x6e,
+	0x63, 0x65, 0x12, 0x31, 0x0a, 0x14, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76,
+	0x65, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x13, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x46, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x73, 0x22, 0x50, 0x0a, 0x0c, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x72, 0x0a, 0x0c, 0x54, 0x6f, 0x70, 0x50, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x06, 0x62, 0x79, 0x5f, 0x63, 0x70,
+	0x75, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52,
+	0x05, 0x62, 0x79, 0x43, 0x70, 0x75, 0x12, 0x33, 0x0a, 0x09, 0x62, 0x79, 0x5f, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x52, 0x08, 0x62, 0x79, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0xa1, 0x01, 0x0a, 0x07,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x70, 0x75, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x70, 0x75, 0x50, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x73, 0x73, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x73, 0x73, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22,
+	0xc5, 0x01, 0x0a, 0x08, 0x50, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x03, 0x63, 0x70,
+	0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x37, 0x0a,
+	0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x72,
+	0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x06,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x2f, 0x0a, 0x02, 0x69, 0x6f, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x50, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x02, 0x69, 0x6f, 0x22, 0x76, 0x0a, 0x10, 0x50, 0x72, 0x65, 0x73, 0x73,
+	0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x04, 0x73,
+	0x6f, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x72, 0x65, 0x73, 0x73, 0x75,
+	0x72, 0x65, 0x53, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x04, 0x73, 0x6f, 0x6d, 0x65, 0x12, 0x30, 0x0a,
+	0x04, 0x66, 0x75, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x72, 0x65, 0x73,
+	0x73, 0x75, 0x72, 0x65, 0x53, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x22,
+	0x79, 0x0a, 0x0d, 0x50, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x53, 0x74, 0x61, 0x6c, 0x6c,
+	0x12, 0x14, 0x0a, 0x05, 0x61, 0x76, 0x67, 0x31, 0x30, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x61, 0x76, 0x67, 0x31, 0x30, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x76, 0x67, 0x36, 0x30, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x61, 0x76, 0x67, 0x36, 0x30, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x76, 0x67, 0x33, 0x30, 0x30, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x76,
+	0x67, 0x33, 0x30, 0x30, 0x12, 0x24, 0x0a, 0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x64, 0x65,
+	0x6c, 0x74, 0x61, 0x5f, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x55, 0x73, 0x22, 0x3a, 0x0a, 0x07, 0x43, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x07, 0x63,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0xe3, 0x01, 0x0a, 0x06, 0x43, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x70, 0x75, 0x5f, 0x70, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x70, 0x75, 0x50,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x43, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0e, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x61, 0x78, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x69, 0x6f, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x69, 0x6f, 0x52, 0x65, 0x61,
+	0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x69, 0x6f, 0x5f, 0x77, 0x72, 0x69,
+	0x74, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
+	0x69, 0x6f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0xc6, 0x01, 0x0a,
+	0x0b, 0x4c, 0x6f, 0x61, 0x64, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x6f, 0x61, 0x64, 0x31, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x6c, 0x6f, 0x61, 0x64, 0x31, 0x6d, 0x69, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x61, 0x64,
+	0x35, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x6f, 0x61, 0x64,
+	0x35, 0x6d, 0x69, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x61, 0x64, 0x31, 0x35, 0x6d, 0x69,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x61, 0x64, 0x31, 0x35, 0x6d,
+	0x69, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x75, 0x6e, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x74,
+	0x61, 0x73, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x72, 0x75, 0x6e, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x70, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6c, 0x61,
+	0x73, 0x74, 0x50, 0x69, 0x64, 0x22, 0x49, 0x0a, 0x07, 0x43, 0x50, 0x55, 0x53, 0x74, 0x61, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04,
+	0x75, 0x73, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04,
+	0x69, 0x64, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x69, 0x64, 0x6c, 0x65,
+	0x22, 0x43, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x6b, 0x73, 0x4c, 0x6f, 0x61, 0x64, 0x12, 0x36, 0x0a,
+	0x0a, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x5f, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x4c, 0x6f, 0x61, 0x64, 0x52, 0x09, 0x64, 0x69, 0x73, 0x6b,
+	0x73, 0x4c, 0x6f, 0x61, 0x64, 0x22, 0x47, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x6b, 0x4c, 0x6f, 0x61,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x70,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x74, 0x70, 0x73, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6b, 0x70, 0x73, 0x22, 0x43,
+	0x0a, 0x09, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x0a, 0x64,
+	0x69, 0x73, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x52, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x22, 0xae, 0x01, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74,
+	0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x12, 0x2e, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x44, 0x69, 0x73, 0x6b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x31, 0x0a, 0x06, 0x69, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x49, 0x6e, 0x6f, 0x64, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x06, 0x69, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x22, 0xc1, 0x01, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x6b, 0x55, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x75, 0x73, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x75, 0x73,
+	0x61, 0x67, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0c, 0x75, 0x73, 0x61, 0x67, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12,
+	0x1f, 0x0a, 0x0b, 0x67, 0x72, 0x6f, 0x77, 0x74, 0x68, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x67, 0x72, 0x6f, 0x77, 0x74, 0x68, 0x52, 0x61, 0x74, 0x65,
+	0x12, 0x26, 0x0a, 0x0f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x5f, 0x74, 0x6f, 0x5f, 0x66,
+	0x75, 0x6c, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x73, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x54, 0x6f, 0x46, 0x75, 0x6c, 0x6c, 0x22, 0xc2, 0x01, 0x0a, 0x0a, 0x49, 0x6e, 0x6f,
+	0x64, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x75, 0x73, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x75,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x75, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x75, 0x73, 0x61, 0x67, 0x65, 0x50, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x67, 0x72, 0x6f, 0x77, 0x74, 0x68, 0x5f, 0x72,
+	0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x67, 0x72, 0x6f, 0x77, 0x74,
+	0x68, 0x52, 0x61, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x5f, 0x74, 0x6f, 0x5f, 0x66, 0x75, 0x6c, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x54, 0x6f, 0x46, 0x75, 0x6c, 0x6c, 0x22, 0x16, 0x0a,
+	0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x72, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x34, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x53, 0x0a, 0x15, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x22, 0x18,
+	0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x9e, 0x02, 0x0a, 0x0c, 0x43, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x0a, 0x73, 0x74, 0x61,
+	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x12, 0x3e, 0x0a, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x68, 0x6f, 0x73,
+	0x74, 0x12, 0x36, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x9a, 0x01, 0x0a, 0x06, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x76, 0x65, 0x72,
+	0x61, 0x67, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x69, 0x6e, 0x67,
+	0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x5f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d, 0x69,
+	0x6e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78,
+	0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x6d, 0x61, 0x78, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x09, 0x6d, 0x61,
+	0x78, 0x5f, 0x74, 0x6f, 0x70, 0x5f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d,
+	0x61, 0x78, 0x54, 0x6f, 0x70, 0x4e, 0x22, 0x78, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x70, 0x75, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x70, 0x75, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x62, 0x6f, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x22, 0x63, 0x0a, 0x0d, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x69,
+	0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x69,
+	0x74, 0x48, 0x61, 0x73, 0x68, 0x22, 0x3d, 0x0a, 0x12, 0x57, 0x61, 0x74, 0x63, 0x68, 0x41, 0x6c,
+	0x65, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x69,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x50, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x22, 0x8d, 0x02, 0x0a, 0x05, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75,
+	0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x12, 0x2f, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x19, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12,
+	0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x73, 0x69,
+	0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x2a, 0x96, 0x01, 0x0a, 0x08, 0x53, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x10, 0x0a, 0x0c, 0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x41, 0x56, 0x45, 0x52, 0x41, 0x47,
+	0x45, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x50, 0x55, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x53,
+	0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x49, 0x53, 0x4b, 0x53, 0x5f, 0x4c, 0x4f, 0x41, 0x44,
+	0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x49, 0x53, 0x4b, 0x5f, 0x55, 0x53, 0x41, 0x47, 0x45,
+	0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x55, 0x53, 0x54, 0x4f, 0x4d, 0x10, 0x04, 0x12, 0x11,
+	0x0a, 0x0d, 0x54, 0x4f, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x45, 0x53, 0x10,
+	0x05, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x52, 0x45, 0x53, 0x53, 0x55, 0x52, 0x45, 0x10, 0x06, 0x12,
+	0x0b, 0x0a, 0x07, 0x43, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x53, 0x10, 0x07, 0x12, 0x0f, 0x0a, 0x0b,
+	0x44, 0x41, 0x45, 0x4d, 0x4f, 0x4e, 0x5f, 0x53, 0x45, 0x4c, 0x46, 0x10, 0x08, 0x2a, 0x4e, 0x0a,
+	0x0b, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04,
+	0x4d, 0x45, 0x41, 0x4e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x49, 0x4e, 0x10, 0x01, 0x12,
+	0x07, 0x0a, 0x03, 0x4d, 0x41, 0x58, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x35, 0x30, 0x10,
+	0x03, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x39, 0x35, 0x10, 0x04, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x39,
+	0x39, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x41, 0x53, 0x54, 0x10, 0x06, 0x2a, 0x41, 0x0a,
+	0x0a, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x49,
+	0x4e, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e,
+	0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x49, 0x52, 0x49, 0x4e, 0x47,
+	0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x53, 0x4f, 0x4c, 0x56, 0x45, 0x44, 0x10, 0x03,
+	0x32, 0xdc, 0x02, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x49, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1b, 0x2e,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4a, 0x0a, 0x0b,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x12, 0x21, 0x2e, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
+	0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41,
+	0x6c, 0x65, 0x72, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x5c, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24,
+	0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x00, 0x42,
+	0x1f, 0x5a, 0x1d, 0x2e, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x3b, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -740,38 +2807,98 @@ func file_stats_proto_rawDescGZIP() []byte {
 	return file_stats_proto_rawDescData
 }
 
-var file_stats_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_stats_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_stats_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_stats_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_stats_proto_goTypes = []any{
-	(StatType)(0),         // 0: stats_service.StatType
-	(*StatsRequest)(nil),  // 1: stats_service.StatsRequest
-	(*StatsResponse)(nil), // 2: stats_service.StatsResponse
-	(*LoadAverage)(nil),   // 3: stats_service.LoadAverage
-	(*CPUStat)(nil),       // 4: stats_service.CPUStat
-	(*DisksLoad)(nil),     // 5: stats_service.DisksLoad
-	(*DiskLoad)(nil),      // 6: stats_service.DiskLoad
-	(*DiskStats)(nil),     // 7: stats_service.DiskStats
-	(*DiskStat)(nil),      // 8: stats_service.DiskStat
-	(*DiskUsage)(nil),     // 9: stats_service.DiskUsage
-	(*InodeUsage)(nil),    // 10: stats_service.InodeUsage
+	(StatType)(0),                  // 0: stats_service.StatType
+	(Aggregation)(0),               // 1: stats_service.Aggregation
+	(AlertState)(0),                // 2: stats_service.AlertState
+	(*StatsRequest)(nil),           // 3: stats_service.StatsRequest
+	(*StatsResponse)(nil),          // 4: stats_service.StatsResponse
+	(*StatCoverage)(nil),           // 5: stats_service.StatCoverage
+	(*DaemonSelf)(nil),             // 6: stats_service.DaemonSelf
+	(*DaemonCollector)(nil),        // 7: stats_service.DaemonCollector
+	(*CollectionError)(nil),        // 8: stats_service.CollectionError
+	(*CustomMetric)(nil),           // 9: stats_service.CustomMetric
+	(*TopProcesses)(nil),           // 10: stats_service.TopProcesses
+	(*Process)(nil),                // 11: stats_service.Process
+	(*Pressure)(nil),               // 12: stats_service.Pressure
+	(*PressureResource)(nil),       // 13: stats_service.PressureResource
+	(*PressureStall)(nil),          // 14: stats_service.PressureStall
+	(*Cgroups)(nil),                // 15: stats_service.Cgroups
+	(*Cgroup)(nil),                 // 16: stats_service.Cgroup
+	(*LoadAverage)(nil),            // 17: stats_service.LoadAverage
+	(*CPUStat)(nil),                // 18: stats_service.CPUStat
+	(*DisksLoad)(nil),              // 19: stats_service.DisksLoad
+	(*DiskLoad)(nil),               // 20: stats_service.DiskLoad
+	(*DiskStats)(nil),              // 21: stats_service.DiskStats
+	(*DiskStat)(nil),               // 22: stats_service.DiskStat
+	(*DiskUsage)(nil),              // 23: stats_service.DiskUsage
+	(*InodeUsage)(nil),             // 24: stats_service.InodeUsage
+	(*ListStatTypesRequest)(nil),   // 25: stats_service.ListStatTypesRequest
+	(*StatTypeInfo)(nil),           // 26: stats_service.StatTypeInfo
+	(*ListStatTypesResponse)(nil),  // 27: stats_service.ListStatTypesResponse
+	(*GetCapabilitiesRequest)(nil), // 28: stats_service.GetCapabilitiesRequest
+	(*Capabilities)(nil),           // 29: stats_service.Capabilities
+	(*Limits)(nil),                 // 30: stats_service.Limits
+	(*HostInfo)(nil),               // 31: stats_service.HostInfo
+	(*DaemonVersion)(nil),          // 32: stats_service.DaemonVersion
+	(*WatchAlertsRequest)(nil),     // 33: stats_service.WatchAlertsRequest
+	(*Alert)(nil),                  // 34: stats_service.Alert
 }
 var file_stats_proto_depIdxs = []int32{
 	0,  // 0: stats_service.StatsRequest.stat_types:type_name -> stats_service.StatType
-	3,  // 1: stats_service.StatsResponse.load_average:type_name -> stats_service.LoadAverage
-	4,  // 2: stats_service.StatsResponse.cpu_stats:type_name -> stats_service.CPUStat
-	5,  // 3: stats_service.StatsResponse.disks_load:type_name -> stats_service.DisksLoad
-	7,  // 4: stats_service.StatsResponse.disk_stats:type_name -> stats_service.DiskStats
-	6,  // 5: stats_service.DisksLoad.disks_load:type_name -> stats_service.DiskLoad
-	8,  // 6: stats_service.DiskStats.disk_stats:type_name -> stats_service.DiskStat
-	9,  // 7: stats_service.DiskStat.usage:type_name -> stats_service.DiskUsage
-	10, // 8: stats_service.DiskStat.inodes:type_name -> stats_service.InodeUsage
-	1,  // 9: stats_service.StatsService.GetStats:input_type -> stats_service.StatsRequest
-	2,  // 10: stats_service.StatsService.GetStats:output_type -> stats_service.StatsResponse
-	10, // [10:11] is the sub-list for method output_type
-	9,  // [9:10] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	1,  // 1: stats_service.StatsRequest.aggregation:type_name -> stats_service.Aggregation
+	17, // 2: stats_service.StatsResponse.load_average:type_name -> stats_service.LoadAverage
+	18, // 3: stats_service.StatsResponse.cpu_stats:type_name -> stats_service.CPUStat
+	19, // 4: stats_service.StatsResponse.disks_load:type_name -> stats_service.DisksLoad
+	21, // 5: stats_service.StatsResponse.disk_stats:type_name -> stats_service.DiskStats
+	1,  // 6: stats_service.StatsResponse.aggregation:type_name -> stats_service.Aggregation
+	5,  // 7: stats_service.StatsResponse.coverage:type_name -> stats_service.StatCoverage
+	0,  // 8: stats_service.StatsResponse.disabled_stat_types:type_name -> stats_service.StatType
+	9,  // 9: stats_service.StatsResponse.custom_metrics:type_name -> stats_service.CustomMetric
+	10, // 10: stats_service.StatsResponse.top_processes:type_name -> stats_service.TopProcesses
+	12, // 11: stats_service.StatsResponse.pressure:type_name -> stats_service.Pressure
+	15, // 12: stats_service.StatsResponse.cgroups:type_name -> stats_service.Cgroups
+	8,  // 13: stats_service.StatsResponse.errors:type_name -> stats_service.CollectionError
+	6,  // 14: stats_service.StatsResponse.daemon_self:type_name -> stats_service.DaemonSelf
+	0,  // 15: stats_service.StatCoverage.stat_type:type_name -> stats_service.StatType
+	7,  // 16: stats_service.DaemonSelf.collectors:type_name -> stats_service.DaemonCollector
+	0,  // 17: stats_service.DaemonCollector.stat_type:type_name -> stats_service.StatType
+	0,  // 18: stats_service.CollectionError.stat_type:type_name -> stats_service.StatType
+	11, // 19: stats_service.TopProcesses.by_cpu:type_name -> stats_service.Process
+	11, // 20: stats_service.TopProcesses.by_memory:type_name -> stats_service.Process
+	13, // 21: stats_service.Pressure.cpu:type_name -> stats_service.PressureResource
+	13, // 22: stats_service.Pressure.memory:type_name -> stats_service.PressureResource
+	13, // 23: stats_service.Pressure.io:type_name -> stats_service.PressureResource
+	14, // 24: stats_service.PressureResource.some:type_name -> stats_service.PressureStall
+	14, // 25: stats_service.PressureResource.full:type_name -> stats_service.PressureStall
+	16, // 26: stats_service.Cgroups.cgroups:type_name -> stats_service.Cgroup
+	20, // 27: stats_service.DisksLoad.disks_load:type_name -> stats_service.DiskLoad
+	22, // 28: stats_service.DiskStats.disk_stats:type_name -> stats_service.DiskStat
+	23, // 29: stats_service.DiskStat.usage:type_name -> stats_service.DiskUsage
+	24, // 30: stats_service.DiskStat.inodes:type_name -> stats_service.InodeUsage
+	0,  // 31: stats_service.StatTypeInfo.stat_type:type_name -> stats_service.StatType
+	26, // 32: stats_service.ListStatTypesResponse.stat_types:type_name -> stats_service.StatTypeInfo
+	26, // 33: stats_service.Capabilities.stat_types:type_name -> stats_service.StatTypeInfo
+	30, // 34: stats_service.Capabilities.limits:type_name -> stats_service.Limits
+	1,  // 35: stats_service.Capabilities.aggregations:type_name -> stats_service.Aggregation
+	31, // 36: stats_service.Capabilities.host:type_name -> stats_service.HostInfo
+	32, // 37: stats_service.Capabilities.version:type_name -> stats_service.DaemonVersion
+	2,  // 38: stats_service.Alert.state:type_name -> stats_service.AlertState
+	3,  // 39: stats_service.StatsService.GetStats:input_type -> stats_service.StatsRequest
+	33, // 40: stats_service.StatsService.WatchAlerts:input_type -> stats_service.WatchAlertsRequest
+	25, // 41: stats_service.StatsService.ListStatTypes:input_type -> stats_service.ListStatTypesRequest
+	28, // 42: stats_service.StatsService.GetCapabilities:input_type -> stats_service.GetCapabilitiesRequest
+	4,  // 43: stats_service.StatsService.GetStats:output_type -> stats_service.StatsResponse
+	34, // 44: stats_service.StatsService.WatchAlerts:output_type -> stats_service.Alert
+	27, // 45: stats_service.StatsService.ListStatTypes:output_type -> stats_service.ListStatTypesResponse
+	29, // 46: stats_service.StatsService.GetCapabilities:output_type -> stats_service.Capabilities
+	43, // [43:47] is the sub-list for method output_type
+	39, // [39:43] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
 }
 
 func init() { file_stats_proto_init() }
@@ -784,8 +2911,8 @@ func file_stats_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stats_proto_rawDesc), len(file_stats_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   10,
+			NumEnums:      3,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	StatsService_GetStats_FullMethodName = "/stats_service.StatsService/GetStats"
+	StatsService_GetStats_FullMethodName        = "/stats_service.StatsService/GetStats"
+	StatsService_WatchAlerts_FullMethodName     = "/stats_service.StatsService/WatchAlerts"
+	StatsService_ListStatTypes_FullMethodName   = "/stats_service.StatsService/ListStatTypes"
+	StatsService_GetCapabilities_FullMethodName = "/stats_service.StatsService/GetCapabilities"
 )
 
 // StatsServiceClient is the client API for StatsService service.
@@ -27,6 +30,11 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type StatsServiceClient interface {
 	GetStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatsResponse], error)
+	WatchAlerts(ctx context.Context, in *WatchAlertsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Alert], error)
+	// Все типы статистики сервера и признак их включения в конфигурации.
+	ListStatTypes(ctx context.Context, in *ListStatTypesRequest, opts ...grpc.CallOption) (*ListStatTypesResponse, error)
+	// Возможности сервера: статистика, ограничения запросов, хост и версия демона.
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*Capabilities, error)
 }
 
 type statsServiceClient struct {
@@ -56,11 +64,55 @@ func (c *statsServiceClient) GetStats(ctx context.Context, in *StatsRequest, opt
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type StatsService_GetStatsClient = grpc.ServerStreamingClient[StatsResponse]
 
+func (c *statsServiceClient) WatchAlerts(ctx context.Context, in *WatchAlertsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Alert], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StatsService_ServiceDesc.Streams[1], StatsService_WatchAlerts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAlertsRequest, Alert]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StatsService_WatchAlertsClient = grpc.ServerStreamingClient[Alert]
+
+func (c *statsServiceClient) ListStatTypes(ctx context.Context, in *ListStatTypesRequest, opts ...grpc.CallOption) (*ListStatTypesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStatTypesResponse)
+	err := c.cc.Invoke(ctx, StatsService_ListStatTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsServiceClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*Capabilities, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Capabilities)
+	err := c.cc.Invoke(ctx, StatsService_GetCapabilities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StatsServiceServer is the server API for StatsService service.
 // All implementations must embed UnimplementedStatsServiceServer
 // for forward compatibility.
 type StatsServiceServer interface {
 	GetStats(*StatsRequest, grpc.ServerStreamingServer[StatsResponse]) error
+	WatchAlerts(*WatchAlertsRequest, grpc.ServerStreamingServer[Alert]) error
+	// Все типы статистики сервера и признак их включения в конфигурации.
+	ListStatTypes(context.Context, *ListStatTypesRequest) (*ListStatTypesResponse, error)
+	// Возможности сервера: статистика, ограничения запросов, хост и версия демона.
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*Capabilities, error)
 	mustEmbedUnimplementedStatsServiceServer()
 }
 
@@ -74,6 +126,15 @@ type UnimplementedStatsServiceServer struct{}
 func (UnimplementedStatsServiceServer) GetStats(*StatsRequest, grpc.ServerStreamingServer[StatsResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method GetStats not implemented")
 }
+func (UnimplementedStatsServiceServer) WatchAlerts(*WatchAlertsRequest, grpc.ServerStreamingServer[Alert]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAlerts not implemented")
+}
+func (UnimplementedStatsServiceServer) ListStatTypes(context.Context, *ListStatTypesRequest) (*ListStatTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStatTypes not implemented")
+}
+func (UnimplementedStatsServiceServer) GetCapabilities(context.Context, *GetCapabilitiesRequest) (*Capabilities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
 func (UnimplementedStatsServiceServer) mustEmbedUnimplementedStatsServiceServer() {}
 func (UnimplementedStatsServiceServer) testEmbeddedByValue()                      {}
 
@@ -106,19 +167,80 @@ func _StatsService_GetStats_Handler(srv interface{}, stream grpc.ServerStream) e
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type StatsService_GetStatsServer = grpc.ServerStreamingServer[StatsResponse]
 
+func _StatsService_WatchAlerts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAlertsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StatsServiceServer).WatchAlerts(m, &grpc.GenericServerStream[WatchAlertsRequest, Alert]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StatsService_WatchAlertsServer = grpc.ServerStreamingServer[Alert]
+
+func _StatsService_ListStatTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStatTypesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).ListStatTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsService_ListStatTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).ListStatTypes(ctx, req.(*ListStatTypesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsService_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsService_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StatsService_ServiceDesc is the grpc.ServiceDesc for StatsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var StatsService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "stats_service.StatsService",
 	HandlerType: (*StatsServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListStatTypes",
+			Handler:    _StatsService_ListStatTypes_Handler,
+		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _StatsService_GetCapabilities_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "GetStats",
 			Handler:       _StatsService_GetStats_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchAlerts",
+			Handler:       _StatsService_WatchAlerts_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "stats.proto",
 }
@@ -1,7 +1,12 @@
 package collector
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,87 +15,241 @@ import (
 	"github.com/cepmap/otus-system-monitoring/internal/converter"
 	"github.com/cepmap/otus-system-monitoring/internal/logger"
 	"github.com/cepmap/otus-system-monitoring/internal/metrics"
-	"github.com/cepmap/otus-system-monitoring/internal/stats/cpu"
-	"github.com/cepmap/otus-system-monitoring/internal/stats/disksload"
-	"github.com/cepmap/otus-system-monitoring/internal/stats/diskstat"
-	"github.com/cepmap/otus-system-monitoring/internal/stats/loadavg"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+
+	// Встроенная статистика регистрируется при импорте пакетов.
+	_ "github.com/cepmap/otus-system-monitoring/internal/stats/cgroups"
+	_ "github.com/cepmap/otus-system-monitoring/internal/stats/cpu"
+	_ "github.com/cepmap/otus-system-monitoring/internal/stats/daemon"
+	_ "github.com/cepmap/otus-system-monitoring/internal/stats/disksload"
+	_ "github.com/cepmap/otus-system-monitoring/internal/stats/diskstat"
+	_ "github.com/cepmap/otus-system-monitoring/internal/stats/loadavg"
+	_ "github.com/cepmap/otus-system-monitoring/internal/stats/plugin"
+	_ "github.com/cepmap/otus-system-monitoring/internal/stats/pressure"
+	_ "github.com/cepmap/otus-system-monitoring/internal/stats/processes"
 )
 
+// maxBackfill ограничивает число ответов, досылаемых клиенту после переподключения.
+const maxBackfill = 1000
+
 type Collector struct {
-	metrics   *metrics.Storage
-	statTypes []pb.StatType
-	avgPeriod time.Duration
+	metrics     *metrics.Storage
+	config      *config.Store
+	collectors  []stats.Collector
+	avgPeriod   time.Duration
+	aggregation metrics.Aggregation
+	options     stats.Options
+	disabled    []pb.StatType
 }
 
-func New(metrics *metrics.Storage, statTypes []pb.StatType, avgPeriod time.Duration) *Collector {
-	return &Collector{
-		metrics:   metrics,
-		statTypes: statTypes,
-		avgPeriod: avgPeriod,
+// CollectError описывает идущие подряд неудачные сборы статистики одного типа.
+type CollectError struct {
+	StatType pb.StatType
+	metrics.CollectError
+}
+
+// Option настраивает Collector.
+type Option func(*settings)
+
+type settings struct {
+	collectors map[pb.StatType]stats.Collector
+}
+
+// WithCollectors подменяет зарегистрированные сборщики тех же типов сборщиками,
+// привязанными к конкретному серверу, например daemon_self с его счётчиками.
+func WithCollectors(collectors ...stats.Collector) Option {
+	return func(s *settings) {
+		for _, sc := range collectors {
+			s.collectors[sc.StatType()] = sc
+		}
 	}
 }
 
-func (c *Collector) collectLoadAverage(timestamp time.Time) {
-	if !config.DaemonConfig.Stats.LoadAverage {
-		return
+func New(
+	metrics *metrics.Storage,
+	cfg *config.Store,
+	statTypes []pb.StatType,
+	avgPeriod time.Duration,
+	aggregation metrics.Aggregation,
+	options stats.Options,
+	opts ...Option,
+) *Collector {
+	s := settings{collectors: make(map[pb.StatType]stats.Collector)}
+	for _, opt := range opts {
+		opt(&s)
 	}
-	if stats, err := loadavg.GetStats(); err == nil {
-		c.metrics.StoreLoadAverage(stats, timestamp)
+
+	collectors := make([]stats.Collector, 0, len(statTypes))
+	for _, statType := range statTypes {
+		if sc, ok := s.collectors[statType]; ok {
+			collectors = append(collectors, sc)
+		} else if sc, ok := stats.Lookup(statType); ok {
+			collectors = append(collectors, sc)
+		}
+	}
+	return &Collector{
+		metrics:     metrics,
+		config:      cfg,
+		collectors:  collectors,
+		avgPeriod:   avgPeriod,
+		aggregation: aggregation,
+		options:     options,
 	}
 }
 
-func (c *Collector) collectCPUStats(timestamp time.Time) {
-	if !config.DaemonConfig.Stats.Cpu {
-		return
+// Enabled сообщает, что статистика данного типа зарегистрирована и включена в конфигурации.
+func Enabled(cfg config.Stats, statType pb.StatType) bool {
+	sc, ok := stats.Lookup(statType)
+	return ok && cfg.Enabled(sc.Name())
+}
+
+// ValidateConfig проверяет, что настройки сбора заданы только для зарегистрированной статистики.
+func ValidateConfig(cfg config.Stats) error {
+	names := make([]string, 0, len(cfg.Collectors))
+	for name := range cfg.Collectors {
+		names = append(names, name)
 	}
-	if stats, err := cpu.GetCpuStat(); err == nil {
-		c.metrics.StoreCPUStats(stats, timestamp)
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if _, ok := stats.ByName(name); !ok {
+			errs = append(errs, &config.FieldError{
+				Field:   "stats.collectors." + name,
+				Message: fmt.Sprintf("unknown stat, expected one of %s", strings.Join(statNames(), ", ")),
+			})
+		}
 	}
+
+	// Прогноз строится по истории замеров, поэтому хранилище должно вмещать
+	// весь период прогноза, иначе он молча считается по меньшему окну.
+	for _, sc := range stats.All() {
+		f, ok := sc.(metrics.Forecaster)
+		if !ok || !cfg.Enabled(sc.Name()) {
+			continue
+		}
+		period := f.ForecastPeriod()
+		if kept := time.Duration(cfg.Limit) * cfg.CollectionFor(sc.Name()).Interval; cfg.Limit > 0 && kept < period {
+			errs = append(errs, &config.FieldError{
+				Field: "stats.limit",
+				Message: fmt.Sprintf("%d samples of %s every %v cover only %v, forecast needs %v",
+					cfg.Limit, sc.Name(), cfg.CollectionFor(sc.Name()).Interval, kept, period),
+			})
+		}
+		if cfg.Retention > 0 && cfg.Retention < period {
+			errs = append(errs, &config.FieldError{
+				Field:   "stats.retention",
+				Message: fmt.Sprintf("%v is shorter than %s forecast period %v", cfg.Retention, sc.Name(), period),
+			})
+		}
+	}
+	return errors.Join(errs...)
 }
 
-func (c *Collector) collectDisksLoad(timestamp time.Time) {
-	if !config.DaemonConfig.Stats.DiskLoad {
-		return
+func statNames() []string {
+	all := stats.All()
+	names := make([]string, 0, len(all))
+	for _, sc := range all {
+		names = append(names, sc.Name())
 	}
-	if stats, err := disksload.GetStats(); err == nil {
-		c.metrics.StoreDisksLoad(stats, timestamp)
+	return names
+}
+
+// collect собирает статистику одного типа, ограничивая сбор таймаутом из конфигурации.
+func (c *Collector) collect(ctx context.Context, sc stats.Collector, timestamp time.Time) {
+	cfg := c.config.Get().Stats
+	ctx, cancel := context.WithTimeout(ctx, cfg.CollectionFor(sc.Name()).Timeout)
+	defer cancel()
+
+	start := time.Now()
+	value, err := sc.Sample(ctx, cfg)
+	c.metrics.RecordCollection(sc.Name(), time.Since(start), err)
+	if value != nil {
+		c.metrics.Store(sc.Name(), value, timestamp)
 	}
+	c.setError(sc, err, timestamp)
 }
 
-func (c *Collector) collectDiskUsage(timestamp time.Time) {
-	if !config.DaemonConfig.Stats.DiskInfo {
+// setError запоминает результат сбора в хранилище, общем для всех подписок.
+// Повторяющаяся ошибка попадает в лог не чаще раза в минуту.
+func (c *Collector) setError(sc stats.Collector, err error, timestamp time.Time) {
+	state, log := c.metrics.SetError(sc.Name(), err, timestamp)
+	if !log {
 		return
 	}
-	if stats, err := diskstat.GetStats(); err == nil {
-		c.metrics.StoreDiskUsage(stats, timestamp)
+	switch {
+	case err == nil:
+		logger.Info(fmt.Sprintf("Collection of %s recovered after %d failures", sc.StatType(), state.Failures))
+	case state.Failures == 1:
+		logger.Error(fmt.Sprintf("Failed to collect %s: %v", sc.StatType(), err))
+	default:
+		logger.Error(fmt.Sprintf("Failed to collect %s %d times in a row since %s: %v",
+			sc.StatType(), state.Failures, state.Since.Format(time.RFC3339), err))
+	}
+}
+
+// Errors возвращает ошибки сбора статистики коллектора, последний сбор которой не удался.
+func (c *Collector) Errors() []CollectError {
+	result := make([]CollectError, 0)
+	for _, sc := range c.collectors {
+		if state, ok := c.metrics.Error(sc.Name()); ok {
+			result = append(result, CollectError{StatType: sc.StatType(), CollectError: state})
+		}
 	}
+	return result
 }
 
-func (c *Collector) CollectMetrics(timestamp time.Time) {
+// CollectMetrics однократно собирает все типы статистики коллектора.
+func (c *Collector) CollectMetrics(ctx context.Context, timestamp time.Time) {
 	var wg sync.WaitGroup
 
-	for _, statType := range c.statTypes {
+	cfg := c.config.Get().Stats
+	for _, sc := range c.collectors {
+		if !cfg.Enabled(sc.Name()) {
+			continue
+		}
 		wg.Add(1)
-		go func(statType pb.StatType) {
+		go func(sc stats.Collector) {
 			defer wg.Done()
-
-			switch statType {
-			case pb.StatType_LOAD_AVERAGE:
-				c.collectLoadAverage(timestamp)
-			case pb.StatType_CPU_STATS:
-				c.collectCPUStats(timestamp)
-			case pb.StatType_DISKS_LOAD:
-				c.collectDisksLoad(timestamp)
-			case pb.StatType_DISK_USAGE:
-				c.collectDiskUsage(timestamp)
-			}
-		}(statType)
+			c.collect(ctx, sc, timestamp)
+		}(sc)
 	}
 
 	wg.Wait()
 }
 
-func (c *Collector) CollectInitialData() {
+// Start запускает сбор каждого типа статистики со своим периодом из конфигурации.
+// Период перечитывается после каждого сбора, поэтому изменения конфигурации
+// подхватываются без перезапуска.
+func (c *Collector) Start(ctx context.Context) {
+	for _, sc := range c.collectors {
+		go c.loop(ctx, sc)
+	}
+}
+
+func (c *Collector) loop(ctx context.Context, sc stats.Collector) {
+	interval := c.config.Get().Stats.CollectionFor(sc.Name()).Interval
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			cfg := c.config.Get().Stats
+			if cfg.Enabled(sc.Name()) {
+				c.collect(ctx, sc, now)
+			}
+			if next := cfg.CollectionFor(sc.Name()).Interval; next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+func (c *Collector) CollectInitialData(ctx context.Context) {
 	logger.Info(fmt.Sprintf("Starting initial data collection for %v", c.avgPeriod))
 	startTime := time.Now()
 
@@ -107,13 +266,14 @@ func (c *Collector) CollectInitialData() {
 
 	for i := 0; i < collectCount; i++ {
 		currentTime := time.Now()
-		c.CollectMetrics(currentTime)
+		c.CollectMetrics(ctx, currentTime)
 
 		// Если это не последняя итерация, ждем до следующего сбора
 		if i < collectCount-1 {
-			sleepTime := interval - time.Since(currentTime)
-			if sleepTime > 0 {
-				time.Sleep(sleepTime)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval - time.Since(currentTime)):
 			}
 		}
 	}
@@ -121,59 +281,86 @@ func (c *Collector) CollectInitialData() {
 	logger.Info(fmt.Sprintf("Initial data collection completed in %v", time.Since(startTime)))
 }
 
-func (c *Collector) prepareLoadAverageResponse(response *pb.StatsResponse) {
-	if !config.DaemonConfig.Stats.LoadAverage {
-		return
-	}
-	if avgStats := c.metrics.GetAverageLoadAverage(c.avgPeriod); avgStats != nil {
-		response.LoadAverage = converter.LoadAverageToProto(avgStats)
+func (c *Collector) PrepareResponse() *pb.StatsResponse {
+	// Начатые сборы не ждём: медленный сбор одной статистики не должен задерживать
+	// ответы, в них попадают уже сохранённые замеры.
+	cfg := c.config.Get().Stats
+	response := c.response(cfg, time.Now())
+	for _, sc := range c.collectors {
+		if !cfg.Enabled(sc.Name()) {
+			continue
+		}
+		if state, ok := c.metrics.Error(sc.Name()); ok {
+			response.Errors = append(response.Errors, converter.CollectErrorToProto(sc.StatType(), state))
+		}
 	}
-}
 
-func (c *Collector) prepareCPUStatsResponse(response *pb.StatsResponse) {
-	if !config.DaemonConfig.Stats.Cpu {
-		return
-	}
-	if avgStats := c.metrics.GetAverageCPUStats(c.avgPeriod); avgStats != nil {
-		response.CpuStats = converter.CPUStatToProto(avgStats)
+	if !slices.Equal(c.disabled, response.DisabledStatTypes) {
+		logger.Warn(fmt.Sprintf("Stat types disabled in configuration: %v", response.DisabledStatTypes))
+		c.disabled = response.DisabledStatTypes
 	}
-}
 
-func (c *Collector) prepareDisksLoadResponse(response *pb.StatsResponse) {
-	if !config.DaemonConfig.Stats.DiskLoad {
-		return
-	}
-	if avgStats := c.metrics.GetAverageDisksLoad(c.avgPeriod); avgStats != nil {
-		response.DisksLoad = converter.DisksLoadToProto(avgStats)
-	}
+	return response
 }
 
-func (c *Collector) prepareDiskUsageResponse(response *pb.StatsResponse) {
-	if !config.DaemonConfig.Stats.DiskInfo {
-		return
+// Backfill восстанавливает по истории ответы, пропущенные клиентом после since:
+// по одному на каждый интервал отправки вплоть до текущего момента. Интервалы,
+// в окно которых не попало ни одного замера, пропускаются.
+func (c *Collector) Backfill(since time.Time, interval time.Duration) []*pb.StatsResponse {
+	cfg := c.config.Get().Stats
+	now := time.Now()
+	start := since.Add(interval)
+	if earliest := now.Add(-maxBackfill * interval); start.Before(earliest) {
+		start = earliest
 	}
-	if stats := c.metrics.GetLatestDiskUsage(); stats != nil {
-		response.DiskStats = converter.DiskStatsToProto(stats)
+
+	var responses []*pb.StatsResponse
+	for end := start; !end.After(now); end = end.Add(interval) {
+		response := c.response(cfg, end)
+		if !hasSamples(response) {
+			continue
+		}
+		response.Backfill = true
+		responses = append(responses, response)
 	}
+	return responses
 }
 
-func (c *Collector) PrepareResponse() *pb.StatsResponse {
+// response агрегирует замеры запрошенной статистики за окно, заканчивающееся в end.
+func (c *Collector) response(cfg config.Stats, end time.Time) *pb.StatsResponse {
 	response := &pb.StatsResponse{
-		Timestamp: time.Now().Unix(),
+		Timestamp:   end.Unix(),
+		Aggregation: converter.AggregationToProto(c.aggregation),
 	}
 
-	for _, statType := range c.statTypes {
-		switch statType {
-		case pb.StatType_LOAD_AVERAGE:
-			c.prepareLoadAverageResponse(response)
-		case pb.StatType_CPU_STATS:
-			c.prepareCPUStatsResponse(response)
-		case pb.StatType_DISKS_LOAD:
-			c.prepareDisksLoadResponse(response)
-		case pb.StatType_DISK_USAGE:
-			c.prepareDiskUsageResponse(response)
+	for _, sc := range c.collectors {
+		if !cfg.Enabled(sc.Name()) {
+			response.DisabledStatTypes = append(response.DisabledStatTypes, sc.StatType())
+			continue
 		}
+		value, window := c.metrics.Aggregate(sc.Name(), sc, metrics.Query{
+			Period:      c.avgPeriod,
+			Aggregation: c.aggregation,
+			Interval:    cfg.CollectionFor(sc.Name()).Interval,
+			End:         end,
+		})
+		if value != nil {
+			sc.ToProto(value, c.options, response)
+		}
+		response.Coverage = append(response.Coverage, converter.WindowToProto(sc.StatType(), window))
 	}
-
 	return response
 }
+
+func hasSamples(response *pb.StatsResponse) bool {
+	for _, coverage := range response.Coverage {
+		if coverage.SampleCount > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Collector) AllDisabled(response *pb.StatsResponse) bool {
+	return len(response.DisabledStatTypes) == len(c.collectors)
+}
@@ -0,0 +1,105 @@
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfig(t *testing.T) {
+	t.Run("known stats", func(t *testing.T) {
+		require.NoError(t, ValidateConfig(config.Stats{Collectors: map[string]config.Collection{
+			config.StatDiskInfo: {Interval: time.Minute},
+			config.StatCPU:      {Timeout: time.Second},
+		}}))
+	})
+
+	t.Run("unknown stat", func(t *testing.T) {
+		err := ValidateConfig(config.Stats{Collectors: map[string]config.Collection{
+			"network": {Interval: time.Second},
+		}})
+		require.ErrorContains(t, err, "stats.collectors.network: unknown stat, expected one of load_average")
+	})
+
+	t.Run("history shorter than forecast period", func(t *testing.T) {
+		cfg := config.Stats{
+			Limit:      500,
+			Retention:  30 * time.Minute,
+			DiskInfo:   true,
+			Collection: config.Collection{Interval: time.Second},
+		}
+		err := ValidateConfig(cfg)
+		require.ErrorContains(t, err, "stats.limit: 500 samples of disk_info every 1s cover only 8m20s, forecast needs 1h0m0s")
+		require.ErrorContains(t, err, "stats.retention: 30m0s is shorter than disk_info forecast period 1h0m0s")
+
+		cfg.Retention = 0
+		cfg.Collectors = map[string]config.Collection{config.StatDiskInfo: {Interval: 10 * time.Second}}
+		require.NoError(t, ValidateConfig(cfg))
+
+		cfg.DiskInfo = false
+		cfg.Collectors = nil
+		require.NoError(t, ValidateConfig(cfg))
+	})
+}
+
+func TestEnabled(t *testing.T) {
+	stats := config.Stats{LoadAverage: true}
+	require.True(t, Enabled(stats, pb.StatType_LOAD_AVERAGE))
+	require.False(t, Enabled(stats, pb.StatType_CPU_STATS))
+	require.False(t, Enabled(stats, pb.StatType(100)))
+}
+
+func TestPrepareResponseErrors(t *testing.T) {
+	cfg := config.Stats{
+		Limit:   10,
+		Plugins: []config.Plugin{{Name: "broken", Command: "false"}},
+	}
+	c := New(metrics.New(cfg), config.NewStore(&config.Config{Stats: cfg}),
+		[]pb.StatType{pb.StatType_CUSTOM}, time.Second, metrics.AggregationMean, stats.Options{})
+
+	c.CollectMetrics(context.Background(), time.Now())
+	c.CollectMetrics(context.Background(), time.Now())
+
+	response := c.PrepareResponse()
+	require.Nil(t, response.GetCustomMetrics())
+	require.Len(t, response.GetErrors(), 1)
+	collectErr := response.GetErrors()[0]
+	require.Equal(t, pb.StatType_CUSTOM, collectErr.GetStatType())
+	require.Contains(t, collectErr.GetMessage(), "plugin broken:")
+	require.Equal(t, int32(2), collectErr.GetConsecutiveFailures())
+	require.Len(t, c.Errors(), 1)
+}
+
+func TestBackfill(t *testing.T) {
+	cfg := config.Stats{Limit: 100, LoadAverage: true}
+	storage := metrics.New(cfg)
+	c := New(storage, config.NewStore(&config.Config{Stats: cfg}),
+		[]pb.StatType{pb.StatType_LOAD_AVERAGE}, 2*time.Second, metrics.AggregationMean, stats.Options{})
+
+	now := time.Now()
+	for i := 10; i >= 6; i-- {
+		storage.Store("load_average", &models.LoadAverage{Load1Min: float64(i)}, now.Add(-time.Duration(i)*time.Second))
+	}
+
+	t.Run("intervals with samples", func(t *testing.T) {
+		responses := c.Backfill(now.Add(-12*time.Second), 2*time.Second)
+		require.Len(t, responses, 4)
+		for i, response := range responses {
+			require.True(t, response.GetBackfill())
+			require.Equal(t, now.Add(time.Duration(2*i-10)*time.Second).Unix(), response.GetTimestamp())
+			require.Nil(t, response.GetErrors())
+		}
+		require.InDelta(t, 9.5, responses[1].GetLoadAverage().GetLoad1Min(), 0.01)
+	})
+
+	t.Run("nothing missed", func(t *testing.T) {
+		require.Empty(t, c.Backfill(now.Add(-3*time.Second), 2*time.Second))
+	})
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Client — параметры подписки клиента. Клиент читает тот же файл и те же
+// переменные окружения APP_*, что и демон, адрес сервера берётся из server.
+type Client struct {
+	Stats           []string `mapstructure:"stats" env:"CLIENT_STATS"`
+	Interval        int      `mapstructure:"interval" env:"CLIENT_INTERVAL"`
+	AveragingPeriod int      `mapstructure:"averaging_period" env:"CLIENT_AVERAGING_PERIOD"`
+	Aggregation     string   `mapstructure:"aggregation" env:"CLIENT_AGGREGATION"`
+	TopN            int      `mapstructure:"top_n" env:"CLIENT_TOP_N"`
+	Output          string   `mapstructure:"output" env:"CLIENT_OUTPUT"`
+}
+
+// Addr возвращает адрес для подключения к серверу. Адрес прослушивания
+// всех интерфейсов заменяется на localhost.
+func (s Server) Addr() string {
+	host := s.Host
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, s.Port)
+}
+
+// InitClientConfig разбирает флаги клиента и читает конфигурацию демона.
+// Собственные флаги клиент объявляет в pflag до вызова. Файл конфигурации
+// по умолчанию может отсутствовать, тогда используются флаги и окружение.
+// Значения по умолчанию общие с демоном, секция client проверяется сразу.
+func InitClientConfig() (*Config, error) {
+	defaults := initSettings()
+	configFilePath := pflag.String("config", "./_configs/config.yaml", "Config file")
+	pflag.String("host", defaults.Server.Host, "Server host, an unspecified address means localhost")
+	pflag.String("port", defaults.Server.Port, "Server port")
+	pflag.StringSlice("stats", defaults.Client.Stats, "Comma-separated stat types to request")
+	pflag.Int("interval", defaults.Client.Interval, "Interval between responses in seconds")
+	pflag.Int("averaging-period", defaults.Client.AveragingPeriod, "Averaging period in seconds")
+	pflag.String("aggregation", defaults.Client.Aggregation,
+		"Aggregation function: mean, min, max, p50, p95, p99, last")
+	pflag.Int("top-n", defaults.Client.TopN, "Number of top processes")
+	pflag.String("output", defaults.Client.Output, "Output format")
+	pflag.Parse()
+
+	viper.SetConfigFile(*configFilePath)
+
+	viper.AutomaticEnv()
+	viper.SetEnvPrefix("APP")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	for key, flag := range map[string]string{
+		"server.host":             "host",
+		"server.port":             "port",
+		"client.stats":            "stats",
+		"client.interval":         "interval",
+		"client.averaging_period": "averaging-period",
+		"client.aggregation":      "aggregation",
+		"client.top_n":            "top-n",
+		"client.output":           "output",
+	} {
+		if err := viper.BindPFlag(key, pflag.Lookup(flag)); err != nil {
+			return nil, fmt.Errorf("failed to bind %s flag: %w", flag, err)
+		}
+	}
+
+	if err := viper.ReadInConfig(); err != nil {
+		if !errors.Is(err, os.ErrNotExist) || pflag.Lookup("config").Changed {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	config := initSettings()
+	if err := viper.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	if err := config.Client.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid client config: %w", err)
+	}
+	return &config, nil
+}
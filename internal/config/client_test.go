@@ -0,0 +1,21 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerAddr(t *testing.T) {
+	for host, want := range map[string]string{
+		"":              "localhost:8080",
+		"0.0.0.0":       "localhost:8080",
+		"::":            "localhost:8080",
+		"10.0.0.5":      "10.0.0.5:8080",
+		"stats.example": "stats.example:8080",
+	} {
+		t.Run(host, func(t *testing.T) {
+			require.Equal(t, want, Server{Host: host, Port: "8080"}.Addr())
+		})
+	}
+}
@@ -5,43 +5,200 @@
 package config
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/cepmap/otus-system-monitoring/internal/logger"
-	"github.com/cepmap/otus-system-monitoring/internal/tools"
+	"github.com/fsnotify/fsnotify"
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
-//nolint:stylecheck,revive
 type Config struct {
-	Log struct {
-		Level string `mapstructure:"level" env:"LOG_LEVEL"`
-	} `mapstructure:"log"`
-	Server struct {
-		Host string `mapstructure:"host" env:"SERVER_HOST"`
-		Port string `mapstructure:"port" env:"SERVER_PORT"`
-	} `mapstructure:"server"`
-	Stats struct {
-		Limit       int64 `mapstructure:"limit" env:"STATS_LIMIT"`
-		LoadAverage bool  `mapstructure:"load_average" env:"STATS_LOAD_AVERAGE"`
-		Cpu         bool  `mapstructure:"CPU" env:"STATS_CPU"`
-		DiskInfo    bool  `mapstructure:"disk_info" env:"STATS_DISK_INFO"`
-		DiskLoad    bool  `mapstructure:"disk_load" env:"STATS_DISK_LOAD"`
-	} `mapstructure:"stats"`
-}
-
-var DaemonConfig *Config
-
-func InitConfig() error {
-	initSettings := initSettings()
-	DaemonConfig = &initSettings
+	Log    Log    `mapstructure:"log"`
+	Server Server `mapstructure:"server"`
+	Stats  Stats  `mapstructure:"stats"`
+	Alerts Alerts `mapstructure:"alerts"`
+	Client Client `mapstructure:"client"`
+}
+
+type Log struct {
+	Level string `mapstructure:"level" env:"LOG_LEVEL"`
+}
+
+type Server struct {
+	Host string `mapstructure:"host" env:"SERVER_HOST"`
+	Port string `mapstructure:"port" env:"SERVER_PORT"`
+	// MaxStreams ограничивает число одновременно открытых потоков, 0 — без ограничения.
+	MaxStreams int `mapstructure:"max_streams" env:"SERVER_MAX_STREAMS"`
+}
+
+//nolint:stylecheck,revive
+type Stats struct {
+	Limit      int64                 `mapstructure:"limit" env:"STATS_LIMIT"`
+	Retention  time.Duration         `mapstructure:"retention" env:"STATS_RETENTION"`
+	Collection Collection            `mapstructure:"collection"`
+	Collectors map[string]Collection `mapstructure:"collectors"`
+	Plugins    []Plugin              `mapstructure:"plugins"`
+	Cgroups    Cgroups               `mapstructure:"cgroups"`
+	// Procfs и Sysfs — каталоги procfs и sysfs, пустые значения — /proc и /sys.
+	// Чтобы наблюдать за хостом из контейнера, сюда указывают, например, /host/proc.
+	Procfs      string `mapstructure:"procfs" env:"STATS_PROCFS"`
+	Sysfs       string `mapstructure:"sysfs" env:"STATS_SYSFS"`
+	LoadAverage bool   `mapstructure:"load_average" env:"STATS_LOAD_AVERAGE"`
+	Cpu         bool   `mapstructure:"cpu" env:"STATS_CPU"`
+	DiskInfo    bool   `mapstructure:"disk_info" env:"STATS_DISK_INFO"`
+	DiskLoad    bool   `mapstructure:"disk_load" env:"STATS_DISK_LOAD"`
+}
+
+// Collection задаёт период опроса и таймаут одного сбора статистики.
+// Enabled включает статистику, для которой нет отдельного флага в Stats.
+type Collection struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// Plugin описывает внешнюю команду, которая печатает метрики в stdout
+// строками "name=value" или JSON-объектом {"name": value}.
+// Плагины запускаются с периодом статистики custom.
+type Plugin struct {
+	Name    string        `mapstructure:"name"`
+	Command string        `mapstructure:"command"`
+	Args    []string      `mapstructure:"args"`
+	Timeout time.Duration `mapstructure:"timeout"`
+	// MaxOutput ограничивает размер вывода в байтах.
+	MaxOutput int64 `mapstructure:"max_output"`
+}
+
+// Cgroups ограничивает статистику cgroup теми, чей путь от корня cgroupfs
+// начинается с Prefix, например "/kubepods.slice". Пустой префикс — все cgroup.
+type Cgroups struct {
+	Prefix string `mapstructure:"prefix" env:"STATS_CGROUPS_PREFIX"`
+}
+
+// Имена статистики в конфигурации, по ним настраиваются отдельные сборщики.
+const (
+	StatLoadAverage = "load_average"
+	StatCPU         = "cpu"
+	StatDiskInfo    = "disk_info"
+	StatDiskLoad    = "disk_load"
+	StatCustom      = "custom"
+)
+
+const (
+	defaultCollectInterval = time.Second
+	defaultCollectTimeout  = 5 * time.Second
+)
+
+// Enabled сообщает, включён ли сбор статистики name.
+func (s Stats) Enabled(name string) bool {
+	switch name {
+	case StatLoadAverage:
+		return s.LoadAverage
+	case StatCPU:
+		return s.Cpu
+	case StatDiskInfo:
+		return s.DiskInfo
+	case StatDiskLoad:
+		return s.DiskLoad
+	case StatCustom:
+		return len(s.Plugins) > 0
+	default:
+		return s.Collectors[name].Enabled
+	}
+}
+
+// CollectionFor возвращает настройки сбора статистики name; незаданные значения
+// берутся из stats.collection, а затем из значений по умолчанию.
+func (s Stats) CollectionFor(name string) Collection {
+	collection := s.Collectors[name]
+	if collection.Interval <= 0 {
+		collection.Interval = s.Collection.Interval
+	}
+	if collection.Interval <= 0 {
+		collection.Interval = defaultCollectInterval
+	}
+	if collection.Timeout <= 0 {
+		collection.Timeout = s.Collection.Timeout
+	}
+	if collection.Timeout <= 0 {
+		collection.Timeout = defaultCollectTimeout
+	}
+	return collection
+}
+
+type Alerts struct {
+	Interval      time.Duration `mapstructure:"interval" env:"ALERTS_INTERVAL"`
+	Rules         []AlertRule   `mapstructure:"rules"`
+	Notifications Notifications `mapstructure:"notifications"`
+}
+
+type Notifications struct {
+	GroupWait      time.Duration `mapstructure:"group_wait" env:"ALERTS_NOTIFICATIONS_GROUP_WAIT"`
+	RepeatInterval time.Duration `mapstructure:"repeat_interval" env:"ALERTS_NOTIFICATIONS_REPEAT_INTERVAL"`
+	Webhooks       []Webhook     `mapstructure:"webhooks"`
+	Exec           []ExecHook    `mapstructure:"exec"`
+}
+
+// AlertRule описывает правило вида "cpu.idle < 10 for 60s".
+// Target ограничивает правило одним диском или точкой монтирования, пустое значение — все.
+type AlertRule struct {
+	Name       string        `mapstructure:"name"`
+	Metric     string        `mapstructure:"metric"`
+	Target     string        `mapstructure:"target"`
+	Operator   string        `mapstructure:"operator"`
+	Threshold  float64       `mapstructure:"threshold"`
+	For        time.Duration `mapstructure:"for"`
+	Window     time.Duration `mapstructure:"window"`
+	Hysteresis float64       `mapstructure:"hysteresis"`
+}
+
+type Webhook struct {
+	URL        string        `mapstructure:"url"`
+	Timeout    time.Duration `mapstructure:"timeout"`
+	MaxRetries int           `mapstructure:"max_retries"`
+	Backoff    time.Duration `mapstructure:"backoff"`
+}
 
+type ExecHook struct {
+	Command string        `mapstructure:"command"`
+	Args    []string      `mapstructure:"args"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Store хранит текущую конфигурацию. Значение не изменяется после публикации,
+// перечитанная конфигурация подменяет его целиком.
+type Store struct {
+	current atomic.Pointer[Config]
+}
+
+func NewStore(config *Config) *Store {
+	s := &Store{}
+	s.current.Store(config)
+	return s
+}
+
+func (s *Store) Get() *Config {
+	return s.current.Load()
+}
+
+func (s *Store) Set(config *Config) {
+	s.current.Store(config)
+}
+
+func InitConfig() (*Config, error) {
 	configFilePath := pflag.String("config", "./_configs/config.yaml", "Config file")
 	pflag.String("loglevel", "info", "Log level")
 	pflag.String("host", "0.0.0.0", "Server host")
 	pflag.String("port", "8080", "Server port")
+	pflag.Bool("watch-config", false, "Reload config on file change")
+	pflag.Bool("check-config", false, "Validate config and exit")
 	pflag.Parse()
 
 	viper.SetConfigFile(*configFilePath)
@@ -50,60 +207,122 @@ func InitConfig() error {
 	viper.SetEnvPrefix("APP")
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
-	if err := viper.ReadInConfig(); err != nil {
-		return fmt.Errorf("failed to read config: %w", err)
-	}
-
 	if err := viper.BindPFlag("log.level", pflag.Lookup("loglevel")); err != nil {
-		return fmt.Errorf("failed to bind log level flag: %w", err)
+		return nil, fmt.Errorf("failed to bind log level flag: %w", err)
 	}
 	if err := viper.BindPFlag("server.host", pflag.Lookup("host")); err != nil {
-		return fmt.Errorf("failed to bind host flag: %w", err)
+		return nil, fmt.Errorf("failed to bind host flag: %w", err)
 	}
 	if err := viper.BindPFlag("server.port", pflag.Lookup("port")); err != nil {
-		return fmt.Errorf("failed to bind port flag: %w", err)
+		return nil, fmt.Errorf("failed to bind port flag: %w", err)
+	}
+	if err := viper.BindPFlag("config.watch", pflag.Lookup("watch-config")); err != nil {
+		return nil, fmt.Errorf("failed to bind watch config flag: %w", err)
 	}
+	if err := viper.BindPFlag("config.check", pflag.Lookup("check-config")); err != nil {
+		return nil, fmt.Errorf("failed to bind check config flag: %w", err)
+	}
+
+	return Reload()
+}
 
-	if err := viper.Unmarshal(DaemonConfig); err != nil {
-		return fmt.Errorf("failed to unmarshal config: %w", err)
+// Reload перечитывает файл конфигурации. Применять новое значение должен вызывающий.
+// Ошибки проверки возвращаются вместе с прочитанной конфигурацией.
+func Reload() (*Config, error) {
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
 
-	checkCommands(DaemonConfig)
-	return nil
+	config := initSettings()
+	var metadata mapstructure.Metadata
+	if err := viper.Unmarshal(&config, func(dc *mapstructure.DecoderConfig) {
+		dc.Metadata = &metadata
+	}); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	for _, key := range unknownKeys(metadata.Unused) {
+		logger.Warn(fmt.Sprintf("Unknown config key %s is ignored", key))
+	}
+
+	// Конфигурацию с ошибками тоже возвращаем, чтобы её можно было проверить дальше.
+	if err := config.Validate(); err != nil {
+		return &config, err
+	}
+
+	return &config, nil
 }
 
-//nolint:stylecheck,revive
-func initSettings() Config {
-	config := Config{
-		Log: struct {
-			Level string `mapstructure:"level" env:"LOG_LEVEL"`
-		}{Level: "DEBUG"},
-		Server: struct {
-			Host string `mapstructure:"host" env:"SERVER_HOST"`
-			Port string `mapstructure:"port" env:"SERVER_PORT"`
-		}{Host: "0.0.0.0", Port: "8080"},
-		Stats: struct {
-			Limit       int64 `mapstructure:"limit" env:"STATS_LIMIT"`
-			LoadAverage bool  `mapstructure:"load_average" env:"STATS_LOAD_AVERAGE"`
-			Cpu         bool  `mapstructure:"CPU" env:"STATS_CPU"`
-			DiskInfo    bool  `mapstructure:"disk_info" env:"STATS_DISK_INFO"`
-			DiskLoad    bool  `mapstructure:"disk_load" env:"STATS_DISK_LOAD"`
-		}{LoadAverage: true, Cpu: false, DiskInfo: false, DiskLoad: false},
-	}
-	return config
-}
-
-func checkCommands(config *Config) {
-	if config.Stats.Cpu {
-		if err := tools.CheckCommand("iostat"); err != nil {
-			logger.Error("command iostat not found, disabling cpu stats collection")
-			config.Stats.Cpu = false
-		}
+// CheckOnly сообщает, что нужно только проверить конфигурацию и завершиться.
+func CheckOnly() bool {
+	return viper.GetBool("config.check")
+}
+
+// WatchEnabled сообщает, нужно ли перечитывать конфигурацию при изменении файла.
+func WatchEnabled() bool {
+	return viper.GetBool("config.watch")
+}
+
+// Watch сообщает в канал об изменении файла конфигурации до отмены ctx.
+// Сам файл не перечитывается: Reload вызывает получатель, поэтому viper
+// читает конфигурацию только из одной горутины. Частые изменения сливаются в одно.
+func Watch(ctx context.Context) (<-chan struct{}, error) {
+	file := filepath.Clean(viper.ConfigFileUsed())
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config watcher: %w", err)
+	}
+	// Каталог, а не файл: редакторы и ConfigMap заменяют файл новым.
+	if err := watcher.Add(filepath.Dir(file)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch config: %w", err)
 	}
-	if config.Stats.DiskLoad {
-		if err := tools.CheckCommand("iostat"); err != nil {
-			logger.Error("command iostat not found, disabling disk load stats collection")
-			config.Stats.DiskLoad = false
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != file || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Warn(fmt.Sprintf("Config watcher error: %v", err))
+			}
 		}
+	}()
+	return changes, nil
+}
+
+func initSettings() Config {
+	return Config{
+		Log:    Log{Level: "DEBUG"},
+		Server: Server{Host: "0.0.0.0", Port: "8080"},
+		Stats: Stats{
+			Limit:       500,
+			Retention:   24 * time.Hour,
+			Collection:  Collection{Interval: defaultCollectInterval, Timeout: defaultCollectTimeout},
+			LoadAverage: true,
+		},
+		Client: Client{
+			Stats:           []string{"load_average", "cpu_stats", "disks_load", "disk_usage"},
+			Interval:        1,
+			AveragingPeriod: 2,
+			Aggregation:     "mean",
+			TopN:            10,
+			Output:          "table",
+		},
 	}
 }
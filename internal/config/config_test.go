@@ -0,0 +1,42 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("log:\n  level: INFO\n"), 0o600))
+	viper.SetConfigFile(file)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes, err := Watch(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.yaml"), []byte("x: 1\n"), 0o600))
+	select {
+	case <-changes:
+		t.Fatal("change of another file must be ignored")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	require.NoError(t, os.WriteFile(file, []byte("log:\n  level: DEBUG\n"), 0o600))
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("config change was not reported")
+	}
+
+	config, err := Reload()
+	require.NoError(t, err)
+	require.Equal(t, "DEBUG", config.Log.Level)
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cepmap/otus-system-monitoring/internal/logger"
+)
+
+// FieldError описывает проблему в одном поле конфигурации.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) addf(field, format string, args ...interface{}) {
+	v.errs = append(v.errs, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) collection(field string, collection Collection) {
+	if collection.Interval < 0 {
+		v.addf(field+".interval", "must not be negative, got %v", collection.Interval)
+	}
+	if collection.Timeout < 0 {
+		v.addf(field+".timeout", "must not be negative, got %v", collection.Timeout)
+	}
+}
+
+// Validate проверяет конфигурацию целиком и возвращает все найденные проблемы сразу.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	if !logger.ValidLevel(c.Log.Level) {
+		v.addf("log.level", "unknown level %q, expected one of %s, %s, %s, %s",
+			c.Log.Level, logger.DebugLevel, logger.InfoLevel, logger.WarnLevel, logger.ErrorLevel)
+	}
+
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 0 || port > 65535 {
+		v.addf("server.port", "must be a number from 0 to 65535, got %q", c.Server.Port)
+	}
+	if c.Server.MaxStreams < 0 {
+		v.addf("server.max_streams", "must not be negative, got %d", c.Server.MaxStreams)
+	}
+
+	if c.Stats.Limit < 1 {
+		v.addf("stats.limit", "must be positive, got %d", c.Stats.Limit)
+	}
+	if c.Stats.Retention < 0 {
+		v.addf("stats.retention", "must not be negative, got %v", c.Stats.Retention)
+	}
+	v.collection("stats.collection", c.Stats.Collection)
+	names := make([]string, 0, len(c.Stats.Collectors))
+	for name := range c.Stats.Collectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v.collection("stats.collectors."+name, c.Stats.Collectors[name])
+	}
+
+	if c.Stats.Procfs != "" && !filepath.IsAbs(c.Stats.Procfs) {
+		v.addf("stats.procfs", "must be an absolute path, got %q", c.Stats.Procfs)
+	}
+	if c.Stats.Sysfs != "" && !filepath.IsAbs(c.Stats.Sysfs) {
+		v.addf("stats.sysfs", "must be an absolute path, got %q", c.Stats.Sysfs)
+	}
+
+	if prefix := c.Stats.Cgroups.Prefix; prefix != "" && !strings.HasPrefix(prefix, "/") {
+		v.addf("stats.cgroups.prefix", "must start with /, got %q", prefix)
+	}
+
+	plugins := make(map[string]struct{}, len(c.Stats.Plugins))
+	// Плагины работают внутри сбора custom, больший таймаут всё равно оборвётся раньше.
+	customTimeout := c.Stats.CollectionFor(StatCustom).Timeout
+	for i, plugin := range c.Stats.Plugins {
+		field := fmt.Sprintf("stats.plugins[%d]", i)
+		if plugin.Name == "" {
+			v.addf(field+".name", "must not be empty")
+		} else if _, ok := plugins[plugin.Name]; ok {
+			v.addf(field+".name", "duplicate plugin name %q", plugin.Name)
+		}
+		plugins[plugin.Name] = struct{}{}
+		if plugin.Command == "" {
+			v.addf(field+".command", "must not be empty")
+		}
+		if plugin.Timeout < 0 {
+			v.addf(field+".timeout", "must not be negative, got %v", plugin.Timeout)
+		} else if plugin.Timeout > customTimeout {
+			v.addf(field+".timeout", "must not exceed stats.collectors.%s.timeout %v, got %v",
+				StatCustom, customTimeout, plugin.Timeout)
+		}
+		if plugin.MaxOutput < 0 {
+			v.addf(field+".max_output", "must not be negative, got %d", plugin.MaxOutput)
+		}
+	}
+
+	if c.Alerts.Interval < 0 {
+		v.addf("alerts.interval", "must not be negative, got %v", c.Alerts.Interval)
+	}
+	for i, rule := range c.Alerts.Rules {
+		field := fmt.Sprintf("alerts.rules[%d]", i)
+		if rule.Name == "" {
+			v.addf(field+".name", "must not be empty")
+		}
+		if rule.Metric == "" {
+			v.addf(field+".metric", "must not be empty")
+		}
+		if rule.For < 0 {
+			v.addf(field+".for", "must not be negative, got %v", rule.For)
+		}
+		if rule.Window < 0 {
+			v.addf(field+".window", "must not be negative, got %v", rule.Window)
+		}
+		if rule.Hysteresis < 0 {
+			v.addf(field+".hysteresis", "must not be negative, got %v", rule.Hysteresis)
+		}
+	}
+
+	notifications := c.Alerts.Notifications
+	if notifications.GroupWait < 0 {
+		v.addf("alerts.notifications.group_wait", "must not be negative, got %v", notifications.GroupWait)
+	}
+	if notifications.RepeatInterval < 0 {
+		v.addf("alerts.notifications.repeat_interval", "must not be negative, got %v", notifications.RepeatInterval)
+	}
+	for i, webhook := range notifications.Webhooks {
+		field := fmt.Sprintf("alerts.notifications.webhooks[%d]", i)
+		if u, err := url.Parse(webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			v.addf(field+".url", "must be an http or https URL, got %q", webhook.URL)
+		}
+		if webhook.MaxRetries < 0 {
+			v.addf(field+".max_retries", "must not be negative, got %d", webhook.MaxRetries)
+		}
+	}
+	for i, hook := range notifications.Exec {
+		if hook.Command == "" {
+			v.addf(fmt.Sprintf("alerts.notifications.exec[%d].command", i), "must not be empty")
+		}
+	}
+
+	v.client(c.Client)
+
+	return errors.Join(v.errs...)
+}
+
+// Validate проверяет параметры подписки клиента.
+func (c Client) Validate() error {
+	v := &validator{}
+	v.client(c)
+	return errors.Join(v.errs...)
+}
+
+func (v *validator) client(c Client) {
+	if len(c.Stats) == 0 {
+		v.addf("client.stats", "must not be empty")
+	}
+	if c.Interval < 1 {
+		v.addf("client.interval", "must be positive, got %d", c.Interval)
+	}
+	if c.AveragingPeriod < 1 {
+		v.addf("client.averaging_period", "must be positive, got %d", c.AveragingPeriod)
+	}
+	if c.TopN < 0 {
+		v.addf("client.top_n", "must not be negative, got %d", c.TopN)
+	}
+	if c.Aggregation == "" {
+		v.addf("client.aggregation", "must not be empty")
+	}
+	if c.Output == "" {
+		v.addf("client.output", "must not be empty")
+	}
+}
+
+// unknownKeys отбрасывает ключи, которые viper получает из флагов, а не из файла.
+func unknownKeys(unused []string) []string {
+	keys := make([]string, 0, len(unused))
+	for _, key := range unused {
+		if key == "config" || strings.HasPrefix(key, "config.") {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
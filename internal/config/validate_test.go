@@ -0,0 +1,152 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("defaults are valid", func(t *testing.T) {
+		config := initSettings()
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("all problems reported", func(t *testing.T) {
+		config := initSettings()
+		config.Log.Level = "verbose"
+		config.Server.Port = "80a"
+		config.Server.MaxStreams = -1
+		config.Stats.Limit = -1
+		config.Alerts.Rules = []AlertRule{{Name: "", Metric: "cpu.idle", For: -time.Second}}
+		config.Alerts.Notifications.Webhooks = []Webhook{{URL: "ftp://example.com"}}
+
+		err := config.Validate()
+		require.Error(t, err)
+
+		var fields []string
+		for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+			var fieldErr *FieldError
+			require.True(t, errors.As(e, &fieldErr))
+			fields = append(fields, fieldErr.Field)
+		}
+		require.Equal(t, []string{
+			"log.level",
+			"server.port",
+			"server.max_streams",
+			"stats.limit",
+			"alerts.rules[0].name",
+			"alerts.rules[0].for",
+			"alerts.notifications.webhooks[0].url",
+		}, fields)
+	})
+
+	t.Run("collectors", func(t *testing.T) {
+		config := initSettings()
+		config.Stats.Collectors = map[string]Collection{
+			StatDiskInfo: {Timeout: -time.Second},
+			"network":    {Interval: -time.Second},
+		}
+
+		err := config.Validate()
+		require.ErrorContains(t, err, "stats.collectors.disk_info.timeout")
+		require.ErrorContains(t, err, "stats.collectors.network.interval")
+	})
+
+	t.Run("plugins", func(t *testing.T) {
+		config := initSettings()
+		config.Stats.Plugins = []Plugin{
+			{Name: "raid", Command: "/usr/local/bin/raid-status"},
+			{Name: "raid", Timeout: -time.Second},
+		}
+
+		err := config.Validate()
+		require.ErrorContains(t, err, `stats.plugins[1].name: duplicate plugin name "raid"`)
+		require.ErrorContains(t, err, "stats.plugins[1].command: must not be empty")
+		require.ErrorContains(t, err, "stats.plugins[1].timeout")
+
+		config.Stats.Plugins = []Plugin{{Name: "raid", Command: "/usr/local/bin/raid-status", Timeout: time.Minute}}
+		require.ErrorContains(t, config.Validate(),
+			"stats.plugins[0].timeout: must not exceed stats.collectors.custom.timeout 5s, got 1m0s")
+		config.Stats.Collectors = map[string]Collection{StatCustom: {Timeout: 2 * time.Minute}}
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("cgroups prefix", func(t *testing.T) {
+		config := initSettings()
+		config.Stats.Cgroups.Prefix = "system.slice"
+		require.ErrorContains(t, config.Validate(), "stats.cgroups.prefix: must start with /")
+
+		config.Stats.Cgroups.Prefix = "/system.slice"
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("procfs and sysfs roots", func(t *testing.T) {
+		config := initSettings()
+		config.Stats.Procfs = "host/proc"
+		config.Stats.Sysfs = "host/sys"
+		err := config.Validate()
+		require.ErrorContains(t, err, "stats.procfs: must be an absolute path")
+		require.ErrorContains(t, err, "stats.sysfs: must be an absolute path")
+
+		config.Stats.Procfs = "/host/proc"
+		config.Stats.Sysfs = "/host/sys"
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("client", func(t *testing.T) {
+		config := initSettings()
+		require.NoError(t, config.Client.Validate())
+
+		config.Client.Stats = nil
+		config.Client.Interval = 0
+		config.Client.TopN = -1
+		err := config.Validate()
+		require.ErrorContains(t, err, "client.stats: must not be empty")
+		require.ErrorContains(t, err, "client.interval: must be positive")
+		require.ErrorContains(t, err, "client.top_n: must not be negative")
+		require.Equal(t, err.Error(), config.Client.Validate().Error())
+	})
+
+	t.Run("log level is case insensitive", func(t *testing.T) {
+		config := initSettings()
+		config.Log.Level = "warn"
+		require.NoError(t, config.Validate())
+	})
+}
+
+func TestUnknownKeys(t *testing.T) {
+	require.Equal(t, []string{"extra", "stats.bogus"},
+		unknownKeys([]string{"stats.bogus", "config.watch", "extra", "config"}))
+}
+
+func TestCollectionFor(t *testing.T) {
+	stats := Stats{
+		Collection: Collection{Interval: 2 * time.Second},
+		Collectors: map[string]Collection{
+			StatDiskInfo: {Interval: time.Minute, Timeout: 30 * time.Second},
+		},
+	}
+
+	require.Equal(t, Collection{Interval: time.Minute, Timeout: 30 * time.Second}, stats.CollectionFor(StatDiskInfo))
+	require.Equal(t, Collection{Interval: 2 * time.Second, Timeout: defaultCollectTimeout}, stats.CollectionFor(StatCPU))
+	require.Equal(t, Collection{Interval: defaultCollectInterval, Timeout: defaultCollectTimeout},
+		Stats{}.CollectionFor(StatLoadAverage))
+}
+
+func TestStatsEnabled(t *testing.T) {
+	stats := Stats{
+		Cpu:        true,
+		Collectors: map[string]Collection{"plugin": {Enabled: true}, StatDiskInfo: {Enabled: true}},
+	}
+
+	require.True(t, stats.Enabled(StatCPU))
+	require.False(t, stats.Enabled(StatDiskInfo), "builtin stats are switched by their own flag")
+	require.True(t, stats.Enabled("plugin"))
+	require.False(t, stats.Enabled(StatCustom))
+	stats.Plugins = []Plugin{{Name: "raid", Command: "raid-status"}}
+	require.True(t, stats.Enabled(StatCustom))
+	require.False(t, stats.Enabled("unknown"))
+}
@@ -2,6 +2,7 @@ package converter
 
 import (
 	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
 	"github.com/cepmap/otus-system-monitoring/internal/models"
 )
 
@@ -10,9 +11,12 @@ func LoadAverageToProto(la *models.LoadAverage) *pb.LoadAverage {
 		return nil
 	}
 	return &pb.LoadAverage{
-		Load1Min:  la.Load1Min,
-		Load5Min:  la.Load5Min,
-		Load15Min: la.Load15Min,
+		Load1Min:      la.Load1Min,
+		Load5Min:      la.Load5Min,
+		Load15Min:     la.Load15Min,
+		RunnableTasks: la.RunnableTasks,
+		TotalTasks:    la.TotalTasks,
+		LastPid:       la.LastPid,
 	}
 }
 
@@ -45,6 +49,126 @@ func DisksLoadToProto(dl *models.DisksLoad) *pb.DisksLoad {
 	}
 }
 
+func CustomMetricsToProto(cm *models.CustomMetrics) []*pb.CustomMetric {
+	if cm == nil {
+		return nil
+	}
+
+	result := make([]*pb.CustomMetric, len(cm.Metrics))
+	for i, metric := range cm.Metrics {
+		result[i] = &pb.CustomMetric{
+			Plugin: metric.Plugin,
+			Name:   metric.Name,
+			Value:  metric.Value,
+		}
+	}
+	return result
+}
+
+func ProcessesToProto(processes []models.Process) []*pb.Process {
+	result := make([]*pb.Process, len(processes))
+	for i, process := range processes {
+		result[i] = &pb.Process{
+			Pid:        process.Pid,
+			Command:    process.Command,
+			User:       process.User,
+			Threads:    process.Threads,
+			CpuPercent: process.CPUPercent,
+			RssBytes:   process.RSS,
+		}
+	}
+	return result
+}
+
+func PressureToProto(p *models.Pressure) *pb.Pressure {
+	if p == nil {
+		return nil
+	}
+
+	resource := func(r models.PressureResource) *pb.PressureResource {
+		return &pb.PressureResource{Some: pressureStallToProto(r.Some), Full: pressureStallToProto(r.Full)}
+	}
+	return &pb.Pressure{
+		Supported: p.Supported,
+		Cpu:       resource(p.CPU),
+		Memory:    resource(p.Memory),
+		Io:        resource(p.IO),
+	}
+}
+
+func pressureStallToProto(s models.PressureStall) *pb.PressureStall {
+	return &pb.PressureStall{
+		Avg10:        s.Avg10,
+		Avg60:        s.Avg60,
+		Avg300:       s.Avg300,
+		TotalDeltaUs: s.Total,
+	}
+}
+
+func CgroupsToProto(c *models.Cgroups) *pb.Cgroups {
+	if c == nil {
+		return nil
+	}
+
+	cgroups := make([]*pb.Cgroup, len(c.Cgroups))
+	for i, cgroup := range c.Cgroups {
+		cgroups[i] = &pb.Cgroup{
+			Path:               cgroup.Path,
+			CpuPercent:         cgroup.CPUPercent,
+			MemoryCurrentBytes: cgroup.MemoryCurrent,
+			MemoryMaxBytes:     cgroup.MemoryMax,
+			IoReadBytes:        cgroup.IOReadBytes,
+			IoWriteBytes:       cgroup.IOWriteBytes,
+		}
+	}
+	return &pb.Cgroups{Cgroups: cgroups}
+}
+
+func DaemonSelfToProto(d *models.DaemonSelf) *pb.DaemonSelf {
+	if d == nil {
+		return nil
+	}
+
+	collectors := make([]*pb.DaemonCollector, len(d.Collectors))
+	for i, c := range d.Collectors {
+		collectors[i] = &pb.DaemonCollector{
+			StatType:            pb.StatType(c.StatType),
+			Name:                c.Name,
+			LastDurationSeconds: c.LastDurationSeconds,
+			Runs:                c.Runs,
+			Errors:              c.Errors,
+			StoredSamples:       c.StoredSamples,
+		}
+	}
+	return &pb.DaemonSelf{
+		Goroutines:     d.Goroutines,
+		HeapBytes:      d.HeapBytes,
+		GcCycles:       d.GCCycles,
+		GcPauseSeconds: d.GCPauseSeconds,
+		ActiveStreams:  d.ActiveStreams,
+		CleanerRuns:    d.CleanerRuns,
+		Collectors:     collectors,
+	}
+}
+
+// HostToProto передаёт неизвестное время загрузки нулём.
+func HostToProto(h *models.Host) *pb.HostInfo {
+	if h == nil {
+		return nil
+	}
+
+	var bootTime int64
+	if !h.BootTime.IsZero() {
+		bootTime = h.BootTime.Unix()
+	}
+	return &pb.HostInfo{
+		Hostname: h.Hostname,
+		Kernel:   h.Kernel,
+		CpuCount: h.CPUCount,
+		BootTime: bootTime,
+	}
+}
+
 func DiskStatsToProto(ds *models.DiskStats) *pb.DiskStats {
 	if ds == nil {
 		return nil
@@ -54,13 +178,22 @@ func DiskStatsToProto(ds *models.DiskStats) *pb.DiskStats {
 	for i, diskStat := range ds.DiskStats {
 		diskStats[i] = &pb.DiskStat{
 			Filesystem: diskStat.FileSystem,
+			MountPoint: diskStat.MountPoint,
 			Usage: &pb.DiskUsage{
-				Used:  diskStat.Usage.Used,
-				Usage: diskStat.Usage.Usage,
+				Used:          diskStat.Usage.Used,
+				Usage:         diskStat.Usage.Usage,
+				Available:     diskStat.Usage.Available,
+				UsagePercent:  diskStat.Usage.UsagePercent,
+				GrowthRate:    diskStat.Usage.GrowthRate,
+				SecondsToFull: diskStat.Usage.SecondsToFull,
 			},
 			Inodes: &pb.InodeUsage{
-				Used:  diskStat.Inodes.Used,
-				Usage: diskStat.Inodes.Usage,
+				Used:          diskStat.Inodes.Used,
+				Usage:         diskStat.Inodes.Usage,
+				Available:     diskStat.Inodes.Available,
+				UsagePercent:  diskStat.Inodes.UsagePercent,
+				GrowthRate:    diskStat.Inodes.GrowthRate,
+				SecondsToFull: diskStat.Inodes.SecondsToFull,
 			},
 		}
 	}
@@ -68,3 +201,100 @@ func DiskStatsToProto(ds *models.DiskStats) *pb.DiskStats {
 		DiskStats: diskStats,
 	}
 }
+
+func WindowToProto(statType pb.StatType, window metrics.Window) *pb.StatCoverage {
+	//nolint:gosec
+	return &pb.StatCoverage{
+		StatType:    statType,
+		SampleCount: int32(window.SampleCount),
+		Coverage:    window.Coverage,
+	}
+}
+
+func CollectErrorToProto(statType pb.StatType, state metrics.CollectError) *pb.CollectionError {
+	//nolint:gosec
+	return &pb.CollectionError{
+		StatType:            statType,
+		Message:             state.Message,
+		Since:               state.Since.Unix(),
+		ConsecutiveFailures: int32(state.Failures),
+	}
+}
+
+func AggregationFromProto(agg pb.Aggregation) metrics.Aggregation {
+	switch agg {
+	case pb.Aggregation_MEAN:
+		return metrics.AggregationMean
+	case pb.Aggregation_MIN:
+		return metrics.AggregationMin
+	case pb.Aggregation_MAX:
+		return metrics.AggregationMax
+	case pb.Aggregation_P50:
+		return metrics.AggregationP50
+	case pb.Aggregation_P95:
+		return metrics.AggregationP95
+	case pb.Aggregation_P99:
+		return metrics.AggregationP99
+	case pb.Aggregation_LAST:
+		return metrics.AggregationLast
+	default:
+		return metrics.AggregationMean
+	}
+}
+
+func AggregationToProto(agg metrics.Aggregation) pb.Aggregation {
+	switch agg {
+	case metrics.AggregationMean:
+		return pb.Aggregation_MEAN
+	case metrics.AggregationMin:
+		return pb.Aggregation_MIN
+	case metrics.AggregationMax:
+		return pb.Aggregation_MAX
+	case metrics.AggregationP50:
+		return pb.Aggregation_P50
+	case metrics.AggregationP95:
+		return pb.Aggregation_P95
+	case metrics.AggregationP99:
+		return pb.Aggregation_P99
+	case metrics.AggregationLast:
+		return pb.Aggregation_LAST
+	default:
+		return pb.Aggregation_MEAN
+	}
+}
+
+func AlertToProto(alert *models.Alert) *pb.Alert {
+	if alert == nil {
+		return nil
+	}
+
+	result := &pb.Alert{
+		Rule:      alert.Rule,
+		Metric:    alert.Metric,
+		Target:    alert.Target,
+		State:     AlertStateToProto(alert.State),
+		Operator:  alert.Operator,
+		Threshold: alert.Threshold,
+		Value:     alert.Value,
+		Timestamp: alert.Timestamp.Unix(),
+	}
+	if !alert.ActiveSince.IsZero() {
+		result.ActiveSince = alert.ActiveSince.Unix()
+	}
+	return result
+}
+
+func AlertStateToProto(state models.AlertState) pb.AlertState {
+	switch state {
+	case models.AlertInactive:
+		return pb.AlertState_INACTIVE
+	case models.AlertPending:
+		return pb.AlertState_PENDING
+	case models.AlertFiring:
+		return pb.AlertState_FIRING
+	case models.AlertResolved:
+		return pb.AlertState_RESOLVED
+	default:
+		return pb.AlertState_INACTIVE
+	}
+}
@@ -2,7 +2,10 @@ package converter
 
 import (
 	"testing"
+	"time"
 
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
 	"github.com/cepmap/otus-system-monitoring/internal/models"
 	"github.com/stretchr/testify/require"
 )
@@ -15,15 +18,21 @@ func TestLoadAverageToProto(t *testing.T) {
 
 	t.Run("valid input", func(t *testing.T) {
 		input := &models.LoadAverage{
-			Load1Min:  1.5,
-			Load5Min:  2.0,
-			Load15Min: 1.8,
+			Load1Min:      1.5,
+			Load5Min:      2.0,
+			Load15Min:     1.8,
+			RunnableTasks: 2,
+			TotalTasks:    345,
+			LastPid:       6789,
 		}
 		result := LoadAverageToProto(input)
 		require.NotNil(t, result)
 		require.Equal(t, input.Load1Min, result.Load1Min)
 		require.Equal(t, input.Load5Min, result.Load5Min)
 		require.Equal(t, input.Load15Min, result.Load15Min)
+		require.Equal(t, input.RunnableTasks, result.RunnableTasks)
+		require.Equal(t, input.TotalTasks, result.TotalTasks)
+		require.Equal(t, input.LastPid, result.LastPid)
 	})
 }
 
@@ -89,6 +98,104 @@ func TestDisksLoadToProto(t *testing.T) {
 	})
 }
 
+func TestCustomMetricsToProto(t *testing.T) {
+	t.Run("nil input", func(t *testing.T) {
+		require.Nil(t, CustomMetricsToProto(nil))
+	})
+
+	t.Run("valid input", func(t *testing.T) {
+		input := &models.CustomMetrics{Metrics: []models.CustomMetric{
+			{Plugin: "raid", Name: "degraded", Value: 1},
+			{Plugin: "queue", Name: "depth", Value: 42.5},
+		}}
+		result := CustomMetricsToProto(input)
+		require.Len(t, result, 2)
+		for i, metric := range input.Metrics {
+			require.Equal(t, metric.Plugin, result[i].Plugin)
+			require.Equal(t, metric.Name, result[i].Name)
+			require.Equal(t, metric.Value, result[i].Value)
+		}
+	})
+}
+
+func TestProcessesToProto(t *testing.T) {
+	input := []models.Process{
+		{Pid: 1, Command: "systemd", User: "root", Threads: 1, CPUPercent: 0.5, RSS: 4096},
+	}
+	result := ProcessesToProto(input)
+	require.Len(t, result, 1)
+	require.Equal(t, int32(1), result[0].Pid)
+	require.Equal(t, "systemd", result[0].Command)
+	require.Equal(t, "root", result[0].User)
+	require.Equal(t, int32(1), result[0].Threads)
+	require.Equal(t, 0.5, result[0].CpuPercent)
+	require.Equal(t, uint64(4096), result[0].RssBytes)
+}
+
+func TestPressureToProto(t *testing.T) {
+	require.Nil(t, PressureToProto(nil))
+
+	result := PressureToProto(&models.Pressure{
+		Supported: true,
+		IO:        models.PressureResource{Full: models.PressureStall{Avg10: 1.5, Avg60: 1, Avg300: 0.5, Total: 3000}},
+	})
+	require.True(t, result.Supported)
+	require.Equal(t, 1.5, result.Io.Full.Avg10)
+	require.Equal(t, uint64(3000), result.Io.Full.TotalDeltaUs)
+	require.NotNil(t, result.Cpu.Some)
+}
+
+func TestCgroupsToProto(t *testing.T) {
+	require.Nil(t, CgroupsToProto(nil))
+
+	result := CgroupsToProto(&models.Cgroups{Cgroups: []models.Cgroup{{
+		Path: "/system.slice/nginx.service", CPUPercent: 12.5, MemoryCurrent: 1024, MemoryMax: 4096,
+		IOReadBytes: 10, IOWriteBytes: 20,
+	}}})
+	require.Len(t, result.Cgroups, 1)
+	require.Equal(t, "/system.slice/nginx.service", result.Cgroups[0].Path)
+	require.Equal(t, 12.5, result.Cgroups[0].CpuPercent)
+	require.Equal(t, uint64(1024), result.Cgroups[0].MemoryCurrentBytes)
+	require.Equal(t, uint64(4096), result.Cgroups[0].MemoryMaxBytes)
+	require.Equal(t, uint64(10), result.Cgroups[0].IoReadBytes)
+	require.Equal(t, uint64(20), result.Cgroups[0].IoWriteBytes)
+}
+
+func TestDaemonSelfToProto(t *testing.T) {
+	require.Nil(t, DaemonSelfToProto(nil))
+
+	result := DaemonSelfToProto(&models.DaemonSelf{
+		Goroutines:     12,
+		HeapBytes:      4096,
+		GCCycles:       3,
+		GCPauseSeconds: 0.002,
+		ActiveStreams:  2,
+		CleanerRuns:    1,
+		Collectors: []models.DaemonCollector{
+			{StatType: int32(pb.StatType_CPU_STATS), Name: "cpu", LastDurationSeconds: 0.01, Runs: 5, Errors: 1, StoredSamples: 5},
+		},
+	})
+	require.Equal(t, int32(12), result.Goroutines)
+	require.Equal(t, uint64(4096), result.HeapBytes)
+	require.Equal(t, uint64(3), result.GcCycles)
+	require.Equal(t, int32(2), result.ActiveStreams)
+	require.Len(t, result.Collectors, 1)
+	require.Equal(t, pb.StatType_CPU_STATS, result.Collectors[0].StatType)
+	require.Equal(t, int64(5), result.Collectors[0].StoredSamples)
+}
+
+func TestHostToProto(t *testing.T) {
+	require.Nil(t, HostToProto(nil))
+
+	result := HostToProto(&models.Host{Hostname: "db-01", Kernel: "6.1.0", CPUCount: 4, BootTime: time.Unix(1700000000, 0)})
+	require.Equal(t, "db-01", result.Hostname)
+	require.Equal(t, "6.1.0", result.Kernel)
+	require.Equal(t, int32(4), result.CpuCount)
+	require.Equal(t, int64(1700000000), result.BootTime)
+
+	require.Zero(t, HostToProto(&models.Host{}).BootTime)
+}
+
 func TestDiskStatsToProto(t *testing.T) {
 	t.Run("nil input", func(t *testing.T) {
 		result := DiskStatsToProto(nil)
@@ -100,13 +207,20 @@ func TestDiskStatsToProto(t *testing.T) {
 			DiskStats: []models.DiskStat{
 				{
 					FileSystem: "/dev/sda1",
+					MountPoint: "/",
 					Usage: models.DiskUsage{
-						Used:  500000,
-						Usage: "50%",
+						Used:         500000,
+						Usage:        "50%",
+						Available:    500000,
+						UsagePercent: 50,
+						GrowthRate:   1024,
 					},
 					Inodes: models.InodeUsage{
-						Used:  1000,
-						Usage: "10%",
+						Used:         1000,
+						Usage:        "10%",
+						Available:    9000,
+						UsagePercent: 10,
+						GrowthRate:   0.5,
 					},
 				},
 				{
@@ -132,6 +246,13 @@ func TestDiskStatsToProto(t *testing.T) {
 			require.Equal(t, disk.Usage.Usage, result.DiskStats[i].Usage.Usage)
 			require.Equal(t, disk.Inodes.Used, result.DiskStats[i].Inodes.Used)
 			require.Equal(t, disk.Inodes.Usage, result.DiskStats[i].Inodes.Usage)
+			require.Equal(t, disk.MountPoint, result.DiskStats[i].MountPoint)
+			require.Equal(t, disk.Usage.Available, result.DiskStats[i].Usage.Available)
+			require.Equal(t, disk.Usage.UsagePercent, result.DiskStats[i].Usage.UsagePercent)
+			require.Equal(t, disk.Usage.GrowthRate, result.DiskStats[i].Usage.GrowthRate)
+			require.Equal(t, disk.Inodes.Available, result.DiskStats[i].Inodes.Available)
+			require.Equal(t, disk.Inodes.UsagePercent, result.DiskStats[i].Inodes.UsagePercent)
+			require.Equal(t, disk.Inodes.GrowthRate, result.DiskStats[i].Inodes.GrowthRate)
 		}
 	})
 
@@ -144,3 +265,67 @@ func TestDiskStatsToProto(t *testing.T) {
 		require.Empty(t, result.DiskStats)
 	})
 }
+
+func TestAggregationConversion(t *testing.T) {
+	for value := range pb.Aggregation_name {
+		agg := pb.Aggregation(value)
+		t.Run(agg.String(), func(t *testing.T) {
+			require.Equal(t, agg, AggregationToProto(AggregationFromProto(agg)))
+		})
+	}
+
+	t.Run("unknown value falls back to mean", func(t *testing.T) {
+		require.Equal(t, metrics.AggregationMean, AggregationFromProto(pb.Aggregation(100)))
+	})
+}
+
+func TestWindowToProto(t *testing.T) {
+	result := WindowToProto(pb.StatType_CPU_STATS, metrics.Window{SampleCount: 5, Coverage: 0.5})
+	require.Equal(t, pb.StatType_CPU_STATS, result.StatType)
+	require.Equal(t, int32(5), result.SampleCount)
+	require.Equal(t, 0.5, result.Coverage)
+}
+
+func TestCollectErrorToProto(t *testing.T) {
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := CollectErrorToProto(pb.StatType_DISKS_LOAD, metrics.CollectError{
+		Message:  "diskstats: permission denied",
+		Since:    since,
+		Failures: 3,
+	})
+	require.Equal(t, pb.StatType_DISKS_LOAD, result.StatType)
+	require.Equal(t, "diskstats: permission denied", result.Message)
+	require.Equal(t, since.Unix(), result.Since)
+	require.Equal(t, int32(3), result.ConsecutiveFailures)
+}
+
+func TestAlertToProto(t *testing.T) {
+	t.Run("nil input", func(t *testing.T) {
+		require.Nil(t, AlertToProto(nil))
+	})
+
+	t.Run("valid input", func(t *testing.T) {
+		now := time.Now()
+		input := &models.Alert{
+			Rule:        "var_full",
+			Metric:      "filesystem.usage_percent",
+			Target:      "/var",
+			State:       models.AlertFiring,
+			Operator:    ">",
+			Threshold:   90,
+			Value:       95,
+			ActiveSince: now.Add(-time.Minute),
+			Timestamp:   now,
+		}
+		result := AlertToProto(input)
+		require.Equal(t, input.Rule, result.Rule)
+		require.Equal(t, input.Metric, result.Metric)
+		require.Equal(t, input.Target, result.Target)
+		require.Equal(t, pb.AlertState_FIRING, result.State)
+		require.Equal(t, input.Operator, result.Operator)
+		require.Equal(t, input.Threshold, result.Threshold)
+		require.Equal(t, input.Value, result.Value)
+		require.Equal(t, now.Add(-time.Minute).Unix(), result.ActiveSince)
+		require.Equal(t, now.Unix(), result.Timestamp)
+	})
+}
@@ -0,0 +1,208 @@
+package converter
+
+import (
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+)
+
+// Обратные преобразования нужны клиентам: ответ сервера раскладывается в те же
+// модели, из которых он был собран. Для отсутствующих в ответе полей возвращается nil.
+
+func LoadAverageFromProto(la *pb.LoadAverage) *models.LoadAverage {
+	if la == nil {
+		return nil
+	}
+	return &models.LoadAverage{
+		Load1Min:      la.GetLoad1Min(),
+		Load5Min:      la.GetLoad5Min(),
+		Load15Min:     la.GetLoad15Min(),
+		RunnableTasks: la.GetRunnableTasks(),
+		TotalTasks:    la.GetTotalTasks(),
+		LastPid:       la.GetLastPid(),
+	}
+}
+
+func CPUStatFromProto(cs *pb.CPUStat) *models.CPUStat {
+	if cs == nil {
+		return nil
+	}
+	return &models.CPUStat{
+		User:   cs.GetUser(),
+		System: cs.GetSystem(),
+		Idle:   cs.GetIdle(),
+	}
+}
+
+func DisksLoadFromProto(dl *pb.DisksLoad) *models.DisksLoad {
+	if dl == nil {
+		return nil
+	}
+
+	disks := make([]models.DiskLoad, len(dl.GetDisksLoad()))
+	for i, disk := range dl.GetDisksLoad() {
+		disks[i] = models.DiskLoad{
+			FSName: disk.GetFsName(),
+			Tps:    disk.GetTps(),
+			Kps:    disk.GetKps(),
+		}
+	}
+	return &models.DisksLoad{DisksLoad: disks}
+}
+
+func DiskStatsFromProto(ds *pb.DiskStats) *models.DiskStats {
+	if ds == nil {
+		return nil
+	}
+
+	diskStats := make([]models.DiskStat, len(ds.GetDiskStats()))
+	for i, diskStat := range ds.GetDiskStats() {
+		usage, inodes := diskStat.GetUsage(), diskStat.GetInodes()
+		diskStats[i] = models.DiskStat{
+			FileSystem: diskStat.GetFilesystem(),
+			MountPoint: diskStat.GetMountPoint(),
+			Usage: models.DiskUsage{
+				Used:          usage.GetUsed(),
+				Usage:         usage.GetUsage(),
+				Available:     usage.GetAvailable(),
+				UsagePercent:  usage.GetUsagePercent(),
+				GrowthRate:    usage.GetGrowthRate(),
+				SecondsToFull: usage.GetSecondsToFull(),
+			},
+			Inodes: models.InodeUsage{
+				Used:          inodes.GetUsed(),
+				Usage:         inodes.GetUsage(),
+				Available:     inodes.GetAvailable(),
+				UsagePercent:  inodes.GetUsagePercent(),
+				GrowthRate:    inodes.GetGrowthRate(),
+				SecondsToFull: inodes.GetSecondsToFull(),
+			},
+		}
+	}
+	return &models.DiskStats{DiskStats: diskStats}
+}
+
+func CustomMetricsFromProto(metrics []*pb.CustomMetric) *models.CustomMetrics {
+	if metrics == nil {
+		return nil
+	}
+
+	result := make([]models.CustomMetric, len(metrics))
+	for i, metric := range metrics {
+		result[i] = models.CustomMetric{
+			Plugin: metric.GetPlugin(),
+			Name:   metric.GetName(),
+			Value:  metric.GetValue(),
+		}
+	}
+	return &models.CustomMetrics{Metrics: result}
+}
+
+func ProcessesFromProto(processes []*pb.Process) []models.Process {
+	result := make([]models.Process, len(processes))
+	for i, process := range processes {
+		result[i] = models.Process{
+			Pid:        process.GetPid(),
+			Command:    process.GetCommand(),
+			User:       process.GetUser(),
+			Threads:    process.GetThreads(),
+			CPUPercent: process.GetCpuPercent(),
+			RSS:        process.GetRssBytes(),
+		}
+	}
+	return result
+}
+
+func TopProcessesFromProto(tp *pb.TopProcesses) *models.TopProcesses {
+	if tp == nil {
+		return nil
+	}
+	return &models.TopProcesses{
+		ByCPU:    ProcessesFromProto(tp.GetByCpu()),
+		ByMemory: ProcessesFromProto(tp.GetByMemory()),
+	}
+}
+
+func PressureFromProto(p *pb.Pressure) *models.Pressure {
+	if p == nil {
+		return nil
+	}
+
+	resource := func(r *pb.PressureResource) models.PressureResource {
+		return models.PressureResource{Some: pressureStallFromProto(r.GetSome()), Full: pressureStallFromProto(r.GetFull())}
+	}
+	return &models.Pressure{
+		Supported: p.GetSupported(),
+		CPU:       resource(p.GetCpu()),
+		Memory:    resource(p.GetMemory()),
+		IO:        resource(p.GetIo()),
+	}
+}
+
+func pressureStallFromProto(s *pb.PressureStall) models.PressureStall {
+	return models.PressureStall{
+		Avg10:  s.GetAvg10(),
+		Avg60:  s.GetAvg60(),
+		Avg300: s.GetAvg300(),
+		Total:  s.GetTotalDeltaUs(),
+	}
+}
+
+func CgroupsFromProto(c *pb.Cgroups) *models.Cgroups {
+	if c == nil {
+		return nil
+	}
+
+	cgroups := make([]models.Cgroup, len(c.GetCgroups()))
+	for i, cgroup := range c.GetCgroups() {
+		cgroups[i] = models.Cgroup{
+			Path:          cgroup.GetPath(),
+			CPUPercent:    cgroup.GetCpuPercent(),
+			MemoryCurrent: cgroup.GetMemoryCurrentBytes(),
+			MemoryMax:     cgroup.GetMemoryMaxBytes(),
+			IOReadBytes:   cgroup.GetIoReadBytes(),
+			IOWriteBytes:  cgroup.GetIoWriteBytes(),
+		}
+	}
+	return &models.Cgroups{Cgroups: cgroups}
+}
+
+func DaemonSelfFromProto(d *pb.DaemonSelf) *models.DaemonSelf {
+	if d == nil {
+		return nil
+	}
+
+	collectors := make([]models.DaemonCollector, len(d.GetCollectors()))
+	for i, c := range d.GetCollectors() {
+		collectors[i] = models.DaemonCollector{
+			StatType:            int32(c.GetStatType()),
+			Name:                c.GetName(),
+			LastDurationSeconds: c.GetLastDurationSeconds(),
+			Runs:                c.GetRuns(),
+			Errors:              c.GetErrors(),
+			StoredSamples:       c.GetStoredSamples(),
+		}
+	}
+	return &models.DaemonSelf{
+		Goroutines:     d.GetGoroutines(),
+		HeapBytes:      d.GetHeapBytes(),
+		GCCycles:       d.GetGcCycles(),
+		GCPauseSeconds: d.GetGcPauseSeconds(),
+		ActiveStreams:  d.GetActiveStreams(),
+		CleanerRuns:    d.GetCleanerRuns(),
+		Collectors:     collectors,
+	}
+}
+
+func HostFromProto(h *pb.HostInfo) *models.Host {
+	if h == nil {
+		return nil
+	}
+
+	host := &models.Host{Hostname: h.GetHostname(), Kernel: h.GetKernel(), CPUCount: h.GetCpuCount()}
+	if h.GetBootTime() != 0 {
+		host.BootTime = time.Unix(h.GetBootTime(), 0)
+	}
+	return host
+}
@@ -0,0 +1,73 @@
+package converter
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromProto(t *testing.T) {
+	t.Run("nil input", func(t *testing.T) {
+		require.Nil(t, LoadAverageFromProto(nil))
+		require.Nil(t, CPUStatFromProto(nil))
+		require.Nil(t, DisksLoadFromProto(nil))
+		require.Nil(t, DiskStatsFromProto(nil))
+		require.Nil(t, CustomMetricsFromProto(nil))
+		require.Nil(t, TopProcessesFromProto(nil))
+		require.Nil(t, PressureFromProto(nil))
+		require.Nil(t, CgroupsFromProto(nil))
+		require.Nil(t, DaemonSelfFromProto(nil))
+		require.Nil(t, HostFromProto(nil))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		loadAverage := &models.LoadAverage{Load1Min: 1.5, Load5Min: 2, Load15Min: 1.8, RunnableTasks: 2, TotalTasks: 345, LastPid: 6789}
+		require.Equal(t, loadAverage, LoadAverageFromProto(LoadAverageToProto(loadAverage)))
+
+		cpu := &models.CPUStat{User: 10.5, System: 5.2, Idle: 84.3}
+		require.Equal(t, cpu, CPUStatFromProto(CPUStatToProto(cpu)))
+
+		disksLoad := &models.DisksLoad{DisksLoad: []models.DiskLoad{{FSName: "sda", Tps: 1.5, Kps: 100}}}
+		require.Equal(t, disksLoad, DisksLoadFromProto(DisksLoadToProto(disksLoad)))
+
+		diskStats := &models.DiskStats{DiskStats: []models.DiskStat{{
+			FileSystem: "/dev/sda1",
+			MountPoint: "/",
+			Usage:      models.DiskUsage{Used: 100, Usage: "50%", Available: 100, UsagePercent: 50, GrowthRate: 1.5, SecondsToFull: 60},
+			Inodes:     models.InodeUsage{Used: 10, Usage: "10%", Available: 90, UsagePercent: 10},
+		}}}
+		require.Equal(t, diskStats, DiskStatsFromProto(DiskStatsToProto(diskStats)))
+
+		custom := &models.CustomMetrics{Metrics: []models.CustomMetric{{Plugin: "queue", Name: "depth", Value: 3}}}
+		require.Equal(t, custom, CustomMetricsFromProto(CustomMetricsToProto(custom)))
+
+		processes := []models.Process{{Pid: 1, Command: "init", User: "root", Threads: 1, CPUPercent: 0.5, RSS: 4096}}
+		require.Equal(t, &models.TopProcesses{ByCPU: processes, ByMemory: []models.Process{}},
+			TopProcessesFromProto(&pb.TopProcesses{ByCpu: ProcessesToProto(processes)}))
+
+		pressure := &models.Pressure{
+			Supported: true,
+			CPU:       models.PressureResource{Some: models.PressureStall{Avg10: 1, Avg60: 2, Avg300: 3, Total: 400}},
+			IO:        models.PressureResource{Full: models.PressureStall{Avg10: 0.5, Total: 10}},
+		}
+		require.Equal(t, pressure, PressureFromProto(PressureToProto(pressure)))
+
+		cgroups := &models.Cgroups{Cgroups: []models.Cgroup{{
+			Path: "system.slice", CPUPercent: 12.5, MemoryCurrent: 1024, MemoryMax: 2048, IOReadBytes: 10, IOWriteBytes: 20,
+		}}}
+		require.Equal(t, cgroups, CgroupsFromProto(CgroupsToProto(cgroups)))
+
+		daemonSelf := &models.DaemonSelf{
+			Goroutines: 12, HeapBytes: 1 << 20, GCCycles: 3, GCPauseSeconds: 0.001, ActiveStreams: 2, CleanerRuns: 5,
+			Collectors: []models.DaemonCollector{{StatType: int32(pb.StatType_CPU_STATS), Name: "cpu", Runs: 10, StoredSamples: 10}},
+		}
+		require.Equal(t, daemonSelf, DaemonSelfFromProto(DaemonSelfToProto(daemonSelf)))
+
+		host := &models.Host{Hostname: "db-01", Kernel: "6.1.0", CPUCount: 8, BootTime: time.Unix(1700000000, 0)}
+		require.Equal(t, host, HostFromProto(HostToProto(host)))
+		require.Equal(t, &models.Host{Hostname: "db-01"}, HostFromProto(HostToProto(&models.Host{Hostname: "db-01"})))
+	})
+}
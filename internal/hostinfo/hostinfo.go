@@ -0,0 +1,84 @@
+// Package hostinfo описывает машину, за которой наблюдает демон. Ядро, процессоры
+// и время загрузки читаются из procfs и в контейнере относятся к машине
+// смонтированного /host/proc. Имя хоста в procfs зависит от UTS-пространства
+// читающего процесса, поэтому имя машины читается из /etc рядом с procfs: /host/etc.
+package hostinfo
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+)
+
+// HostEtc возвращает /etc машины, чей procfs смонтирован в procRoot, например
+// /host/etc для /host/proc. Для procfs текущей системы возвращает nil.
+func HostEtc(procRoot string) fs.FS {
+	root := procfs.HostRoot(procRoot)
+	if root == "/" {
+		return nil
+	}
+	return os.DirFS(filepath.Join(root, "etc"))
+}
+
+// Get собирает сведения о машине. Имя хоста берётся из etc/hostname, если etc
+// не nil, затем из procfs и у текущего процесса. Недоступное в procfs число
+// процессоров берётся у текущего процесса, ядро и время загрузки остаются пустыми.
+func Get(fsys procfs.FS, etc fs.FS) models.Host {
+	host := models.Host{Kernel: readLine(fsys.Proc, "sys/kernel/osrelease")}
+	if etc != nil {
+		host.Hostname = readLine(etc, "hostname")
+	}
+	if host.Hostname == "" {
+		host.Hostname = readLine(fsys.Proc, "sys/kernel/hostname")
+	}
+	if host.Hostname == "" {
+		host.Hostname, _ = os.Hostname()
+	}
+
+	if data, err := fs.ReadFile(fsys.Proc, "stat"); err == nil {
+		host.CPUCount, host.BootTime = parseStat(data)
+	}
+	if host.CPUCount == 0 {
+		host.CPUCount = int32(runtime.NumCPU()) //nolint:gosec
+	}
+	return host
+}
+
+func readLine(fsys fs.FS, name string) string {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// parseStat считает строки "cpuN" в /proc/stat и читает время загрузки из "btime".
+func parseStat(data []byte) (int32, time.Time) {
+	var cpus int32
+	var bootTime time.Time
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch {
+		case fields[0] == "btime":
+			if sec, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+				bootTime = time.Unix(sec, 0)
+			}
+		case strings.HasPrefix(fields[0], "cpu") && fields[0] != "cpu":
+			cpus++
+		}
+	}
+	return cpus, bootTime
+}
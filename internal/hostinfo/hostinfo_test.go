@@ -0,0 +1,60 @@
+package hostinfo
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	t.Run("reads procfs root", func(t *testing.T) {
+		proc := fstest.MapFS{
+			"sys/kernel/hostname":  {Data: []byte("db-01\n")},
+			"sys/kernel/osrelease": {Data: []byte("6.1.0-18-amd64\n")},
+			"stat": {Data: []byte("cpu  10 0 5 100 0 0 0 0 0 0\n" +
+				"cpu0 5 0 2 50 0 0 0 0 0 0\n" +
+				"cpu1 5 0 3 50 0 0 0 0 0 0\n" +
+				"intr 12345\n" +
+				"btime 1700000000\n")},
+		}
+
+		host := Get(procfs.FS{Proc: proc}, nil)
+		require.Equal(t, "db-01", host.Hostname)
+		require.Equal(t, "6.1.0-18-amd64", host.Kernel)
+		require.Equal(t, int32(2), host.CPUCount)
+		require.Equal(t, time.Unix(1700000000, 0), host.BootTime)
+
+		// Из контейнера procfs отдаёт имя контейнера, имя машины лежит в её /etc.
+		etc := fstest.MapFS{"hostname": {Data: []byte("node-7\n")}}
+		require.Equal(t, "node-7", Get(procfs.FS{Proc: proc}, etc).Hostname)
+	})
+
+	t.Run("host etc", func(t *testing.T) {
+		require.Nil(t, HostEtc(""))
+		require.Nil(t, HostEtc("/proc/"))
+
+		root := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(root, "etc"), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(root, "etc", "hostname"), []byte("node-7\n"), 0o600))
+		data, err := fs.ReadFile(HostEtc(filepath.Join(root, "proc")), "hostname")
+		require.NoError(t, err)
+		require.Equal(t, "node-7\n", string(data))
+	})
+
+	t.Run("falls back to current process", func(t *testing.T) {
+		host := Get(procfs.FS{Proc: fstest.MapFS{}}, fstest.MapFS{})
+		hostname, err := os.Hostname()
+		require.NoError(t, err)
+		require.Equal(t, hostname, host.Hostname)
+		require.Equal(t, int32(runtime.NumCPU()), host.CPUCount)
+		require.Empty(t, host.Kernel)
+		require.True(t, host.BootTime.IsZero())
+	})
+}
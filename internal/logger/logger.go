@@ -3,15 +3,17 @@ package logger
 import (
 	"io"
 	"os"
+	"strings"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 type Logger struct {
-	core   *zap.Logger
-	level  string
-	writer io.Writer
+	core        *zap.Logger
+	level       string
+	atomicLevel zap.AtomicLevel
+	writer      io.Writer
 }
 
 var custLogger *Logger
@@ -24,13 +26,19 @@ const (
 )
 
 func init() {
-	custLogger = &Logger{core: zap.Must(zap.NewDevelopment()), level: InfoLevel, writer: os.Stdout}
+	custLogger = &Logger{
+		core:        zap.Must(zap.NewDevelopment()),
+		level:       InfoLevel,
+		atomicLevel: zap.NewAtomicLevelAt(zap.InfoLevel),
+		writer:      os.Stdout,
+	}
 	initCore()
 }
 
+// SetLogLevel меняет уровень без пересоздания ядра, поэтому безопасна при работающем демоне.
 func SetLogLevel(level string) {
-	custLogger.level = level
-	initCore()
+	custLogger.level = strings.ToUpper(level)
+	custLogger.atomicLevel.SetLevel(zapLevel(custLogger.level))
 }
 
 func SetWriter(writer io.Writer) {
@@ -38,19 +46,30 @@ func SetWriter(writer io.Writer) {
 	initCore()
 }
 
-func initCore() {
-	var zapLevel zapcore.Level
-
-	switch custLogger.level {
-	case "WARN":
-		zapLevel = zap.WarnLevel
-	case "INFO":
-		zapLevel = zap.InfoLevel
-	case "DEBUG":
-		zapLevel = zap.DebugLevel
+// ValidLevel сообщает, известен ли уровень логирования; регистр не важен.
+func ValidLevel(level string) bool {
+	switch strings.ToUpper(level) {
+	case ErrorLevel, WarnLevel, InfoLevel, DebugLevel:
+		return true
+	default:
+		return false
+	}
+}
+
+func zapLevel(level string) zapcore.Level {
+	switch level {
+	case WarnLevel:
+		return zap.WarnLevel
+	case InfoLevel:
+		return zap.InfoLevel
+	case DebugLevel:
+		return zap.DebugLevel
 	default:
-		zapLevel = zap.ErrorLevel
+		return zap.ErrorLevel
 	}
+}
+
+func initCore() {
 
 	encoderCfg := zap.NewProductionEncoderConfig()
 	encoderCfg.TimeKey = "timestamp"
@@ -59,7 +78,7 @@ func initCore() {
 	core := zapcore.NewCore(
 		zapcore.NewJSONEncoder(encoderCfg),
 		zapcore.AddSync(custLogger.writer),
-		zap.NewAtomicLevelAt(zapLevel))
+		custLogger.atomicLevel)
 
 	custLogger.core = zap.New(core)
 }
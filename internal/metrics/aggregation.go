@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+)
+
+type Aggregation int
+
+const (
+	AggregationMean Aggregation = iota
+	AggregationMin
+	AggregationMax
+	AggregationP50
+	AggregationP95
+	AggregationP99
+	AggregationLast
+)
+
+func (a Aggregation) String() string {
+	switch a {
+	case AggregationMean:
+		return "mean"
+	case AggregationMin:
+		return "min"
+	case AggregationMax:
+		return "max"
+	case AggregationP50:
+		return "p50"
+	case AggregationP95:
+		return "p95"
+	case AggregationP99:
+		return "p99"
+	case AggregationLast:
+		return "last"
+	default:
+		return "unknown"
+	}
+}
+
+// AggregateValues ожидает значения в хронологическом порядке: от старых к новым.
+// Веса используются только для среднего; если их нет, все значения равнозначны.
+func AggregateValues(values, weights []float64, agg Aggregation) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	switch agg {
+	case AggregationMean:
+		return weightedMean(values, weights)
+	case AggregationMin:
+		result := values[0]
+		for _, v := range values[1:] {
+			result = math.Min(result, v)
+		}
+		return result
+	case AggregationMax:
+		result := values[0]
+		for _, v := range values[1:] {
+			result = math.Max(result, v)
+		}
+		return result
+	case AggregationP50:
+		return percentile(values, 50)
+	case AggregationP95:
+		return percentile(values, 95)
+	case AggregationP99:
+		return percentile(values, 99)
+	case AggregationLast:
+		return values[len(values)-1]
+	default:
+		return weightedMean(values, weights)
+	}
+}
+
+func weightedMean(values, weights []float64) float64 {
+	var sum, weightSum float64
+	if len(weights) == len(values) {
+		for i, v := range values {
+			sum += v * weights[i]
+			weightSum += weights[i]
+		}
+	}
+	if weightSum > 0 {
+		return sum / weightSum
+	}
+
+	sum = 0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// Round округляет значение до сотых для ответа клиенту.
+func Round(x float64) float64 {
+	return math.Round(x*100) / 100
+}
+
+// percentile считает перцентиль с линейной интерполяцией между соседними значениями.
+func percentile(values []float64, p float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
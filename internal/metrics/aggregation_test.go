@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregate(t *testing.T) {
+	values := []float64{4, 1, 3, 2, 5}
+
+	tests := []struct {
+		name     string
+		agg      Aggregation
+		expected float64
+	}{
+		{name: "mean", agg: AggregationMean, expected: 3},
+		{name: "min", agg: AggregationMin, expected: 1},
+		{name: "max", agg: AggregationMax, expected: 5},
+		{name: "p50", agg: AggregationP50, expected: 3},
+		{name: "p95", agg: AggregationP95, expected: 4.8},
+		{name: "p99", agg: AggregationP99, expected: 4.96},
+		{name: "last", agg: AggregationLast, expected: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, tt.expected, AggregateValues(values, nil, tt.agg), 1e-9)
+		})
+	}
+
+	t.Run("empty values", func(t *testing.T) {
+		require.Zero(t, AggregateValues(nil, nil, AggregationMax))
+	})
+
+	t.Run("percentile does not reorder input", func(t *testing.T) {
+		percentile(values, 50)
+		require.Equal(t, []float64{4, 1, 3, 2, 5}, values)
+	})
+}
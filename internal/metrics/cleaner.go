@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/cepmap/otus-system-monitoring/internal/logger"
-	"github.com/cepmap/otus-system-monitoring/internal/storage"
 )
 
 const (
@@ -40,25 +39,26 @@ func (m *Storage) cleanOldData() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	retention := m.retention
+	if retention <= 0 {
+		retention = defaultRetentionPeriod
+	}
+
 	now := time.Now()
-	cutoff := now.Add(-defaultRetentionPeriod)
+	cutoff := now.Add(-retention)
 
+	m.cleanerRuns++
 	cleanedCount := 0
-	cleanedCount += m.cleanStorageOldData(m.loadAvg, cutoff)
-	cleanedCount += m.cleanStorageOldData(m.cpuStats, cutoff)
-	cleanedCount += m.cleanStorageOldData(m.diskLoad, cutoff)
-	cleanedCount += m.cleanStorageOldData(m.diskUsage, cutoff)
+	// Удаление во время обхода GetElementsAt блокируется: обход держит RLock хранилища.
+	for _, s := range m.series {
+		cleanedCount += s.Clean(cutoff)
+	}
 
 	logger.Info(fmt.Sprintf("Cleaned %d old metrics data before %s", cleanedCount, cutoff.Format(time.RFC3339)))
 }
 
-func (m *Storage) cleanStorageOldData(s storage.Storage, cutoff time.Time) int {
-	count := 0
-	for item := range s.GetElementsAt(time.Time{}) {
-		if ts, ok := s.GetTimestamp(item); ok && ts.Before(cutoff) {
-			s.Remove(item)
-			count++
-		}
-	}
-	return count
+func (m *Storage) SetRetention(retention time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retention = retention
 }
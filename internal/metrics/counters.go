@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"time"
+)
+
+// CollectorStats — счётчики сборов одной статистики с запуска демона.
+type CollectorStats struct {
+	Runs         int64
+	Errors       int64
+	LastDuration time.Duration
+	// Items — количество замеров в хранилище.
+	Items int
+}
+
+// StorageStats — состояние хранилища для самодиагностики демона.
+type StorageStats struct {
+	Collectors  map[string]CollectorStats
+	CleanerRuns int64
+}
+
+// RecordCollection учитывает один сбор статистики name.
+func (m *Storage) RecordCollection(name string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := m.collections[name]
+	stats.Runs++
+	if err != nil {
+		stats.Errors++
+	}
+	stats.LastDuration = duration
+	m.collections[name] = stats
+}
+
+// Stats возвращает счётчики сборов и количество замеров по каждой статистике.
+func (m *Storage) Stats() StorageStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := StorageStats{
+		Collectors:  make(map[string]CollectorStats, len(m.collections)),
+		CleanerRuns: m.cleanerRuns,
+	}
+	for name, stats := range m.collections {
+		result.Collectors[name] = stats
+	}
+	for name, s := range m.series {
+		stats := result.Collectors[name]
+		for range s.GetItemsAt(time.Time{}) {
+			stats.Items++
+		}
+		result.Collectors[name] = stats
+	}
+	return result
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageStats(t *testing.T) {
+	m := New(config.Stats{Limit: 10})
+	now := time.Now()
+	m.Store("cpu", 1.0, now.Add(-time.Second))
+	m.Store("cpu", 2.0, now)
+	m.Store("disk_load", 1.0, now)
+
+	m.RecordCollection("cpu", 10*time.Millisecond, nil)
+	m.RecordCollection("cpu", 30*time.Millisecond, errors.New("timeout"))
+	m.cleanOldData()
+
+	stats := m.Stats()
+	require.Equal(t, int64(1), stats.CleanerRuns)
+	require.Equal(t, CollectorStats{Runs: 2, Errors: 1, LastDuration: 30 * time.Millisecond, Items: 2}, stats.Collectors["cpu"])
+	require.Equal(t, CollectorStats{Items: 1}, stats.Collectors["disk_load"])
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"time"
+)
+
+// errorLogInterval — как часто повторять в логе ошибку сбора, которая не меняется.
+const errorLogInterval = time.Minute
+
+// CollectError описывает идущие подряд неудачные сборы статистики.
+type CollectError struct {
+	Message string
+	// Since — время первого из неудачных сборов.
+	Since    time.Time
+	Failures int
+
+	logged time.Time
+}
+
+// SetError запоминает результат сбора статистики name: ошибку или успех при err == nil.
+// Возвращает состояние ошибки и признак того, что его стоит записать в лог: это первая
+// ошибка, смена сообщения, восстановление после ошибок или прошло errorLogInterval.
+// При восстановлении возвращается последнее состояние ошибки.
+func (m *Storage) SetError(name string, err error, timestamp time.Time) (CollectError, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prev, failed := m.errors[name]
+	if err == nil {
+		if !failed {
+			return CollectError{}, false
+		}
+		delete(m.errors, name)
+		return *prev, true
+	}
+
+	if !failed || prev.Message != err.Error() {
+		state := &CollectError{Message: err.Error(), Since: timestamp, Failures: 1, logged: timestamp}
+		if failed {
+			state.Since = prev.Since
+			state.Failures = prev.Failures + 1
+		}
+		m.errors[name] = state
+		return *state, true
+	}
+
+	prev.Failures++
+	log := timestamp.Sub(prev.logged) >= errorLogInterval
+	if log {
+		prev.logged = timestamp
+	}
+	return *prev, log
+}
+
+// Error возвращает состояние ошибки статистики name, если её последний сбор не удался.
+func (m *Storage) Error(name string) (CollectError, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	state, ok := m.errors[name]
+	if !ok {
+		return CollectError{}, false
+	}
+	return *state, true
+}
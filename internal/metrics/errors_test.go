@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetError(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	errBroken := errors.New("iostat: not found")
+
+	t.Run("success without errors", func(t *testing.T) {
+		m := New(config.Stats{Limit: 10})
+		_, log := m.SetError("cpu", nil, start)
+		require.False(t, log)
+		_, ok := m.Error("cpu")
+		require.False(t, ok)
+	})
+
+	t.Run("repeated error is counted and rate limited", func(t *testing.T) {
+		m := New(config.Stats{Limit: 10})
+
+		state, log := m.SetError("cpu", errBroken, start)
+		require.True(t, log)
+		require.Equal(t, 1, state.Failures)
+
+		state, log = m.SetError("cpu", errBroken, start.Add(time.Second))
+		require.False(t, log)
+		require.Equal(t, 2, state.Failures)
+		require.Equal(t, start, state.Since)
+
+		_, log = m.SetError("cpu", errBroken, start.Add(errorLogInterval))
+		require.True(t, log)
+		_, log = m.SetError("cpu", errBroken, start.Add(errorLogInterval+time.Second))
+		require.False(t, log)
+
+		state, ok := m.Error("cpu")
+		require.True(t, ok)
+		require.Equal(t, "iostat: not found", state.Message)
+		require.Equal(t, 4, state.Failures)
+		_, ok = m.Error("disk_load")
+		require.False(t, ok)
+	})
+
+	t.Run("changed message is logged", func(t *testing.T) {
+		m := New(config.Stats{Limit: 10})
+		m.SetError("cpu", errBroken, start)
+
+		state, log := m.SetError("cpu", errors.New("timeout"), start.Add(time.Second))
+		require.True(t, log)
+		require.Equal(t, "timeout", state.Message)
+		require.Equal(t, 2, state.Failures)
+		require.Equal(t, start, state.Since)
+	})
+
+	t.Run("recovery clears the error", func(t *testing.T) {
+		m := New(config.Stats{Limit: 10})
+		m.SetError("cpu", errBroken, start)
+		m.SetError("cpu", errBroken, start.Add(time.Second))
+
+		state, log := m.SetError("cpu", nil, start.Add(2*time.Second))
+		require.True(t, log)
+		require.Equal(t, 2, state.Failures)
+		_, ok := m.Error("cpu")
+		require.False(t, ok)
+
+		state, _ = m.SetError("cpu", errBroken, start.Add(3*time.Second))
+		require.Equal(t, 1, state.Failures)
+		require.Equal(t, start.Add(3*time.Second), state.Since)
+	})
+}
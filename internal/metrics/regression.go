@@ -0,0 +1,44 @@
+package metrics
+
+import "time"
+
+// LinearRegression находит прямую y = slope*x + intercept методом наименьших квадратов,
+// где x — секунды от первого замера. ok == false, если точек меньше двух
+// или все они сняты в один момент времени.
+func LinearRegression(timestamps []time.Time, values []float64) (slope, intercept float64, ok bool) {
+	if len(timestamps) < 2 || len(timestamps) != len(values) {
+		return 0, 0, false
+	}
+
+	n := float64(len(values))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, ts := range timestamps {
+		x := ts.Sub(timestamps[0]).Seconds()
+		sumX += x
+		sumY += values[i]
+		sumXY += x * values[i]
+		sumXX += x * x
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0, 0, false
+	}
+
+	slope = (n*sumXY - sumX*sumY) / denominator
+	intercept = (sumY - slope*sumX) / n
+	return slope, intercept, true
+}
+
+// SecondsToFull оценивает по линейному тренду, через сколько секунд used достигнет capacity.
+// Возвращает 0, если тренда нет или он не растёт. Если по тренду место уже
+// кончилось, возвращается 1: 0 значит «не заполняется», а не «заполнено».
+func SecondsToFull(timestamps []time.Time, used []float64, capacity float64, now time.Time) float64 {
+	slope, intercept, ok := LinearRegression(timestamps, used)
+	if !ok || slope <= 0 || capacity <= 0 {
+		return 0
+	}
+
+	current := slope*now.Sub(timestamps[0]).Seconds() + intercept
+	return max((capacity-current)/slope, 1)
+}
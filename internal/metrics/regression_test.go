@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinearRegression(t *testing.T) {
+	start := time.Now()
+
+	t.Run("not enough points", func(t *testing.T) {
+		_, _, ok := LinearRegression([]time.Time{start}, []float64{1})
+		require.False(t, ok)
+	})
+
+	t.Run("same timestamp", func(t *testing.T) {
+		_, _, ok := LinearRegression([]time.Time{start, start}, []float64{1, 2})
+		require.False(t, ok)
+	})
+
+	t.Run("exact line", func(t *testing.T) {
+		timestamps := []time.Time{start, start.Add(time.Second), start.Add(2 * time.Second), start.Add(4 * time.Second)}
+		values := []float64{10, 13, 16, 22}
+
+		slope, intercept, ok := LinearRegression(timestamps, values)
+		require.True(t, ok)
+		require.InDelta(t, 3.0, slope, 1e-9)
+		require.InDelta(t, 10.0, intercept, 1e-9)
+	})
+}
+
+func TestSecondsToFull(t *testing.T) {
+	start := time.Now()
+	timestamps := []time.Time{start, start.Add(time.Second), start.Add(2 * time.Second)}
+
+	t.Run("growing usage", func(t *testing.T) {
+		result := SecondsToFull(timestamps, []float64{100, 110, 120}, 200, start.Add(2*time.Second))
+		require.InDelta(t, 8.0, result, 1e-9)
+	})
+
+	t.Run("shrinking usage", func(t *testing.T) {
+		require.Zero(t, SecondsToFull(timestamps, []float64{120, 110, 100}, 200, start.Add(2*time.Second)))
+	})
+
+	t.Run("flat usage", func(t *testing.T) {
+		require.Zero(t, SecondsToFull(timestamps, []float64{100, 100, 100}, 200, start.Add(2*time.Second)))
+	})
+
+	t.Run("already full by trend", func(t *testing.T) {
+		require.Equal(t, 1.0, SecondsToFull(timestamps, []float64{100, 150, 200}, 200, start.Add(10*time.Second)))
+	})
+}
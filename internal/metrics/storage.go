@@ -1,95 +1,130 @@
 package metrics
 
 import (
+	"slices"
 	"sync"
 	"time"
 
-	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
 	"github.com/cepmap/otus-system-monitoring/internal/storage"
 	memorystorage "github.com/cepmap/otus-system-monitoring/internal/storage/memory"
 )
 
-type Storage struct {
-	mu        sync.RWMutex
-	loadAvg   storage.Storage
-	cpuStats  storage.Storage
-	diskLoad  storage.Storage
-	diskUsage storage.Storage
+// Aggregator сворачивает замеры окна в одно значение того же типа, что и замеры.
+// Для пустого окна возвращает nil.
+type Aggregator interface {
+	Aggregate(samples []Sample, weights []float64, agg Aggregation) any
 }
 
-func New() *Storage {
-	return &Storage{
-		loadAvg:   memorystorage.New(),
-		cpuStats:  memorystorage.New(),
-		diskLoad:  memorystorage.New(),
-		diskUsage: memorystorage.New(),
-	}
+// Forecaster дополняет агрегированное значение прогнозом по истории длиннее окна клиента.
+type Forecaster interface {
+	ForecastPeriod() time.Duration
+	Forecast(value any, history []Sample, now time.Time)
 }
 
-func (m *Storage) StoreLoadAverage(stats *models.LoadAverage, timestamp time.Time) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.loadAvg.Push(stats, timestamp)
+// Query описывает окно, за которое агрегируются замеры.
+type Query struct {
+	Period      time.Duration
+	Aggregation Aggregation
+	// Interval — ожидаемый период сбора, по нему определяются пропуски в замерах.
+	Interval time.Duration
+	// End — конец окна, нулевое значение означает текущий момент.
+	End time.Time
 }
 
-func (m *Storage) StoreCPUStats(stats *models.CPUStat, timestamp time.Time) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.cpuStats.Push(stats, timestamp)
+// Storage хранит замеры каждой статистики отдельно, по её имени.
+type Storage struct {
+	mu          sync.RWMutex
+	limit       int64
+	retention   time.Duration
+	series      map[string]storage.Storage
+	errors      map[string]*CollectError
+	collections map[string]CollectorStats
+	cleanerRuns int64
 }
 
-func (m *Storage) StoreDisksLoad(stats *models.DisksLoad, timestamp time.Time) {
+func New(cfg config.Stats) *Storage {
+	return &Storage{
+		limit:       cfg.Limit,
+		retention:   cfg.Retention,
+		series:      make(map[string]storage.Storage),
+		errors:      make(map[string]*CollectError),
+		collections: make(map[string]CollectorStats),
+	}
+}
+
+// SetLimit меняет количество хранимых замеров каждого типа.
+func (m *Storage) SetLimit(limit int64) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.diskLoad.Push(stats, timestamp)
+	m.limit = limit
+	for _, s := range m.series {
+		s.SetSize("metrics", limit+1)
+	}
 }
 
-func (m *Storage) StoreDiskUsage(stats *models.DiskStats, timestamp time.Time) {
+func (m *Storage) Store(name string, value any, timestamp time.Time) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.diskUsage.Push(stats, timestamp)
+	s, ok := m.series[name]
+	if !ok {
+		s = memorystorage.New(m.limit + 1)
+		m.series[name] = s
+	}
+	s.Push(value, timestamp)
 }
 
-func getAverageFromStorage[T any](store storage.Storage, period time.Duration) []T {
-	now := time.Now()
-	start := now.Add(-period)
+// Samples возвращает замеры начиная со start в хронологическом порядке.
+func (m *Storage) Samples(name string, start time.Time) []Sample {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.samples(name, start, time.Time{})
+}
 
-	var result []T
-	for item := range store.GetElementsAt(start) {
-		if stat, ok := item.(T); ok {
-			result = append(result, stat)
+// samples возвращает замеры из интервала [start, end], нулевой end не ограничивает окно.
+func (m *Storage) samples(name string, start, end time.Time) []Sample {
+	s, ok := m.series[name]
+	if !ok {
+		return nil
+	}
+	var result []Sample
+	for item := range s.GetItemsAt(start) {
+		if !end.IsZero() && item.Timestamp.After(end) {
+			continue
 		}
+		result = append(result, Sample{Timestamp: item.Timestamp, Value: item.Data})
 	}
+	slices.Reverse(result)
 	return result
 }
 
-func (m *Storage) GetAverageLoadAverage(period time.Duration) *models.LoadAverage {
+// Aggregate агрегирует замеры статистики name за окно запроса.
+func (m *Storage) Aggregate(name string, a Aggregator, q Query) (any, Window) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	stats := getAverageFromStorage[*models.LoadAverage](m.loadAvg, period)
-	return averageLoadAverage(stats)
-}
 
-func (m *Storage) GetAverageCPUStats(period time.Duration) *models.CPUStat {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	stats := getAverageFromStorage[*models.CPUStat](m.cpuStats, period)
-	return averageCPUStat(stats)
-}
+	interval := q.Interval
+	if interval <= 0 {
+		interval = defaultSampleInterval
+	}
 
-func (m *Storage) GetAverageDisksLoad(period time.Duration) *models.DisksLoad {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	stats := getAverageFromStorage[*models.DisksLoad](m.diskLoad, period)
-	return averageDisksLoad(stats)
-}
+	end := q.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	// Окно короче периода сбора часто не содержит ни одного замера, поэтому оно
+	// расширяется до полутора периодов: с запасом на задержку сбора.
+	period := max(q.Period, interval*3/2)
+	samples := m.samples(name, end.Add(-period), end)
+	weights, window := sampleWeights(samples, end.Add(-period), end, interval)
+	value := a.Aggregate(samples, weights, q.Aggregation)
 
-func (m *Storage) GetLatestDiskUsage() *models.DiskStats {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	stats := getAverageFromStorage[*models.DiskStats](m.diskUsage, time.Second)
-	if len(stats) > 0 {
-		return stats[len(stats)-1]
+	if f, ok := a.(Forecaster); ok && value != nil {
+		history := samples
+		if forecastPeriod := f.ForecastPeriod(); period < forecastPeriod {
+			history = m.samples(name, end.Add(-forecastPeriod), end)
+		}
+		f.Forecast(value, history, end)
 	}
-	return nil
+	return value, window
 }
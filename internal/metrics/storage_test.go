@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type sumAggregator struct{}
+
+func (sumAggregator) Aggregate(samples []Sample, _ []float64, _ Aggregation) any {
+	if len(samples) == 0 {
+		return nil
+	}
+	var sum int
+	for _, s := range samples {
+		sum += s.Value.(int)
+	}
+	return sum
+}
+
+func TestStorageAggregateEnd(t *testing.T) {
+	storage := New(config.Stats{Limit: 100})
+	now := time.Now()
+	for i := 1; i <= 10; i++ {
+		storage.Store("test", i, now.Add(time.Duration(i-10)*time.Second))
+	}
+
+	t.Run("zero end means now", func(t *testing.T) {
+		value, window := storage.Aggregate("test", sumAggregator{}, Query{Period: 3 * time.Second, Interval: time.Second})
+		require.Equal(t, 8+9+10, value)
+		require.Equal(t, 3, window.SampleCount)
+	})
+
+	t.Run("window in the past", func(t *testing.T) {
+		value, window := storage.Aggregate("test", sumAggregator{}, Query{
+			Period:   3 * time.Second,
+			Interval: time.Second,
+			End:      now.Add(-5500 * time.Millisecond),
+		})
+		require.Equal(t, 2+3+4, value)
+		require.Equal(t, 3, window.SampleCount)
+		require.InDelta(t, 2.5/3, window.Coverage, 0.01)
+	})
+
+	t.Run("window before history", func(t *testing.T) {
+		value, window := storage.Aggregate("test", sumAggregator{}, Query{
+			Period:   3 * time.Second,
+			Interval: time.Second,
+			End:      now.Add(-time.Minute),
+		})
+		require.Nil(t, value)
+		require.Zero(t, window.SampleCount)
+	})
+}
+
+func TestStorageAggregateSlowCollector(t *testing.T) {
+	storage := New(config.Stats{Limit: 100})
+	now := time.Now()
+	storage.Store("disk", 1, now.Add(-12*time.Second))
+
+	// Период сбора 10 секунд длиннее окна клиента в 2 секунды: последний замер всё равно попадает в ответ.
+	value, window := storage.Aggregate("disk", sumAggregator{}, Query{Period: 2 * time.Second, Interval: 10 * time.Second})
+	require.Equal(t, 1, value)
+	require.Equal(t, 1, window.SampleCount)
+
+	value, _ = storage.Aggregate("disk", sumAggregator{}, Query{Period: 2 * time.Second, Interval: 5 * time.Second})
+	require.Nil(t, value, "sample older than 1.5 collection periods is stale")
+}
+
+func TestStorageCleanOldData(t *testing.T) {
+	storage := New(config.Stats{Limit: 100, Retention: time.Minute})
+	now := time.Now()
+	storage.Store("test", 1, now.Add(-3*time.Minute))
+	storage.Store("test", 2, now.Add(-2*time.Minute))
+	storage.Store("test", 1, now.Add(-time.Second))
+	storage.Store("other", 3, now.Add(-2*time.Minute))
+
+	done := make(chan struct{})
+	go func() {
+		storage.cleanOldData()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("cleaner is blocked")
+	}
+
+	samples := storage.Samples("test", time.Time{})
+	require.Len(t, samples, 1)
+	require.Equal(t, 1, samples[0].Value)
+	require.Empty(t, storage.Samples("other", time.Time{}))
+}
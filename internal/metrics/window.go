@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"time"
+)
+
+const (
+	defaultSampleInterval = time.Second
+
+	// Если между соседними замерами прошло больше gapFactor интервалов сбора,
+	// считаем, что замеры пропущены, и промежуток не засчитываем.
+	gapFactor = 2
+)
+
+type Window struct {
+	SampleCount int
+	Coverage    float64
+}
+
+type Sample struct {
+	Timestamp time.Time
+	Value     any
+}
+
+// sampleWeights считает вес каждого замера как время, в течение которого он был актуален:
+// до следующего замера или до конца окна. Пропуски длиннее gapFactor интервалов
+// обрезаются до одного интервала и не входят в покрытие окна.
+func sampleWeights(samples []Sample, start, end time.Time, interval time.Duration) ([]float64, Window) {
+	window := Window{SampleCount: len(samples)}
+	if len(samples) == 0 {
+		return nil, window
+	}
+
+	weights := make([]float64, len(samples))
+	var observed time.Duration
+	for i, s := range samples {
+		next := end
+		if i < len(samples)-1 {
+			next = samples[i+1].Timestamp
+		}
+
+		w := next.Sub(s.Timestamp)
+		if w < 0 {
+			w = 0
+		}
+		if w > gapFactor*interval {
+			w = interval
+		}
+
+		weights[i] = w.Seconds()
+		observed += w
+	}
+
+	if period := end.Sub(start); period > 0 {
+		window.Coverage = Round(min(observed.Seconds()/period.Seconds(), 1))
+	}
+
+	return weights, window
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleWeights(t *testing.T) {
+	end := time.Date(2025, 1, 1, 0, 0, 10, 0, time.UTC)
+	start := end.Add(-10 * time.Second)
+
+	samplesAt := func(offsets ...time.Duration) []Sample {
+		samples := make([]Sample, 0, len(offsets))
+		for _, offset := range offsets {
+			samples = append(samples, Sample{Timestamp: start.Add(offset)})
+		}
+		return samples
+	}
+
+	t.Run("empty window", func(t *testing.T) {
+		weights, window := sampleWeights(nil, start, end, time.Second)
+		require.Nil(t, weights)
+		require.Equal(t, Window{}, window)
+	})
+
+	t.Run("regular samples cover the window", func(t *testing.T) {
+		offsets := make([]time.Duration, 0, 10)
+		for i := 0; i < 10; i++ {
+			offsets = append(offsets, time.Duration(i)*time.Second)
+		}
+
+		weights, window := sampleWeights(samplesAt(offsets...), start, end, time.Second)
+		require.Len(t, weights, 10)
+		for _, w := range weights {
+			require.InDelta(t, 1.0, w, 1e-9)
+		}
+		require.Equal(t, Window{SampleCount: 10, Coverage: 1}, window)
+	})
+
+	t.Run("gap is not counted as observed", func(t *testing.T) {
+		weights, window := sampleWeights(samplesAt(0, time.Second, 8*time.Second, 9*time.Second), start, end, time.Second)
+		require.Equal(t, []float64{1, 1, 1, 1}, weights)
+		require.Equal(t, Window{SampleCount: 4, Coverage: 0.4}, window)
+	})
+
+	t.Run("simultaneous samples share their interval", func(t *testing.T) {
+		weights, window := sampleWeights(samplesAt(8*time.Second, 9*time.Second, 9*time.Second), start, end, time.Second)
+		require.Equal(t, []float64{1, 0, 1}, weights)
+		require.Equal(t, 3, window.SampleCount)
+		require.InDelta(t, 0.2, window.Coverage, 1e-9)
+	})
+}
@@ -1,9 +1,15 @@
 package models
 
+import "time"
+
 type LoadAverage struct {
 	Load1Min  float64 `protobuf:"fixed64,1,opt,name=load1min,proto3" json:"load1min"`
 	Load5Min  float64 `protobuf:"fixed64,2,opt,name=load5min,proto3" json:"load5min"`
 	Load15Min float64 `protobuf:"fixed64,3,opt,name=load15min,proto3" json:"load15min"`
+	// Количество выполняемых и всех задач, PID последнего созданного процесса.
+	RunnableTasks int32 `protobuf:"varint,4,opt,name=runnable_tasks,proto3" json:"runnableTasks"`
+	TotalTasks    int32 `protobuf:"varint,5,opt,name=total_tasks,proto3" json:"totalTasks"`
+	LastPid       int32 `protobuf:"varint,6,opt,name=last_pid,proto3" json:"lastPid"`
 }
 
 type CPUStat struct {
@@ -31,14 +37,158 @@ type DiskStat struct {
 	FileSystem string     `protobuf:"bytes,1,opt,name=filesystem,proto3" json:"filesystem"`
 	Usage      DiskUsage  `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage"`
 	Inodes     InodeUsage `protobuf:"bytes,3,opt,name=inodes,proto3" json:"inodes"`
+	MountPoint string     `protobuf:"bytes,4,opt,name=mount_point,proto3" json:"mountPoint"`
 }
 
 type DiskUsage struct {
-	Used  uint64 `protobuf:"varint,1,opt,name=used,proto3" json:"used"`
-	Usage string `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage"`
+	Used          uint64  `protobuf:"varint,1,opt,name=used,proto3" json:"used"`
+	Usage         string  `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage"`
+	Available     uint64  `protobuf:"varint,3,opt,name=available,proto3" json:"available"`
+	UsagePercent  float64 `protobuf:"fixed64,4,opt,name=usage_percent,proto3" json:"usagePercent"`
+	GrowthRate    float64 `protobuf:"fixed64,5,opt,name=growth_rate,proto3" json:"growthRate"`
+	SecondsToFull float64 `protobuf:"fixed64,6,opt,name=seconds_to_full,proto3" json:"secondsToFull"`
 }
 
 type InodeUsage struct {
-	Used  uint64 `protobuf:"varint,1,opt,name=used,proto3" json:"used"`
-	Usage string `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage"`
+	Used          uint64  `protobuf:"varint,1,opt,name=used,proto3" json:"used"`
+	Usage         string  `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage"`
+	Available     uint64  `protobuf:"varint,3,opt,name=available,proto3" json:"available"`
+	UsagePercent  float64 `protobuf:"fixed64,4,opt,name=usage_percent,proto3" json:"usagePercent"`
+	GrowthRate    float64 `protobuf:"fixed64,5,opt,name=growth_rate,proto3" json:"growthRate"`
+	SecondsToFull float64 `protobuf:"fixed64,6,opt,name=seconds_to_full,proto3" json:"secondsToFull"`
+}
+
+// CustomMetrics — числовые метрики внешних плагинов.
+type CustomMetrics struct {
+	Metrics []CustomMetric `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics"`
+}
+
+type CustomMetric struct {
+	Plugin string  `protobuf:"bytes,1,opt,name=plugin,proto3" json:"plugin"`
+	Name   string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name"`
+	Value  float64 `protobuf:"fixed64,3,opt,name=value,proto3" json:"value"`
+}
+
+type Processes struct {
+	Processes []Process `protobuf:"bytes,1,rep,name=processes,proto3" json:"processes"`
+}
+
+type Process struct {
+	Pid        int32   `protobuf:"varint,1,opt,name=pid,proto3" json:"pid"`
+	Command    string  `protobuf:"bytes,2,opt,name=command,proto3" json:"command"`
+	User       string  `protobuf:"bytes,3,opt,name=user,proto3" json:"user"`
+	Threads    int32   `protobuf:"varint,4,opt,name=threads,proto3" json:"threads"`
+	CPUPercent float64 `protobuf:"fixed64,5,opt,name=cpu_percent,proto3" json:"cpuPercent"`
+	RSS        uint64  `protobuf:"varint,6,opt,name=rss_bytes,proto3" json:"rssBytes"`
+}
+
+// TopProcesses — лидеры по CPU и по памяти в ответе клиенту.
+type TopProcesses struct {
+	ByCPU    []Process `protobuf:"bytes,1,rep,name=by_cpu,proto3" json:"byCpu"`
+	ByMemory []Process `protobuf:"bytes,2,rep,name=by_memory,proto3" json:"byMemory"`
+}
+
+type Pressure struct {
+	Supported bool             `protobuf:"varint,1,opt,name=supported,proto3" json:"supported"`
+	CPU       PressureResource `protobuf:"bytes,2,opt,name=cpu,proto3" json:"cpu"`
+	Memory    PressureResource `protobuf:"bytes,3,opt,name=memory,proto3" json:"memory"`
+	IO        PressureResource `protobuf:"bytes,4,opt,name=io,proto3" json:"io"`
+}
+
+type PressureResource struct {
+	Some PressureStall `protobuf:"bytes,1,opt,name=some,proto3" json:"some"`
+	Full PressureStall `protobuf:"bytes,2,opt,name=full,proto3" json:"full"`
+}
+
+// PressureStall хранит в Total счётчик ядра в замере и прирост счётчика за окно в агрегате.
+type PressureStall struct {
+	Avg10  float64 `protobuf:"fixed64,1,opt,name=avg10,proto3" json:"avg10"`
+	Avg60  float64 `protobuf:"fixed64,2,opt,name=avg60,proto3" json:"avg60"`
+	Avg300 float64 `protobuf:"fixed64,3,opt,name=avg300,proto3" json:"avg300"`
+	Total  uint64  `protobuf:"varint,4,opt,name=total_delta_us,proto3" json:"total"`
+}
+
+type Cgroups struct {
+	Cgroups []Cgroup `protobuf:"bytes,1,rep,name=cgroups,proto3" json:"cgroups"`
+}
+
+// Cgroup в замере хранит счётчики ядра, а в агрегате — загрузку CPU
+// и объём ввода-вывода за окно. MemoryMax равен 0, если в memory.max
+// записано "max", то есть лимит памяти не задан.
+type Cgroup struct {
+	Path          string  `protobuf:"bytes,1,opt,name=path,proto3" json:"path"`
+	CPUUsageUsec  uint64  `json:"cpuUsageUsec"`
+	CPUPercent    float64 `protobuf:"fixed64,2,opt,name=cpu_percent,proto3" json:"cpuPercent"`
+	MemoryCurrent uint64  `protobuf:"varint,3,opt,name=memory_current_bytes,proto3" json:"memoryCurrent"`
+	MemoryMax     uint64  `protobuf:"varint,4,opt,name=memory_max_bytes,proto3" json:"memoryMax"`
+	IOReadBytes   uint64  `protobuf:"varint,5,opt,name=io_read_bytes,proto3" json:"ioReadBytes"`
+	IOWriteBytes  uint64  `protobuf:"varint,6,opt,name=io_write_bytes,proto3" json:"ioWriteBytes"`
+}
+
+// DaemonSelf — состояние самого демона. Счётчики GC и сборов накапливаются с запуска.
+type DaemonSelf struct {
+	Goroutines     int32             `protobuf:"varint,1,opt,name=goroutines,proto3" json:"goroutines"`
+	HeapBytes      uint64            `protobuf:"varint,2,opt,name=heap_bytes,proto3" json:"heapBytes"`
+	GCCycles       uint64            `protobuf:"varint,3,opt,name=gc_cycles,proto3" json:"gcCycles"`
+	GCPauseSeconds float64           `protobuf:"fixed64,4,opt,name=gc_pause_seconds,proto3" json:"gcPauseSeconds"`
+	ActiveStreams  int32             `protobuf:"varint,5,opt,name=active_streams,proto3" json:"activeStreams"`
+	CleanerRuns    int64             `protobuf:"varint,6,opt,name=cleaner_runs,proto3" json:"cleanerRuns"`
+	Collectors     []DaemonCollector `protobuf:"bytes,7,rep,name=collectors,proto3" json:"collectors"`
+}
+
+type DaemonCollector struct {
+	StatType            int32   `protobuf:"varint,1,opt,name=stat_type,proto3" json:"statType"`
+	Name                string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name"`
+	LastDurationSeconds float64 `protobuf:"fixed64,3,opt,name=last_duration_seconds,proto3" json:"lastDurationSeconds"`
+	Runs                int64   `protobuf:"varint,4,opt,name=runs,proto3" json:"runs"`
+	Errors              int64   `protobuf:"varint,5,opt,name=errors,proto3" json:"errors"`
+	StoredSamples       int64   `protobuf:"varint,6,opt,name=stored_samples,proto3" json:"storedSamples"`
+}
+
+// Host описывает машину, за которой наблюдает демон. Нулевой BootTime — время загрузки неизвестно.
+type Host struct {
+	Hostname string    `json:"hostname"`
+	Kernel   string    `json:"kernel"`
+	CPUCount int32     `json:"cpuCount"`
+	BootTime time.Time `json:"bootTime"`
+}
+
+type AlertState int
+
+const (
+	AlertInactive AlertState = iota
+	AlertPending
+	AlertFiring
+	AlertResolved
+)
+
+func (s AlertState) String() string {
+	switch s {
+	case AlertInactive:
+		return "inactive"
+	case AlertPending:
+		return "pending"
+	case AlertFiring:
+		return "firing"
+	case AlertResolved:
+		return "resolved"
+	default:
+		return "unknown"
+	}
+}
+
+func (s AlertState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+type Alert struct {
+	Rule        string     `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule"`
+	Metric      string     `protobuf:"bytes,2,opt,name=metric,proto3" json:"metric"`
+	Target      string     `protobuf:"bytes,3,opt,name=target,proto3" json:"target"`
+	State       AlertState `protobuf:"varint,4,opt,name=state,proto3" json:"state"`
+	Operator    string     `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator"`
+	Threshold   float64    `protobuf:"fixed64,6,opt,name=threshold,proto3" json:"threshold"`
+	Value       float64    `protobuf:"fixed64,7,opt,name=value,proto3" json:"value"`
+	ActiveSince time.Time  `protobuf:"varint,8,opt,name=active_since,proto3" json:"activeSince"`
+	Timestamp   time.Time  `protobuf:"varint,9,opt,name=timestamp,proto3" json:"timestamp"`
 }
@@ -4,48 +4,153 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"reflect"
+	"sync/atomic"
 	"time"
 
+	"github.com/cepmap/otus-system-monitoring/internal/alerts"
 	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
 	"github.com/cepmap/otus-system-monitoring/internal/collector"
 	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/converter"
+	"github.com/cepmap/otus-system-monitoring/internal/hostinfo"
 	"github.com/cepmap/otus-system-monitoring/internal/logger"
 	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/notifier"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+	"github.com/cepmap/otus-system-monitoring/internal/stats/daemon"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	// minKeepaliveTime — минимальный допустимый интервал keepalive-пингов клиента.
+	minKeepaliveTime = 5 * time.Second
+	// minInterval — наименьший интервал отправки статистики в секундах.
+	minInterval = 1
+)
+
+// Version — версия сборки демона, её задают при сборке через ldflags.
+type Version struct {
+	Release   string
+	BuildDate string
+	GitHash   string
+}
+
 type StatsDaemonServer struct {
 	ctx        context.Context
+	config     *config.Store
 	grpcServer *grpc.Server
 	metrics    *metrics.Storage
+	alerts     *alerts.Engine
+	// collectors — сборщики, которым нужны зависимости этого сервера.
+	collectors collector.Option
+	version    Version
+	// streams — количество открытых потоков GetStats и WatchAlerts.
+	streams atomic.Int64
 	pb.UnimplementedStatsServiceServer
 }
 
-func NewStatsDaemonServer(ctx context.Context) *StatsDaemonServer {
+func NewStatsDaemonServer(ctx context.Context, cfg *config.Config) *StatsDaemonServer {
+	// Клиенты держат поток открытым пингами, в том числе в ожидании первого ответа.
+	keepalivePolicy := grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+		MinTime:             minKeepaliveTime,
+		PermitWithoutStream: true,
+	})
 	s := &StatsDaemonServer{
 		ctx:        ctx,
-		grpcServer: grpc.NewServer(),
-		metrics:    metrics.New(),
+		config:     config.NewStore(cfg),
+		grpcServer: grpc.NewServer(keepalivePolicy),
+		metrics:    metrics.New(cfg.Stats),
 	}
 	pb.RegisterStatsServiceServer(s.grpcServer, s)
 
 	s.metrics.StartCleaner(ctx)
+	s.collectors = collector.WithCollectors(daemon.NewCollector(s))
+
+	if err := collector.ValidateConfig(cfg.Stats); err != nil {
+		logger.Warn(fmt.Sprintf("Stats config problems: %v", err))
+	}
+
+	engine, err := alerts.New(s.metrics, s.config, cfg.Alerts.Interval, cfg.Alerts.Rules, s.collectors)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Invalid alert rules, disabling alerting: %v", err))
+		engine, _ = alerts.New(s.metrics, s.config, cfg.Alerts.Interval, nil, s.collectors)
+	}
+	s.alerts = engine
+	s.alerts.Start(ctx)
+
+	notifications := cfg.Alerts.Notifications
+	if notifiers := notifier.FromConfig(notifications.Webhooks, notifications.Exec); len(notifiers) > 0 {
+		events, _ := s.alerts.Subscribe()
+		notifier.NewDispatcher(notifiers, notifications.GroupWait, notifications.RepeatInterval).Run(ctx, events)
+	}
 
 	return s
 }
 
+// ApplyConfig применяет перечитанную конфигурацию к работающему серверу.
+// Включение и выключение статистики подхватывается подписками при следующей отправке.
+// Правила и уведомления алертов применяются только при запуске: в cfg для них
+// остаются действующие значения, чтобы конфигурация сервера совпадала с работающей.
+func (s *StatsDaemonServer) ApplyConfig(cfg *config.Config) {
+	prev := s.config.Get()
+	if !reflect.DeepEqual(prev.Alerts, cfg.Alerts) {
+		logger.Warn("Alert rules and notification changes require a restart, keeping current alerts config")
+		cfg.Alerts = prev.Alerts
+	}
+	s.config.Set(cfg)
+
+	if prev.Stats.Limit != cfg.Stats.Limit {
+		logger.Info(fmt.Sprintf("Stats limit changed from %d to %d", prev.Stats.Limit, cfg.Stats.Limit))
+		s.metrics.SetLimit(cfg.Stats.Limit)
+	}
+	if prev.Stats.Retention != cfg.Stats.Retention {
+		logger.Info(fmt.Sprintf("Stats retention changed from %v to %v", prev.Stats.Retention, cfg.Stats.Retention))
+		s.metrics.SetRetention(cfg.Stats.Retention)
+	}
+	if prev.Server.MaxStreams != cfg.Server.MaxStreams {
+		logger.Info(fmt.Sprintf("Max streams changed from %d to %d", prev.Server.MaxStreams, cfg.Server.MaxStreams))
+	}
+	if prev.Server.Host != cfg.Server.Host || prev.Server.Port != cfg.Server.Port {
+		logger.Warn("Server address changes require a restart")
+	}
+}
+
+// SetVersion задаёт версию, которую сервер сообщает в GetCapabilities.
+func (s *StatsDaemonServer) SetVersion(version Version) {
+	s.version = version
+}
+
+// ActiveStreams возвращает количество открытых потоков статистики и алертов.
+func (s *StatsDaemonServer) ActiveStreams() int {
+	return int(s.streams.Load())
+}
+
+// StorageStats возвращает счётчики хранилища замеров для статистики DAEMON_SELF.
+func (s *StatsDaemonServer) StorageStats() metrics.StorageStats {
+	return s.metrics.Stats()
+}
+
 func (s *StatsDaemonServer) Start() error {
-	addr := net.JoinHostPort(config.DaemonConfig.Server.Host, config.DaemonConfig.Server.Port)
+	cfg := s.config.Get()
+	addr := net.JoinHostPort(cfg.Server.Host, cfg.Server.Port)
 	lis, err := net.Listen("tcp4", addr)
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 
 	logger.Info(fmt.Sprintf("Starting stats daemon server on %s (IPv4 only)", addr))
+	return s.Serve(lis)
+}
 
+// Serve обслуживает запросы на готовом слушателе, например на bufconn в тестах.
+func (s *StatsDaemonServer) Serve(lis net.Listener) error {
 	go func() {
 		<-s.ctx.Done()
 		logger.Info("Context cancelled, stopping server...")
@@ -77,16 +182,23 @@ func (s *StatsDaemonServer) GetStats(req *pb.StatsRequest, stream pb.StatsServic
 		}
 		logger.Info(fmt.Sprintf("Client %s disconnected", clientAddr))
 	}()
+	release, err := s.acquireStream()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Rejecting stats request from %s: %v", clientAddr, err))
+		return err
+	}
+	defer release()
 
-	logger.Info(fmt.Sprintf("New stats request received from %s: interval=%d, averaging_period=%d, types=%v",
-		clientAddr, req.IntervalN, req.AveragingPeriodM, req.StatTypes))
+	logger.Info(fmt.Sprintf(
+		"New stats request received from %s: interval=%d, averaging_period=%d, types=%v, aggregation=%s, top_n=%d, since=%d",
+		clientAddr, req.IntervalN, req.AveragingPeriodM, req.StatTypes, req.Aggregation, req.TopN, req.Since))
 
 	if len(req.StatTypes) == 0 {
 		logger.Error("Empty stat types list")
 		return status.Errorf(codes.InvalidArgument, "stat types list cannot be empty")
 	}
 
-	if req.IntervalN < 1 {
+	if req.IntervalN < minInterval {
 		logger.Error(fmt.Sprintf("Interval %d is less than 1", req.IntervalN))
 		return status.Errorf(codes.InvalidArgument, "interval must be greater than 0")
 	}
@@ -96,42 +208,64 @@ func (s *StatsDaemonServer) GetStats(req *pb.StatsRequest, stream pb.StatsServic
 		return status.Errorf(codes.InvalidArgument, "averaging period must be greater than 0")
 	}
 
+	if req.TopN < 0 || req.TopN > stats.MaxTopN {
+		logger.Error(fmt.Sprintf("Top N %d is out of range", req.TopN))
+		return status.Errorf(codes.InvalidArgument, "top n must be from 0 to %d", stats.MaxTopN)
+	}
+
+	if _, ok := pb.Aggregation_name[int32(req.Aggregation)]; !ok {
+		logger.Error(fmt.Sprintf("Unknown aggregation %d", req.Aggregation))
+		return status.Errorf(codes.InvalidArgument, "unknown aggregation")
+	}
+
+	if req.Since < 0 || req.Since > time.Now().Unix() {
+		logger.Error(fmt.Sprintf("Since %d is out of range", req.Since))
+		return status.Errorf(codes.InvalidArgument, "since must not be negative or in the future")
+	}
+
+	cfg := s.config.Get()
 	for _, statType := range req.StatTypes {
-		switch statType {
-		case pb.StatType_LOAD_AVERAGE:
-			if !config.DaemonConfig.Stats.LoadAverage {
-				return status.Errorf(codes.FailedPrecondition, "load average metrics are disabled in configuration")
-			}
-		case pb.StatType_CPU_STATS:
-			if !config.DaemonConfig.Stats.Cpu {
-				return status.Errorf(codes.FailedPrecondition, "CPU metrics are disabled in configuration")
-			}
-		case pb.StatType_DISKS_LOAD:
-			if !config.DaemonConfig.Stats.DiskLoad {
-				return status.Errorf(codes.FailedPrecondition, "disk load metrics are disabled in configuration")
-			}
-		case pb.StatType_DISK_USAGE:
-			if !config.DaemonConfig.Stats.DiskInfo {
-				return status.Errorf(codes.FailedPrecondition, "disk usage metrics are disabled in configuration")
-			}
+		if _, ok := stats.Lookup(statType); !ok {
+			logger.Error(fmt.Sprintf("Unknown stat type %s", statType))
+			return status.Errorf(codes.InvalidArgument, "unknown stat type %s", statType)
+		}
+		if !collector.Enabled(cfg.Stats, statType) {
+			return status.Errorf(codes.FailedPrecondition, "%s metrics are disabled in configuration", statType)
 		}
 	}
 
-	if int64(req.AveragingPeriodM) > config.DaemonConfig.Stats.Limit {
+	if int64(req.AveragingPeriodM) > cfg.Stats.Limit {
 		logger.Error(fmt.Sprintf("Averaging period %d is greater than limit %d",
-			req.AveragingPeriodM, config.DaemonConfig.Stats.Limit))
+			req.AveragingPeriodM, cfg.Stats.Limit))
 		return status.Errorf(codes.InvalidArgument, "averaging period is greater than limit")
 	}
 
 	averagingPeriod := time.Duration(req.AveragingPeriodM) * time.Second
-	collector := collector.New(s.metrics, req.StatTypes, averagingPeriod)
+	aggregation := converter.AggregationFromProto(req.Aggregation)
+	options := stats.Options{TopN: int(req.TopN)}
+	collector := collector.New(s.metrics, s.config, req.StatTypes, averagingPeriod, aggregation, options, s.collectors)
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
 
-	collectTicker := time.NewTicker(1 * time.Second)
-	defer collectTicker.Stop()
+	interval := time.Duration(req.IntervalN) * time.Second
+	if req.Since > 0 {
+		responses := collector.Backfill(time.Unix(req.Since, 0), interval)
+		logger.Info(fmt.Sprintf("Sending %d missed responses to %s", len(responses), clientAddr))
+		for _, response := range responses {
+			if err := stream.Send(response); err != nil {
+				logger.Error(fmt.Sprintf("Failed to send stats to %s: %v", clientAddr, err))
+				return err
+			}
+		}
+	}
 
-	collector.CollectInitialData()
+	// Периодический сбор запускается до начального, чтобы очередной замер
+	// всегда успевал попасть в окно перед отправкой.
+	collector.Start(ctx)
+	collector.CollectInitialData(ctx)
 
-	sendTicker := time.NewTicker(time.Duration(req.IntervalN) * time.Second)
+	sendTicker := time.NewTicker(interval)
 	defer sendTicker.Stop()
 
 	for {
@@ -142,14 +276,130 @@ func (s *StatsDaemonServer) GetStats(req *pb.StatsRequest, stream pb.StatsServic
 		case <-stream.Context().Done():
 			logger.Info(fmt.Sprintf("Request cancelled by client %s", clientAddr))
 			return fmt.Errorf("client cancelled the request")
-		case <-collectTicker.C:
-			collector.CollectMetrics(time.Now())
 		case <-sendTicker.C:
 			response := collector.PrepareResponse()
 			if err := stream.Send(response); err != nil {
 				logger.Error(fmt.Sprintf("Failed to send stats to %s: %v", clientAddr, err))
 				return err
 			}
+			if collector.AllDisabled(response) {
+				logger.Info(fmt.Sprintf("All stats requested by %s are disabled, closing stream", clientAddr))
+				return status.Errorf(codes.FailedPrecondition, "requested metrics are disabled in configuration")
+			}
 		}
 	}
 }
+
+func (s *StatsDaemonServer) WatchAlerts(req *pb.WatchAlertsRequest, stream pb.StatsService_WatchAlertsServer) error {
+	peer, ok := peer.FromContext(stream.Context())
+	clientAddr := "unknown"
+	if ok {
+		clientAddr = peer.Addr.String()
+	}
+	logger.Info(fmt.Sprintf("New alerts watcher %s: include_pending=%v", clientAddr, req.IncludePending))
+	defer logger.Info(fmt.Sprintf("Alerts watcher %s disconnected", clientAddr))
+	release, err := s.acquireStream()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Rejecting alerts watcher %s: %v", clientAddr, err))
+		return err
+	}
+	defer release()
+
+	events, unsubscribe := s.alerts.Subscribe()
+	defer unsubscribe()
+
+	visible := func(alert models.Alert) bool {
+		switch alert.State {
+		case models.AlertFiring, models.AlertResolved:
+			return true
+		case models.AlertPending, models.AlertInactive:
+			return req.IncludePending
+		default:
+			return false
+		}
+	}
+
+	for _, alert := range s.alerts.Active() {
+		if !visible(alert) {
+			continue
+		}
+		if err := stream.Send(converter.AlertToProto(&alert)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return fmt.Errorf("server is shutting down")
+		case <-stream.Context().Done():
+			return fmt.Errorf("client cancelled the request")
+		case alert := <-events:
+			if !visible(alert) {
+				continue
+			}
+			if err := stream.Send(converter.AlertToProto(&alert)); err != nil {
+				logger.Error(fmt.Sprintf("Failed to send alert to %s: %v", clientAddr, err))
+				return err
+			}
+		}
+	}
+}
+
+// acquireStream учитывает открытый поток. Сверх server.max_streams поток
+// отклоняется с ResourceExhausted, клиент может повторить попытку позже.
+func (s *StatsDaemonServer) acquireStream() (func(), error) {
+	limit := int64(s.config.Get().Server.MaxStreams)
+	if n := s.streams.Add(1); limit > 0 && n > limit {
+		s.streams.Add(-1)
+		return nil, status.Errorf(codes.ResourceExhausted, "too many open streams, limit is %d", limit)
+	}
+	return func() { s.streams.Add(-1) }, nil
+}
+
+func (s *StatsDaemonServer) ListStatTypes(context.Context, *pb.ListStatTypesRequest) (*pb.ListStatTypesResponse, error) {
+	return &pb.ListStatTypesResponse{StatTypes: statTypes(s.config.Get().Stats)}, nil
+}
+
+// statTypes перечисляет все типы статистики в порядке номеров. Тип без
+// зарегистрированного сборщика остаётся без имени и считается выключенным.
+func statTypes(cfg config.Stats) []*pb.StatTypeInfo {
+	result := make([]*pb.StatTypeInfo, 0, len(pb.StatType_name))
+	for i := range int32(len(pb.StatType_name)) {
+		statType := pb.StatType(i)
+		info := &pb.StatTypeInfo{StatType: statType, Enabled: collector.Enabled(cfg, statType)}
+		if sc, ok := stats.Lookup(statType); ok {
+			info.Name = sc.Name()
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+func (s *StatsDaemonServer) GetCapabilities(context.Context, *pb.GetCapabilitiesRequest) (*pb.Capabilities, error) {
+	cfg := s.config.Get()
+
+	aggregations := make([]pb.Aggregation, 0, len(pb.Aggregation_name))
+	for i := range int32(len(pb.Aggregation_name)) {
+		aggregations = append(aggregations, pb.Aggregation(i))
+	}
+	host := hostinfo.Get(procfs.New(cfg.Stats.Procfs, cfg.Stats.Sysfs), hostinfo.HostEtc(cfg.Stats.Procfs))
+
+	//nolint:gosec
+	return &pb.Capabilities{
+		StatTypes: statTypes(cfg.Stats),
+		Limits: &pb.Limits{
+			MaxAveragingPeriod: cfg.Stats.Limit,
+			MinInterval:        minInterval,
+			MaxStreams:         int32(cfg.Server.MaxStreams),
+			MaxTopN:            stats.MaxTopN,
+		},
+		Aggregations: aggregations,
+		Host:         converter.HostToProto(&host),
+		Version: &pb.DaemonVersion{
+			Release:   s.version.Release,
+			BuildDate: s.version.BuildDate,
+			GitHash:   s.version.GitHash,
+		},
+	}, nil
+}
@@ -7,18 +7,22 @@ import (
 	"testing"
 	"time"
 
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/collector"
 	"github.com/cepmap/otus-system-monitoring/internal/config"
 	"github.com/stretchr/testify/require"
 )
 
 func TestServer(t *testing.T) {
-	config.DaemonConfig = &config.Config{}
-	config.DaemonConfig.Server.Host = "localhost"
-	config.DaemonConfig.Server.Port = "0"
+	t.Parallel()
+
+	cfg := &config.Config{}
+	cfg.Server.Host = "localhost"
+	cfg.Server.Port = "0"
 
 	t.Run("create server", func(t *testing.T) {
 		ctx := context.Background()
-		srv := NewStatsDaemonServer(ctx)
+		srv := NewStatsDaemonServer(ctx, cfg)
 		require.NotNil(t, srv)
 		require.NotNil(t, srv.grpcServer)
 		require.NotNil(t, srv.metrics)
@@ -28,15 +32,16 @@ func TestServer(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		srv := NewStatsDaemonServer(ctx)
-		require.NotNil(t, srv)
-
-		lis, err := net.Listen("tcp", net.JoinHostPort(config.DaemonConfig.Server.Host, "0"))
+		lis, err := net.Listen("tcp", net.JoinHostPort(cfg.Server.Host, "0"))
 		require.NoError(t, err)
 		port := lis.Addr().(*net.TCPAddr).Port
 		lis.Close()
 
-		config.DaemonConfig.Server.Port = fmt.Sprintf("%d", port)
+		listenCfg := *cfg
+		listenCfg.Server.Port = fmt.Sprintf("%d", port)
+
+		srv := NewStatsDaemonServer(ctx, &listenCfg)
+		require.NotNil(t, srv)
 
 		errCh := make(chan error, 1)
 		go func() {
@@ -50,7 +55,7 @@ func TestServer(t *testing.T) {
 			require.NoError(t, err)
 		default:
 
-			conn, err := net.Dial("tcp", net.JoinHostPort(config.DaemonConfig.Server.Host, config.DaemonConfig.Server.Port))
+			conn, err := net.Dial("tcp", net.JoinHostPort(listenCfg.Server.Host, listenCfg.Server.Port))
 			if err == nil {
 				conn.Close()
 			}
@@ -59,4 +64,22 @@ func TestServer(t *testing.T) {
 
 		srv.Stop()
 	})
+	t.Run("apply config", func(t *testing.T) {
+		srv := NewStatsDaemonServer(context.Background(), cfg)
+
+		reloaded := *cfg
+		reloaded.Stats.LoadAverage = true
+		reloaded.Stats.Limit = 10
+		srv.ApplyConfig(&reloaded)
+
+		require.Same(t, &reloaded, srv.config.Get())
+		require.True(t, collector.Enabled(srv.config.Get().Stats, pb.StatType_LOAD_AVERAGE))
+
+		// Алерты не перезапускаются на ходу, поэтому их изменения не применяются.
+		changed := reloaded
+		changed.Alerts.Rules = []config.AlertRule{{Name: "high cpu", Metric: "cpu.user", Operator: ">", Threshold: 90}}
+		srv.ApplyConfig(&changed)
+		require.Empty(t, srv.config.Get().Alerts.Rules)
+		require.Equal(t, 10, int(srv.config.Get().Stats.Limit))
+	})
 }
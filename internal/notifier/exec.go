@@ -0,0 +1,92 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/tools"
+)
+
+const defaultExecTimeout = 10 * time.Second
+
+// maxExecOutput ограничивает вывод команды, который попадает в ошибку.
+const maxExecOutput = 4 * 1024
+
+// ExecHook запускает локальную команду и передаёт данные алерта через переменные окружения
+// ALERT_*, а полное уведомление в JSON — через ALERT_PAYLOAD.
+type ExecHook struct {
+	command string
+	args    []string
+	timeout time.Duration
+}
+
+func NewExecHook(cfg config.ExecHook) *ExecHook {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultExecTimeout
+	}
+	return &ExecHook{command: cfg.Command, args: cfg.Args, timeout: timeout}
+}
+
+func (h *ExecHook) Name() string {
+	return "exec " + h.command
+}
+
+func (h *ExecHook) Notify(ctx context.Context, n Notification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	//nolint:gosec
+	cmd := exec.CommandContext(ctx, h.command, h.args...)
+	cmd.Env = append(os.Environ(), notificationEnv(n, payload)...)
+	output := tools.NewLimitedBuffer(maxExecOutput)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	// Фоновый потомок команды держит вывод открытым, Wait не должен его ждать.
+	cmd.WaitDelay = tools.WaitDelay
+
+	// ErrWaitDelay означает, что сама команда завершилась успешно.
+	if err := cmd.Run(); err != nil && !errors.Is(err, exec.ErrWaitDelay) {
+		return fmt.Errorf("command failed: %w: %s", err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
+func notificationEnv(n Notification, payload []byte) []string {
+	targets := make([]string, 0, len(n.Alerts))
+	values := make([]string, 0, len(n.Alerts))
+	for _, alert := range n.Alerts {
+		targets = append(targets, alert.Target)
+		values = append(values, strconv.FormatFloat(alert.Value, 'f', -1, 64))
+	}
+
+	env := []string{
+		"ALERT_RULE=" + n.Rule,
+		"ALERT_STATE=" + n.State.String(),
+		"ALERT_COUNT=" + strconv.Itoa(len(n.Alerts)),
+		"ALERT_TARGETS=" + strings.Join(targets, ","),
+		"ALERT_VALUES=" + strings.Join(values, ","),
+		"ALERT_PAYLOAD=" + string(payload),
+	}
+	if len(n.Alerts) > 0 {
+		env = append(env,
+			"ALERT_METRIC="+n.Alerts[0].Metric,
+			"ALERT_OPERATOR="+n.Alerts[0].Operator,
+			"ALERT_THRESHOLD="+strconv.FormatFloat(n.Alerts[0].Threshold, 'f', -1, 64),
+		)
+	}
+	return env
+}
@@ -0,0 +1,216 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/logger"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+)
+
+const (
+	defaultGroupWait = 5 * time.Second
+	// maxRepeatCheck ограничивает задержку повторного уведомления сверх repeatInterval.
+	maxRepeatCheck = time.Minute
+)
+
+// Notification объединяет алерты одного правила с одинаковым состоянием,
+// например, несколько заполняющихся файловых систем.
+type Notification struct {
+	Rule   string            `json:"rule"`
+	State  models.AlertState `json:"state"`
+	Alerts []models.Alert    `json:"alerts"`
+}
+
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, n Notification) error
+}
+
+func FromConfig(cfg []config.Webhook, hooks []config.ExecHook) []Notifier {
+	notifiers := make([]Notifier, 0, len(cfg)+len(hooks))
+	for _, webhook := range cfg {
+		notifiers = append(notifiers, NewWebhook(webhook))
+	}
+	for _, hook := range hooks {
+		notifiers = append(notifiers, NewExecHook(hook))
+	}
+	return notifiers
+}
+
+type sentAlert struct {
+	alert models.Alert
+	at    time.Time
+}
+
+// Dispatcher получает смену состояний алертов, отбрасывает повторы
+// и группирует алерты по правилу перед отправкой. Движок сообщает только
+// о смене состояния, поэтому о всё ещё сработавших алертах Dispatcher
+// напоминает сам раз в repeatInterval.
+type Dispatcher struct {
+	notifiers      []Notifier
+	groupWait      time.Duration
+	repeatInterval time.Duration
+
+	sent    map[string]sentAlert
+	pending map[string]*Notification
+	flushCh chan string
+}
+
+func NewDispatcher(notifiers []Notifier, groupWait, repeatInterval time.Duration) *Dispatcher {
+	if groupWait <= 0 {
+		groupWait = defaultGroupWait
+	}
+	return &Dispatcher{
+		notifiers:      notifiers,
+		groupWait:      groupWait,
+		repeatInterval: repeatInterval,
+		sent:           make(map[string]sentAlert),
+		pending:        make(map[string]*Notification),
+		flushCh:        make(chan string),
+	}
+}
+
+func (d *Dispatcher) Run(ctx context.Context, events <-chan models.Alert) {
+	if len(d.notifiers) == 0 {
+		return
+	}
+	go d.loop(ctx, events)
+}
+
+func (d *Dispatcher) loop(ctx context.Context, events <-chan models.Alert) {
+	logger.Info(fmt.Sprintf("Alert notifications started with %d notifiers", len(d.notifiers)))
+	defer logger.Info("Alert notifications stopped")
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	var repeatCh <-chan time.Time
+	if d.repeatInterval > 0 {
+		ticker := time.NewTicker(min(d.repeatInterval, maxRepeatCheck))
+		defer ticker.Stop()
+		repeatCh = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case alert := <-events:
+			d.add(ctx, alert)
+		case now := <-repeatCh:
+			d.repeat(ctx, now)
+		case key := <-d.flushCh:
+			n, ok := d.pending[key]
+			if !ok {
+				continue
+			}
+			delete(d.pending, key)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				d.send(ctx, *n)
+			}()
+		}
+	}
+}
+
+func (d *Dispatcher) add(ctx context.Context, alert models.Alert) {
+	if alert.State != models.AlertFiring && alert.State != models.AlertResolved {
+		return
+	}
+
+	alertKey := alert.Rule + "/" + alert.Target
+	last, ok := d.sent[alertKey]
+	switch {
+	case alert.State == models.AlertResolved && (!ok || last.alert.State != models.AlertFiring):
+		// Не сообщаем о восстановлении того, о срабатывании чего не сообщали.
+		return
+	case ok && last.alert.State == alert.State:
+		return
+	}
+	if alert.State == models.AlertResolved {
+		delete(d.sent, alertKey)
+		// Алерт восстановился раньше, чем ушло уведомление о срабатывании: не отправляем ни то, ни другое.
+		if d.dropPending(alert.Rule+"/"+models.AlertFiring.String(), alert.Target) {
+			return
+		}
+	} else {
+		d.sent[alertKey] = sentAlert{alert: alert, at: time.Now()}
+	}
+	d.enqueue(ctx, alert)
+}
+
+// repeat повторно отправляет алерты, которые остаются сработавшими дольше repeatInterval.
+func (d *Dispatcher) repeat(ctx context.Context, now time.Time) {
+	for key, last := range d.sent {
+		if now.Sub(last.at) < d.repeatInterval {
+			continue
+		}
+		alert := last.alert
+		alert.Timestamp = now
+		d.sent[key] = sentAlert{alert: alert, at: now}
+		d.enqueue(ctx, alert)
+	}
+}
+
+// enqueue добавляет алерт в уведомление его правила, отправляемое через groupWait.
+func (d *Dispatcher) enqueue(ctx context.Context, alert models.Alert) {
+	groupKey := alert.Rule + "/" + alert.State.String()
+	n, ok := d.pending[groupKey]
+	if !ok {
+		n = &Notification{Rule: alert.Rule, State: alert.State}
+		d.pending[groupKey] = n
+		time.AfterFunc(d.groupWait, func() {
+			select {
+			case d.flushCh <- groupKey:
+			case <-ctx.Done():
+			}
+		})
+	}
+
+	for i := range n.Alerts {
+		if n.Alerts[i].Target == alert.Target {
+			n.Alerts[i] = alert
+			return
+		}
+	}
+	n.Alerts = append(n.Alerts, alert)
+}
+
+func (d *Dispatcher) dropPending(groupKey, target string) bool {
+	n, ok := d.pending[groupKey]
+	if !ok {
+		return false
+	}
+	for i := range n.Alerts {
+		if n.Alerts[i].Target == target {
+			n.Alerts = append(n.Alerts[:i], n.Alerts[i+1:]...)
+			if len(n.Alerts) == 0 {
+				delete(d.pending, groupKey)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Dispatcher) send(ctx context.Context, n Notification) {
+	var wg sync.WaitGroup
+	for _, notifier := range d.notifiers {
+		wg.Add(1)
+		go func(notifier Notifier) {
+			defer wg.Done()
+			if err := notifier.Notify(ctx, n); err != nil {
+				logger.Error(fmt.Sprintf("Failed to send %s notification for %s via %s: %v",
+					n.State, n.Rule, notifier.Name(), err))
+				return
+			}
+			logger.Info(fmt.Sprintf("Sent %s notification for %s via %s", n.State, n.Rule, notifier.Name()))
+		}(notifier)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,237 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func testNotification() Notification {
+	return Notification{
+		Rule:  "var_full",
+		State: models.AlertFiring,
+		Alerts: []models.Alert{
+			{Rule: "var_full", Metric: "filesystem.usage_percent", Target: "/var", Operator: ">", Threshold: 90, Value: 95},
+			{Rule: "var_full", Metric: "filesystem.usage_percent", Target: "/home", Operator: ">", Threshold: 90, Value: 91},
+		},
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	t.Run("posts json payload", func(t *testing.T) {
+		received := make(chan map[string]interface{}, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			received <- body
+		}))
+		defer srv.Close()
+
+		err := NewWebhook(config.Webhook{URL: srv.URL}).Notify(context.Background(), testNotification())
+		require.NoError(t, err)
+
+		body := <-received
+		require.Equal(t, "var_full", body["rule"])
+		require.Equal(t, "firing", body["state"])
+		require.Len(t, body["alerts"], 2)
+	})
+
+	t.Run("retries server errors", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer srv.Close()
+
+		webhook := NewWebhook(config.Webhook{URL: srv.URL, MaxRetries: 3, Backoff: time.Millisecond})
+		require.NoError(t, webhook.Notify(context.Background(), testNotification()))
+		require.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		webhook := NewWebhook(config.Webhook{URL: srv.URL, MaxRetries: 2, Backoff: time.Millisecond})
+		err := webhook.Notify(context.Background(), testNotification())
+		require.ErrorIs(t, err, ErrWebhookStatus)
+		require.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer srv.Close()
+
+		webhook := NewWebhook(config.Webhook{URL: srv.URL, MaxRetries: 2, Backoff: time.Millisecond})
+		require.ErrorIs(t, webhook.Notify(context.Background(), testNotification()), ErrWebhookStatus)
+		require.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestExecHook(t *testing.T) {
+	t.Run("passes alert in environment", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "env")
+		hook := NewExecHook(config.ExecHook{
+			Command: "sh",
+			Args:    []string{"-c", "env | grep ^ALERT_ > " + out},
+		})
+		require.NoError(t, hook.Notify(context.Background(), testNotification()))
+
+		data, err := os.ReadFile(out)
+		require.NoError(t, err)
+		env := string(data)
+		require.Contains(t, env, "ALERT_RULE=var_full\n")
+		require.Contains(t, env, "ALERT_STATE=firing\n")
+		require.Contains(t, env, "ALERT_COUNT=2\n")
+		require.Contains(t, env, "ALERT_TARGETS=/var,/home\n")
+		require.Contains(t, env, "ALERT_VALUES=95,91\n")
+		require.Contains(t, env, "ALERT_METRIC=filesystem.usage_percent\n")
+		require.Contains(t, env, "ALERT_THRESHOLD=90\n")
+		require.Contains(t, env, `ALERT_PAYLOAD={"rule":"var_full"`)
+	})
+
+	t.Run("command failure", func(t *testing.T) {
+		hook := NewExecHook(config.ExecHook{Command: "sh", Args: []string{"-c", "echo broken; exit 3"}})
+		err := hook.Notify(context.Background(), testNotification())
+		require.Error(t, err)
+		require.True(t, strings.Contains(err.Error(), "broken"))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		hook := NewExecHook(config.ExecHook{Command: "sleep", Args: []string{"5"}, Timeout: 50 * time.Millisecond})
+		start := time.Now()
+		require.Error(t, hook.Notify(context.Background(), testNotification()))
+		require.Less(t, time.Since(start), 2*time.Second)
+	})
+
+	t.Run("background child keeps output open", func(t *testing.T) {
+		hook := NewExecHook(config.ExecHook{Command: "sh", Args: []string{"-c", "sleep 10 & echo started"}})
+		start := time.Now()
+		require.NoError(t, hook.Notify(context.Background(), testNotification()))
+		require.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("output is capped", func(t *testing.T) {
+		hook := NewExecHook(config.ExecHook{Command: "sh", Args: []string{"-c", "yes broken | head -c 100000; exit 3"}})
+		err := hook.Notify(context.Background(), testNotification())
+		require.ErrorContains(t, err, "broken")
+		require.Less(t, len(err.Error()), maxExecOutput+100)
+	})
+}
+
+type fakeNotifier struct {
+	mu   sync.Mutex
+	sent []Notification
+}
+
+func (f *fakeNotifier) Name() string { return "fake" }
+
+func (f *fakeNotifier) Notify(_ context.Context, n Notification) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, n)
+	return nil
+}
+
+func (f *fakeNotifier) notifications() []Notification {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Notification(nil), f.sent...)
+}
+
+func TestDispatcher(t *testing.T) {
+	alert := func(target string, state models.AlertState) models.Alert {
+		return models.Alert{Rule: "var_full", Target: target, State: state}
+	}
+
+	t.Run("groups and deduplicates per rule", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		fake := &fakeNotifier{}
+		events := make(chan models.Alert)
+		NewDispatcher([]Notifier{fake}, 50*time.Millisecond, time.Hour).Run(ctx, events)
+
+		events <- alert("/var", models.AlertPending)
+		events <- alert("/var", models.AlertFiring)
+		events <- alert("/home", models.AlertFiring)
+		events <- alert("/var", models.AlertFiring)
+
+		require.Eventually(t, func() bool { return len(fake.notifications()) == 1 }, time.Second, 10*time.Millisecond)
+		n := fake.notifications()[0]
+		require.Equal(t, models.AlertFiring, n.State)
+		require.Len(t, n.Alerts, 2)
+
+		events <- alert("/var", models.AlertFiring)
+		events <- alert("/var", models.AlertResolved)
+		require.Eventually(t, func() bool { return len(fake.notifications()) == 2 }, time.Second, 10*time.Millisecond)
+		n = fake.notifications()[1]
+		require.Equal(t, models.AlertResolved, n.State)
+		require.Len(t, n.Alerts, 1)
+	})
+
+	t.Run("drops alerts resolved before notification", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		fake := &fakeNotifier{}
+		events := make(chan models.Alert)
+		NewDispatcher([]Notifier{fake}, 50*time.Millisecond, 0).Run(ctx, events)
+
+		events <- alert("/var", models.AlertResolved)
+		events <- alert("/home", models.AlertFiring)
+		events <- alert("/home", models.AlertResolved)
+
+		time.Sleep(150 * time.Millisecond)
+		require.Empty(t, fake.notifications())
+	})
+
+	t.Run("repeats still firing alerts", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		fake := &fakeNotifier{}
+		events := make(chan models.Alert)
+		NewDispatcher([]Notifier{fake}, 10*time.Millisecond, 100*time.Millisecond).Run(ctx, events)
+
+		events <- alert("/var", models.AlertFiring)
+		require.Eventually(t, func() bool { return len(fake.notifications()) >= 3 }, 2*time.Second, 10*time.Millisecond)
+		for _, n := range fake.notifications() {
+			require.Equal(t, models.AlertFiring, n.State)
+			require.Len(t, n.Alerts, 1)
+		}
+
+		events <- alert("/var", models.AlertResolved)
+		require.Eventually(t, func() bool {
+			sent := fake.notifications()
+			return sent[len(sent)-1].State == models.AlertResolved
+		}, time.Second, 10*time.Millisecond)
+		count := len(fake.notifications())
+		time.Sleep(300 * time.Millisecond)
+		require.Len(t, fake.notifications(), count, "resolved alert must not repeat")
+	})
+}
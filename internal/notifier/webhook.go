@@ -0,0 +1,97 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+)
+
+const (
+	defaultWebhookTimeout = 5 * time.Second
+	defaultWebhookBackoff = time.Second
+)
+
+var ErrWebhookStatus = errors.New("unexpected webhook response status")
+
+// Webhook отправляет уведомление POST-запросом с JSON-телом.
+// Ошибки сети и ответы 5xx/429 повторяются с экспоненциальной задержкой.
+type Webhook struct {
+	url        string
+	client     *http.Client
+	maxRetries int
+	backoff    time.Duration
+}
+
+func NewWebhook(cfg config.Webhook) *Webhook {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	backoff := cfg.Backoff
+	if backoff <= 0 {
+		backoff = defaultWebhookBackoff
+	}
+	return &Webhook{
+		url:        cfg.URL,
+		client:     &http.Client{Timeout: timeout},
+		maxRetries: max(cfg.MaxRetries, 0),
+		backoff:    backoff,
+	}
+}
+
+func (w *Webhook) Name() string {
+	return "webhook " + w.url
+}
+
+func (w *Webhook) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	backoff := w.backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := w.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= w.maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (w *Webhook) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("%w: %s", ErrWebhookStatus, resp.Status)
+}
@@ -0,0 +1,54 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+)
+
+// detailTables — служебные таблицы ответа, в CSV они не попадают.
+var detailTables = map[string]bool{"coverage": true, "errors": true}
+
+var csvHeader = []string{"timestamp", "stat", "key", "field", "value"}
+
+// csvWriter выводит статистику в длинном формате: одна строка на значение.
+// Заголовок не зависит от типов статистики, поэтому в одном выводе могут идти
+// любые типы. key — значения ключевых колонок строки через пробел, например
+// устройство или файловая система и точка монтирования; у статистики из одной
+// строки он пустой.
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Write(resp *pb.StatsResponse) error {
+	if !c.header {
+		if err := c.w.Write(csvHeader); err != nil {
+			return err
+		}
+		c.header = true
+	}
+
+	ts := timestamp(resp.GetTimestamp())
+	for _, tbl := range tables(resp) {
+		if detailTables[tbl.name] {
+			continue
+		}
+		for _, row := range tbl.rows {
+			key := strings.Join(row[:tbl.keys], " ")
+			for i := tbl.keys; i < len(row); i++ {
+				if err := c.w.Write([]string{ts, tbl.name, key, tbl.header[i], row[i]}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,78 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSV(t *testing.T) {
+	t.Run("single stat type", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := New("csv", &buf)
+		require.NoError(t, err)
+
+		first := &pb.StatsResponse{
+			Timestamp: testTimestamp,
+			DisksLoad: testResponse().DisksLoad,
+			Coverage:  []*pb.StatCoverage{{StatType: pb.StatType_DISKS_LOAD, SampleCount: 2, Coverage: 1}},
+		}
+		require.NoError(t, w.Write(first))
+		next := &pb.StatsResponse{
+			Timestamp: testTimestamp + 1,
+			DisksLoad: &pb.DisksLoad{DisksLoad: []*pb.DiskLoad{{FsName: "sda", Tps: 2, Kps: 50}}},
+		}
+		require.NoError(t, w.Write(next))
+		// Пустой ответ ничего не добавляет.
+		require.NoError(t, w.Write(&pb.StatsResponse{Timestamp: testTimestamp + 2}))
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, [][]string{
+			{"timestamp", "stat", "key", "field", "value"},
+			{"2025-01-01T00:00:00Z", "disks_load", "sda", "tps", "1.5"},
+			{"2025-01-01T00:00:00Z", "disks_load", "sda", "kps", "100"},
+			{"2025-01-01T00:00:00Z", "disks_load", "nvme0n1", "tps", "12.25"},
+			{"2025-01-01T00:00:00Z", "disks_load", "nvme0n1", "kps", "2048"},
+			{"2025-01-01T00:00:01Z", "disks_load", "sda", "tps", "2"},
+			{"2025-01-01T00:00:01Z", "disks_load", "sda", "kps", "50"},
+		}, records)
+	})
+
+	t.Run("several stat types", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := New("csv", &buf)
+		require.NoError(t, err)
+
+		require.NoError(t, w.Write(&pb.StatsResponse{
+			Timestamp: testTimestamp,
+			CpuStats:  &pb.CPUStat{User: 1, System: 2, Idle: 97},
+			DiskStats: &pb.DiskStats{DiskStats: []*pb.DiskStat{{
+				Filesystem: "/dev/sda1",
+				MountPoint: "/",
+				Usage:      &pb.DiskUsage{Used: 2, Available: 6, UsagePercent: 25},
+				Inodes:     &pb.InodeUsage{Used: 10, Available: 90, UsagePercent: 10},
+			}}},
+		}))
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, [][]string{
+			{"timestamp", "stat", "key", "field", "value"},
+			{"2025-01-01T00:00:00Z", "cpu_stats", "", "user", "1"},
+			{"2025-01-01T00:00:00Z", "cpu_stats", "", "system", "2"},
+			{"2025-01-01T00:00:00Z", "cpu_stats", "", "idle", "97"},
+			{"2025-01-01T00:00:00Z", "disk_usage", "/dev/sda1 /", "used_bytes", "2048"},
+			{"2025-01-01T00:00:00Z", "disk_usage", "/dev/sda1 /", "available_bytes", "6144"},
+			{"2025-01-01T00:00:00Z", "disk_usage", "/dev/sda1 /", "usage_percent", "25"},
+			{"2025-01-01T00:00:00Z", "disk_usage", "/dev/sda1 /", "growth_bytes_per_second", "0"},
+			{"2025-01-01T00:00:00Z", "disk_usage", "/dev/sda1 /", "seconds_to_full", "0"},
+			{"2025-01-01T00:00:00Z", "disk_usage", "/dev/sda1 /", "inodes_used", "10"},
+			{"2025-01-01T00:00:00Z", "disk_usage", "/dev/sda1 /", "inodes_available", "90"},
+			{"2025-01-01T00:00:00Z", "disk_usage", "/dev/sda1 /", "inodes_usage_percent", "10"},
+		}, records)
+	})
+}
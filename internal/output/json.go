@@ -0,0 +1,29 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// jsonWriter выводит ответы через protojson: с отступами или по одному на строку.
+// Нулевые значения выводятся, иначе пропадали бы, например, LOAD_AVERAGE и нулевые счётчики.
+type jsonWriter struct {
+	w         io.Writer
+	multiline bool
+}
+
+func (j *jsonWriter) Write(resp *pb.StatsResponse) error {
+	opts := protojson.MarshalOptions{Multiline: j.multiline, EmitDefaultValues: true}
+	if j.multiline {
+		opts.Indent = "  "
+	}
+	data, err := opts.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+	_, err = fmt.Fprintf(j.w, "%s\n", data)
+	return err
+}
@@ -0,0 +1,37 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+)
+
+var ErrUnknownFormat = errors.New("unknown output format")
+
+// Formats — поддерживаемые форматы вывода.
+var Formats = []string{"table", "json", "jsonl", "csv", "prom"}
+
+// Writer выводит ответы сервера в выбранном формате по мере их получения.
+type Writer interface {
+	Write(resp *pb.StatsResponse) error
+}
+
+func New(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case "table":
+		return &tableWriter{w: w}, nil
+	case "json":
+		return &jsonWriter{w: w, multiline: true}, nil
+	case "jsonl":
+		return &jsonWriter{w: w}, nil
+	case "csv":
+		return newCSVWriter(w), nil
+	case "prom":
+		return &promWriter{w: w}, nil
+	default:
+		return nil, fmt.Errorf("%w %q, expected one of %s", ErrUnknownFormat, format, strings.Join(Formats, ", "))
+	}
+}
@@ -0,0 +1,74 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// 2025-01-01T00:00:00Z
+const testTimestamp = 1735689600
+
+func testResponse() *pb.StatsResponse {
+	return &pb.StatsResponse{
+		Timestamp:   testTimestamp,
+		Aggregation: pb.Aggregation_MEAN,
+		LoadAverage: &pb.LoadAverage{Load1Min: 0.5, Load5Min: 0.25, Load15Min: 0.1, RunnableTasks: 2, TotalTasks: 300},
+		DisksLoad: &pb.DisksLoad{DisksLoad: []*pb.DiskLoad{
+			{FsName: "sda", Tps: 1.5, Kps: 100},
+			{FsName: "nvme0n1", Tps: 12.25, Kps: 2048},
+		}},
+		DiskStats: &pb.DiskStats{DiskStats: []*pb.DiskStat{{
+			Filesystem: "/dev/sda1",
+			MountPoint: "/",
+			Usage:      &pb.DiskUsage{Used: 100, Available: 300, UsagePercent: 25},
+			Inodes:     &pb.InodeUsage{Used: 10, Available: 90, UsagePercent: 10},
+		}}},
+		Coverage: []*pb.StatCoverage{{StatType: pb.StatType_LOAD_AVERAGE, SampleCount: 2, Coverage: 1}},
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, format := range Formats {
+		w, err := New(format, &bytes.Buffer{})
+		require.NoError(t, err)
+		require.NotNil(t, w)
+	}
+
+	_, err := New("xml", &bytes.Buffer{})
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestJSON(t *testing.T) {
+	for _, format := range []string{"json", "jsonl"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := New(format, &buf)
+			require.NoError(t, err)
+			require.NoError(t, w.Write(testResponse()))
+			require.NoError(t, w.Write(testResponse()))
+
+			lines := strings.Count(buf.String(), "\n")
+			if format == "jsonl" {
+				require.Equal(t, 2, lines)
+			} else {
+				require.Greater(t, lines, 2)
+			}
+
+			decoder := json.NewDecoder(&buf)
+			for range 2 {
+				var document json.RawMessage
+				require.NoError(t, decoder.Decode(&document))
+				var resp pb.StatsResponse
+				require.NoError(t, protojson.Unmarshal(document, &resp))
+				require.True(t, proto.Equal(testResponse(), &resp))
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+)
+
+const promPrefix = "sysmon_"
+
+var promEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// promWriter выводит каждый ответ блоком в текстовом формате Prometheus
+// с меткой времени ответа у каждого значения. Блоки разделены пустой строкой.
+type promWriter struct {
+	w io.Writer
+}
+
+type promFamily struct {
+	name    string
+	kind    string
+	samples []string
+}
+
+// promBlock собирает значения по семействам: формат требует, чтобы значения
+// одной метрики шли подряд после её описания.
+type promBlock struct {
+	families []*promFamily
+	index    map[string]*promFamily
+	suffix   string
+}
+
+func (b *promBlock) add(name, kind string, value float64, labels ...string) {
+	family, ok := b.index[name]
+	if !ok {
+		family = &promFamily{name: name, kind: kind}
+		b.families = append(b.families, family)
+		b.index[name] = family
+	}
+
+	var sample strings.Builder
+	sample.WriteString(promPrefix + name)
+	if len(labels) > 0 {
+		sample.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				sample.WriteByte(',')
+			}
+			fmt.Fprintf(&sample, "%s=\"%s\"", labels[i], promEscaper.Replace(labels[i+1]))
+		}
+		sample.WriteByte('}')
+	}
+	sample.WriteString(" " + float(value) + b.suffix)
+	family.samples = append(family.samples, sample.String())
+}
+
+func (b *promBlock) gauge(name string, value float64, labels ...string) {
+	b.add(name, "gauge", value, labels...)
+}
+
+func (b *promBlock) counter(name string, value float64, labels ...string) {
+	b.add(name, "counter", value, labels...)
+}
+
+func (p *promWriter) Write(resp *pb.StatsResponse) error {
+	b := &promBlock{
+		index:  make(map[string]*promFamily),
+		suffix: " " + strconv.FormatInt(resp.GetTimestamp()*1000, 10),
+	}
+
+	if la := resp.GetLoadAverage(); la != nil {
+		b.gauge("load_average", la.GetLoad1Min(), "period", "1m")
+		b.gauge("load_average", la.GetLoad5Min(), "period", "5m")
+		b.gauge("load_average", la.GetLoad15Min(), "period", "15m")
+		b.gauge("tasks_runnable", float64(la.GetRunnableTasks()))
+		b.gauge("tasks_total", float64(la.GetTotalTasks()))
+		b.gauge("last_pid", float64(la.GetLastPid()))
+	}
+
+	if cpu := resp.GetCpuStats(); cpu != nil {
+		b.gauge("cpu_percent", cpu.GetUser(), "mode", "user")
+		b.gauge("cpu_percent", cpu.GetSystem(), "mode", "system")
+		b.gauge("cpu_percent", cpu.GetIdle(), "mode", "idle")
+	}
+
+	for _, disk := range resp.GetDisksLoad().GetDisksLoad() {
+		b.gauge("disk_transfers_per_second", disk.GetTps(), "device", disk.GetFsName())
+		b.gauge("disk_kilobytes_per_second", disk.GetKps(), "device", disk.GetFsName())
+	}
+
+	for _, disk := range resp.GetDiskStats().GetDiskStats() {
+		labels := []string{"filesystem", disk.GetFilesystem(), "mount_point", disk.GetMountPoint()}
+		// Сервер отдаёт место в килобайтах, Prometheus принято отдавать в байтах.
+		b.gauge("filesystem_used_bytes", float64(disk.GetUsage().GetUsed())*1024, labels...)
+		b.gauge("filesystem_available_bytes", float64(disk.GetUsage().GetAvailable())*1024, labels...)
+		b.gauge("filesystem_usage_percent", disk.GetUsage().GetUsagePercent(), labels...)
+		b.gauge("filesystem_seconds_to_full", disk.GetUsage().GetSecondsToFull(), labels...)
+		b.gauge("filesystem_inodes_used", float64(disk.GetInodes().GetUsed()), labels...)
+		b.gauge("filesystem_inodes_available", float64(disk.GetInodes().GetAvailable()), labels...)
+		b.gauge("filesystem_inodes_usage_percent", disk.GetInodes().GetUsagePercent(), labels...)
+	}
+
+	for _, metric := range resp.GetCustomMetrics() {
+		b.gauge("custom", metric.GetValue(), "plugin", metric.GetPlugin(), "name", metric.GetName())
+	}
+
+	// Процесс может входить в оба списка лидеров, значения выводятся один раз.
+	seen := make(map[int32]bool)
+	for _, process := range append(resp.GetTopProcesses().GetByCpu(), resp.GetTopProcesses().GetByMemory()...) {
+		if seen[process.GetPid()] {
+			continue
+		}
+		seen[process.GetPid()] = true
+		labels := []string{
+			"pid", strconv.Itoa(int(process.GetPid())), "command", process.GetCommand(), "user", process.GetUser(),
+		}
+		b.gauge("process_cpu_percent", process.GetCpuPercent(), labels...)
+		b.gauge("process_rss_bytes", float64(process.GetRssBytes()), labels...)
+		b.gauge("process_threads", float64(process.GetThreads()), labels...)
+	}
+
+	for _, s := range pressureStalls(resp.GetPressure()) {
+		labels := []string{"resource", s.resource, "kind", s.kind}
+		b.gauge("pressure_avg10", s.stall.GetAvg10(), labels...)
+		b.gauge("pressure_avg60", s.stall.GetAvg60(), labels...)
+		b.gauge("pressure_avg300", s.stall.GetAvg300(), labels...)
+		b.gauge("pressure_stall_microseconds", float64(s.stall.GetTotalDeltaUs()), labels...)
+	}
+
+	for _, cgroup := range resp.GetCgroups().GetCgroups() {
+		b.gauge("cgroup_cpu_percent", cgroup.GetCpuPercent(), "path", cgroup.GetPath())
+		b.gauge("cgroup_memory_current_bytes", float64(cgroup.GetMemoryCurrentBytes()), "path", cgroup.GetPath())
+		b.gauge("cgroup_memory_max_bytes", float64(cgroup.GetMemoryMaxBytes()), "path", cgroup.GetPath())
+		b.gauge("cgroup_io_read_bytes", float64(cgroup.GetIoReadBytes()), "path", cgroup.GetPath())
+		b.gauge("cgroup_io_write_bytes", float64(cgroup.GetIoWriteBytes()), "path", cgroup.GetPath())
+	}
+
+	if self := resp.GetDaemonSelf(); self != nil {
+		b.gauge("daemon_goroutines", float64(self.GetGoroutines()))
+		b.gauge("daemon_heap_bytes", float64(self.GetHeapBytes()))
+		b.counter("daemon_gc_cycles_total", float64(self.GetGcCycles()))
+		b.counter("daemon_gc_pause_seconds_total", self.GetGcPauseSeconds())
+		b.gauge("daemon_active_streams", float64(self.GetActiveStreams()))
+		b.counter("daemon_cleaner_runs_total", float64(self.GetCleanerRuns()))
+		for _, c := range self.GetCollectors() {
+			labels := []string{"stat_type", c.GetStatType().String(), "name", c.GetName()}
+			b.gauge("daemon_collector_last_duration_seconds", c.GetLastDurationSeconds(), labels...)
+			b.counter("daemon_collector_runs_total", float64(c.GetRuns()), labels...)
+			b.counter("daemon_collector_errors_total", float64(c.GetErrors()), labels...)
+			b.gauge("daemon_collector_stored_samples", float64(c.GetStoredSamples()), labels...)
+		}
+	}
+
+	for _, coverage := range resp.GetCoverage() {
+		b.gauge("window_samples", float64(coverage.GetSampleCount()), "stat_type", coverage.GetStatType().String())
+		b.gauge("window_coverage_ratio", coverage.GetCoverage(), "stat_type", coverage.GetStatType().String())
+	}
+
+	for _, collectErr := range resp.GetErrors() {
+		b.gauge("collection_consecutive_failures", float64(collectErr.GetConsecutiveFailures()),
+			"stat_type", collectErr.GetStatType().String())
+	}
+
+	var out strings.Builder
+	for _, family := range b.families {
+		fmt.Fprintf(&out, "# TYPE %s%s %s\n", promPrefix, family.name, family.kind)
+		for _, sample := range family.samples {
+			out.WriteString(sample + "\n")
+		}
+	}
+	out.WriteString("\n")
+	_, err := io.WriteString(p.w, out.String())
+	return err
+}
@@ -0,0 +1,34 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProm(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := New("prom", &buf)
+	require.NoError(t, err)
+
+	resp := testResponse()
+	resp.CustomMetrics = []*pb.CustomMetric{{Plugin: `q"ueue`, Name: "depth\\x", Value: 3}}
+	resp.Pressure = &pb.Pressure{Supported: true, Io: &pb.PressureResource{Full: &pb.PressureStall{Avg10: 1.5}}}
+	require.NoError(t, w.Write(resp))
+
+	out := buf.String()
+	require.True(t, strings.HasPrefix(out,
+		"# TYPE sysmon_load_average gauge\n"+
+			`sysmon_load_average{period="1m"} 0.5 1735689600000`+"\n"+
+			`sysmon_load_average{period="5m"} 0.25 1735689600000`+"\n"), out)
+	require.Contains(t, out, `sysmon_disk_transfers_per_second{device="nvme0n1"} 12.25 1735689600000`)
+	require.Contains(t, out, `sysmon_filesystem_used_bytes{filesystem="/dev/sda1",mount_point="/"} 102400 1735689600000`)
+	require.Contains(t, out, `sysmon_custom{plugin="q\"ueue",name="depth\\x"} 3 1735689600000`)
+	require.Contains(t, out, `sysmon_pressure_avg10{resource="io",kind="full"} 1.5 1735689600000`)
+	require.Contains(t, out, `sysmon_window_coverage_ratio{stat_type="LOAD_AVERAGE"} 1 1735689600000`)
+	require.Equal(t, 1, strings.Count(out, "# TYPE sysmon_pressure_avg10 gauge\n"))
+	require.True(t, strings.HasSuffix(out, "\n\n"))
+}
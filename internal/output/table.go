@@ -0,0 +1,46 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+)
+
+// tableWriter выводит каждый ответ блоком таблиц, выровненных по колонкам.
+type tableWriter struct {
+	w io.Writer
+}
+
+func (t *tableWriter) Write(resp *pb.StatsResponse) error {
+	title := fmt.Sprintf("%s  aggregation=%s", timestamp(resp.GetTimestamp()), resp.GetAggregation())
+	if resp.GetBackfill() {
+		title += "  backfill"
+	}
+	if disabled := resp.GetDisabledStatTypes(); len(disabled) > 0 {
+		title += fmt.Sprintf("  disabled=%v", disabled)
+	}
+	if _, err := fmt.Fprintf(t.w, "=== %s\n", title); err != nil {
+		return err
+	}
+
+	for _, tbl := range tables(resp) {
+		tw := tabwriter.NewWriter(t.w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.ReplaceAll(tbl.name, "_", " ")))
+		header := make([]string, len(tbl.header))
+		for i, column := range tbl.header {
+			header[i] = strings.ToUpper(column)
+		}
+		fmt.Fprintln(tw, "  "+strings.Join(header, "\t"))
+		for _, row := range tbl.rows {
+			fmt.Fprintln(tw, "  "+strings.Join(row, "\t"))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(t.w)
+	return err
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := New("table", &buf)
+	require.NoError(t, err)
+
+	resp := testResponse()
+	resp.Backfill = true
+	require.NoError(t, w.Write(resp))
+
+	out := buf.String()
+	require.True(t, strings.HasPrefix(out, "=== 2025-01-01T00:00:00Z  aggregation=MEAN  backfill\n"), out)
+	require.Contains(t, out, "DISKS LOAD\n"+
+		"  DEVICE   TPS    KPS\n"+
+		"  sda      1.5    100\n"+
+		"  nvme0n1  12.25  2048\n")
+	require.Contains(t, out, "DISK USAGE\n  FILESYSTEM  MOUNT_POINT  USED")
+	require.Contains(t, out, "  /dev/sda1   /            102400")
+	require.NotContains(t, out, "CPU STATS")
+	require.True(t, strings.HasSuffix(out, "\n\n"))
+}
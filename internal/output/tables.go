@@ -0,0 +1,191 @@
+package output
+
+import (
+	"strconv"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+)
+
+// table — строки одного типа статистики. Набор и порядок колонок не зависят
+// от содержимого ответа. Первые keys колонок определяют строку, например
+// устройство или процесс.
+type table struct {
+	name   string
+	header []string
+	keys   int
+	rows   [][]string
+}
+
+// tables раскладывает ответ в таблицы в порядке полей ответа. Таблицы без строк опускаются.
+func tables(resp *pb.StatsResponse) []table {
+	var result []table
+	add := func(name string, header []string, keys int, rows ...[]string) {
+		if len(rows) > 0 {
+			result = append(result, table{name: name, header: header, keys: keys, rows: rows})
+		}
+	}
+
+	if la := resp.GetLoadAverage(); la != nil {
+		add("load_average",
+			[]string{"load1min", "load5min", "load15min", "runnable_tasks", "total_tasks", "last_pid"}, 0,
+			[]string{
+				float(la.GetLoad1Min()), float(la.GetLoad5Min()), float(la.GetLoad15Min()),
+				integer(la.GetRunnableTasks()), integer(la.GetTotalTasks()), integer(la.GetLastPid()),
+			})
+	}
+
+	if cpu := resp.GetCpuStats(); cpu != nil {
+		add("cpu_stats", []string{"user", "system", "idle"}, 0,
+			[]string{float(cpu.GetUser()), float(cpu.GetSystem()), float(cpu.GetIdle())})
+	}
+
+	var rows [][]string
+	for _, disk := range resp.GetDisksLoad().GetDisksLoad() {
+		rows = append(rows, []string{disk.GetFsName(), float(disk.GetTps()), float(disk.GetKps())})
+	}
+	add("disks_load", []string{"device", "tps", "kps"}, 1, rows...)
+
+	rows = nil
+	for _, disk := range resp.GetDiskStats().GetDiskStats() {
+		usage, inodes := disk.GetUsage(), disk.GetInodes()
+		rows = append(rows, []string{
+			disk.GetFilesystem(), disk.GetMountPoint(),
+			unsigned(usage.GetUsed() * 1024), unsigned(usage.GetAvailable() * 1024), float(usage.GetUsagePercent()),
+			float(usage.GetGrowthRate()), float(usage.GetSecondsToFull()),
+			unsigned(inodes.GetUsed()), unsigned(inodes.GetAvailable()), float(inodes.GetUsagePercent()),
+		})
+	}
+	add("disk_usage", []string{
+		"filesystem", "mount_point", "used_bytes", "available_bytes", "usage_percent", "growth_bytes_per_second",
+		"seconds_to_full",
+		"inodes_used", "inodes_available", "inodes_usage_percent",
+	}, 2, rows...)
+
+	rows = nil
+	for _, metric := range resp.GetCustomMetrics() {
+		rows = append(rows, []string{metric.GetPlugin(), metric.GetName(), float(metric.GetValue())})
+	}
+	add("custom", []string{"plugin", "name", "value"}, 2, rows...)
+
+	rows = nil
+	for _, top := range []struct {
+		by        string
+		processes []*pb.Process
+	}{
+		{"cpu", resp.GetTopProcesses().GetByCpu()},
+		{"memory", resp.GetTopProcesses().GetByMemory()},
+	} {
+		for _, process := range top.processes {
+			rows = append(rows, []string{
+				top.by, integer(process.GetPid()), process.GetCommand(), process.GetUser(),
+				integer(process.GetThreads()), float(process.GetCpuPercent()), unsigned(process.GetRssBytes()),
+			})
+		}
+	}
+	add("top_processes", []string{"by", "pid", "command", "user", "threads", "cpu_percent", "rss_bytes"}, 2, rows...)
+
+	rows = nil
+	for _, s := range pressureStalls(resp.GetPressure()) {
+		rows = append(rows, []string{
+			s.resource, s.kind, float(s.stall.GetAvg10()), float(s.stall.GetAvg60()), float(s.stall.GetAvg300()),
+			unsigned(s.stall.GetTotalDeltaUs()),
+		})
+	}
+	add("pressure", []string{"resource", "kind", "avg10", "avg60", "avg300", "total_delta_us"}, 2, rows...)
+
+	rows = nil
+	for _, cgroup := range resp.GetCgroups().GetCgroups() {
+		rows = append(rows, []string{
+			cgroup.GetPath(), float(cgroup.GetCpuPercent()),
+			unsigned(cgroup.GetMemoryCurrentBytes()), unsigned(cgroup.GetMemoryMaxBytes()),
+			unsigned(cgroup.GetIoReadBytes()), unsigned(cgroup.GetIoWriteBytes()),
+		})
+	}
+	add("cgroups", []string{
+		"path", "cpu_percent", "memory_current_bytes", "memory_max_bytes", "io_read_bytes", "io_write_bytes",
+	}, 1, rows...)
+
+	if self := resp.GetDaemonSelf(); self != nil {
+		add("daemon_self",
+			[]string{"goroutines", "heap_bytes", "gc_cycles", "gc_pause_seconds", "active_streams", "cleaner_runs"}, 0,
+			[]string{
+				integer(self.GetGoroutines()), unsigned(self.GetHeapBytes()), unsigned(self.GetGcCycles()),
+				float(self.GetGcPauseSeconds()), integer(self.GetActiveStreams()), integer(self.GetCleanerRuns()),
+			})
+
+		rows = nil
+		for _, c := range self.GetCollectors() {
+			rows = append(rows, []string{
+				c.GetStatType().String(), c.GetName(), float(c.GetLastDurationSeconds()),
+				integer(c.GetRuns()), integer(c.GetErrors()), integer(c.GetStoredSamples()),
+			})
+		}
+		add("daemon_collectors",
+			[]string{"stat_type", "name", "last_duration_seconds", "runs", "errors", "stored_samples"}, 2, rows...)
+	}
+
+	rows = nil
+	for _, coverage := range resp.GetCoverage() {
+		rows = append(rows, []string{
+			coverage.GetStatType().String(), integer(coverage.GetSampleCount()), float(coverage.GetCoverage()),
+		})
+	}
+	add("coverage", []string{"stat_type", "sample_count", "coverage"}, 1, rows...)
+
+	rows = nil
+	for _, collectErr := range resp.GetErrors() {
+		rows = append(rows, []string{
+			collectErr.GetStatType().String(), collectErr.GetMessage(),
+			timestamp(collectErr.GetSince()), integer(collectErr.GetConsecutiveFailures()),
+		})
+	}
+	add("errors", []string{"stat_type", "message", "since", "consecutive_failures"}, 1, rows...)
+
+	return result
+}
+
+type pressureStall struct {
+	resource string
+	kind     string
+	stall    *pb.PressureStall
+}
+
+// pressureStalls перечисляет показатели PSI в фиксированном порядке ресурсов.
+// Если ядро не поддерживает PSI, показателей нет.
+func pressureStalls(pressure *pb.Pressure) []pressureStall {
+	if !pressure.GetSupported() {
+		return nil
+	}
+	var result []pressureStall
+	for _, resource := range []struct {
+		name     string
+		resource *pb.PressureResource
+	}{
+		{"cpu", pressure.GetCpu()},
+		{"memory", pressure.GetMemory()},
+		{"io", pressure.GetIo()},
+	} {
+		result = append(result,
+			pressureStall{resource.name, "some", resource.resource.GetSome()},
+			pressureStall{resource.name, "full", resource.resource.GetFull()})
+	}
+	return result
+}
+
+func float(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func integer[T int32 | int64](v T) string {
+	return strconv.FormatInt(int64(v), 10)
+}
+
+func unsigned(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
+
+// timestamp форматирует время ответа в UTC, чтобы вывод не зависел от часового пояса.
+func timestamp(unix int64) string {
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,56 @@
+// Package procfs даёт сборщикам статистики доступ к procfs и sysfs через fs.FS.
+// Корни настраиваются, поэтому из контейнера можно читать смонтированные /host/proc
+// и /host/sys, а в тестах подставлять fstest.MapFS.
+package procfs
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	DefaultProcRoot = "/proc"
+	DefaultSysRoot  = "/sys"
+)
+
+// FS — procfs и sysfs, пути в них задаются без ведущего "/", например "loadavg".
+type FS struct {
+	Proc fs.FS
+	Sys  fs.FS
+	// ProcRoot — каталог procfs, пустой у файловых систем, подставленных в тестах.
+	ProcRoot string
+}
+
+// DefaultProc сообщает, что procfs — это /proc текущей системы, а не
+// настроенный каталог. Только тогда его отсутствие можно восполнить системными вызовами.
+func (f FS) DefaultProc() bool {
+	return f.ProcRoot == DefaultProcRoot
+}
+
+// New открывает procfs и sysfs в каталогах procRoot и sysRoot.
+// Пустой путь заменяется корнем по умолчанию.
+func New(procRoot, sysRoot string) FS {
+	if procRoot == "" {
+		procRoot = DefaultProcRoot
+	}
+	if sysRoot == "" {
+		sysRoot = DefaultSysRoot
+	}
+	procRoot = filepath.Clean(procRoot)
+	return FS{Proc: os.DirFS(procRoot), Sys: os.DirFS(sysRoot), ProcRoot: procRoot}
+}
+
+// HostRoot возвращает корень файловой системы машины, чей procfs смонтирован
+// в procRoot: "/host" для "/host/proc". Для procfs текущей системы — "/".
+func HostRoot(procRoot string) string {
+	if procRoot == "" {
+		return "/"
+	}
+	return filepath.Dir(filepath.Clean(procRoot))
+}
+
+// Default возвращает procfs и sysfs текущей системы.
+func Default() FS {
+	return New("", "")
+}
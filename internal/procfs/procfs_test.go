@@ -0,0 +1,41 @@
+package procfs
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("custom roots", func(t *testing.T) {
+		proc := t.TempDir()
+		sys := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(proc, "loadavg"), []byte("proc"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(sys, "loadavg"), []byte("sys"), 0o600))
+
+		fsys := New(proc, sys)
+		data, err := fs.ReadFile(fsys.Proc, "loadavg")
+		require.NoError(t, err)
+		require.Equal(t, "proc", string(data))
+		data, err = fs.ReadFile(fsys.Sys, "loadavg")
+		require.NoError(t, err)
+		require.Equal(t, "sys", string(data))
+	})
+
+	t.Run("default roots", func(t *testing.T) {
+		require.Equal(t, os.DirFS(DefaultProcRoot), New("", "").Proc)
+		require.Equal(t, os.DirFS(DefaultSysRoot), Default().Sys)
+		require.True(t, Default().DefaultProc())
+		require.True(t, New("/proc/", "").DefaultProc())
+		require.False(t, New("/host/proc", "").DefaultProc())
+	})
+}
+
+func TestHostRoot(t *testing.T) {
+	require.Equal(t, "/", HostRoot(""))
+	require.Equal(t, "/", HostRoot(DefaultProcRoot))
+	require.Equal(t, "/host", HostRoot("/host/proc/"))
+}
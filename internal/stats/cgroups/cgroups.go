@@ -0,0 +1,21 @@
+package cgroups
+
+import (
+	"context"
+	"io/fs"
+
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+)
+
+// cgroupDir — точка монтирования cgroupfs внутри sysfs.
+const cgroupDir = "fs/cgroup"
+
+// GetStats читает счётчики cgroup v2, путь которых начинается с prefix.
+func GetStats(ctx context.Context, fsys procfs.FS, prefix string) (*models.Cgroups, error) {
+	root, err := fs.Sub(fsys.Sys, cgroupDir)
+	if err != nil {
+		return nil, err
+	}
+	return readCgroups(ctx, root, prefix)
+}
@@ -0,0 +1,109 @@
+package cgroups
+
+import (
+	"context"
+	"math"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/converter"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+)
+
+// Name — имя статистики в конфигурации, включается через stats.collectors.cgroups.enabled.
+const Name = "cgroups"
+
+func init() {
+	stats.Register(collector{})
+}
+
+type collector struct{}
+
+func (collector) Name() string {
+	return Name
+}
+
+func (collector) StatType() pb.StatType {
+	return pb.StatType_CGROUPS
+}
+
+func (collector) Sample(ctx context.Context, cfg config.Stats) (any, error) {
+	stats, err := GetStats(ctx, procfs.New(cfg.Procfs, cfg.Sysfs), cfg.Cgroups.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// Aggregate считает загрузку CPU и объём ввода-вывода по приросту счётчиков
+// между первым и последним замером cgroup в окне, память агрегируется как обычно.
+func (collector) Aggregate(samples []metrics.Sample, weights []float64, agg metrics.Aggregation) any {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	type cgroupValues struct {
+		first   metrics.Sample
+		last    metrics.Sample
+		memory  []float64
+		weights []float64
+	}
+
+	order := make([]string, 0)
+	cgroups := make(map[string]*cgroupValues)
+
+	for i, s := range samples {
+		for _, cgroup := range s.Value.(*models.Cgroups).Cgroups {
+			sample := metrics.Sample{Timestamp: s.Timestamp, Value: cgroup}
+			values, ok := cgroups[cgroup.Path]
+			if !ok {
+				values = &cgroupValues{first: sample}
+				cgroups[cgroup.Path] = values
+				order = append(order, cgroup.Path)
+			}
+			values.last = sample
+			values.memory = append(values.memory, float64(cgroup.MemoryCurrent))
+			if i < len(weights) {
+				values.weights = append(values.weights, weights[i])
+			}
+		}
+	}
+
+	result := make([]models.Cgroup, 0, len(cgroups))
+	for _, path := range order {
+		values := cgroups[path]
+		first := values.first.Value.(models.Cgroup)
+		last := values.last.Value.(models.Cgroup)
+
+		var cpuPercent float64
+		if elapsed := values.last.Timestamp.Sub(values.first.Timestamp); elapsed > 0 {
+			cpuPercent = float64(delta(first.CPUUsageUsec, last.CPUUsageUsec)) / float64(elapsed.Microseconds()) * 100
+		}
+
+		result = append(result, models.Cgroup{
+			Path:          path,
+			CPUPercent:    metrics.Round(cpuPercent),
+			MemoryCurrent: uint64(math.Round(metrics.AggregateValues(values.memory, values.weights, agg))),
+			MemoryMax:     last.MemoryMax,
+			IOReadBytes:   delta(first.IOReadBytes, last.IOReadBytes),
+			IOWriteBytes:  delta(first.IOWriteBytes, last.IOWriteBytes),
+		})
+	}
+
+	return &models.Cgroups{Cgroups: result}
+}
+
+// delta возвращает прирост счётчика; счётчик пересозданной cgroup начинается заново.
+func delta(first, last uint64) uint64 {
+	if last < first {
+		return 0
+	}
+	return last - first
+}
+
+func (collector) ToProto(value any, _ stats.Options, response *pb.StatsResponse) {
+	response.Cgroups = converter.CgroupsToProto(value.(*models.Cgroups))
+}
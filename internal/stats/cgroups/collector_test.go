@@ -0,0 +1,43 @@
+package cgroups
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorAggregate(t *testing.T) {
+	t.Run("empty window", func(t *testing.T) {
+		require.Nil(t, collector{}.Aggregate(nil, nil, metrics.AggregationMean))
+	})
+
+	t.Run("counter deltas", func(t *testing.T) {
+		start := time.Now()
+		at := func(usage, memory, read uint64) *models.Cgroups {
+			return &models.Cgroups{Cgroups: []models.Cgroup{{
+				Path:          "/app.slice",
+				CPUUsageUsec:  usage,
+				MemoryCurrent: memory,
+				MemoryMax:     8192,
+				IOReadBytes:   read,
+			}}}
+		}
+		samples := []metrics.Sample{
+			{Timestamp: start, Value: at(1_000_000, 1000, 100)},
+			{Timestamp: start.Add(time.Second), Value: at(1_250_000, 2000, 150)},
+			{Timestamp: start.Add(2 * time.Second), Value: at(1_500_000, 3000, 400)},
+		}
+
+		result := collector{}.Aggregate(samples, nil, metrics.AggregationMean).(*models.Cgroups)
+		require.Equal(t, []models.Cgroup{{
+			Path:          "/app.slice",
+			CPUPercent:    25,
+			MemoryCurrent: 2000,
+			MemoryMax:     8192,
+			IOReadBytes:   300,
+		}}, result.Cgroups)
+	})
+}
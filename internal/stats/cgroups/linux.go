@@ -0,0 +1,168 @@
+//go:build linux
+
+package cgroups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+)
+
+var errInvalidCgroup = errors.New("invalid cgroup data")
+
+// readCgroups обходит cgroupfs и читает cgroup, в которых есть cpu.stat.
+// Каталоги, не ведущие к prefix, не обходятся.
+func readCgroups(ctx context.Context, root fs.FS, prefix string) (*models.Cgroups, error) {
+	if _, err := fs.Stat(root, "cgroup.controllers"); err != nil {
+		return nil, fmt.Errorf("cgroup v2 is not mounted: %w", err)
+	}
+
+	result := &models.Cgroups{}
+	err := fs.WalkDir(root, ".", func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// cgroup могла быть удалена во время обхода.
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			// Недоступные каталоги (например, чужие делегированные поддеревья) пропускаем.
+			if errors.Is(err, fs.ErrPermission) {
+				return skipEntry(entry)
+			}
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		name := "/" + dir
+		if dir == "." {
+			name = "/"
+		}
+		if !matchPrefix(name, prefix) {
+			// Спускаемся только в предков cgroup с нужным префиксом.
+			if matchPrefix(prefix, name) {
+				return nil
+			}
+			return fs.SkipDir
+		}
+
+		cgroup, err := readCgroup(root, dir)
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		cgroup.Path = name
+		result.Cgroups = append(result.Cgroups, cgroup)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// skipEntry пропускает entry в fs.WalkDir: каталог вместе с содержимым, файл — сам по себе.
+func skipEntry(entry fs.DirEntry) error {
+	if entry != nil && entry.IsDir() {
+		return fs.SkipDir
+	}
+	return nil
+}
+
+// matchPrefix сравнивает пути по целым компонентам: "/a" подходит к "/a/b", но не к "/ab".
+func matchPrefix(name, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return prefix == "" || name == prefix || strings.HasPrefix(name, prefix+"/")
+}
+
+func readCgroup(root fs.FS, dir string) (models.Cgroup, error) {
+	var cgroup models.Cgroup
+
+	data, err := fs.ReadFile(root, path.Join(dir, "cpu.stat"))
+	if err != nil {
+		return cgroup, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if value, ok := strings.CutPrefix(line, "usage_usec "); ok {
+			if cgroup.CPUUsageUsec, err = strconv.ParseUint(strings.TrimSpace(value), 10, 64); err != nil {
+				return cgroup, fmt.Errorf("%w: cpu.stat: %w", errInvalidCgroup, err)
+			}
+		}
+	}
+
+	// В корневой cgroup нет memory.current, memory.max и io.stat.
+	if cgroup.MemoryCurrent, err = readUint(root, path.Join(dir, "memory.current")); err != nil {
+		return cgroup, err
+	}
+	// memory.max "max" — лимита нет, он передаётся как 0.
+	if cgroup.MemoryMax, err = readUint(root, path.Join(dir, "memory.max")); err != nil {
+		return cgroup, err
+	}
+
+	data, err = fs.ReadFile(root, path.Join(dir, "io.stat"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return cgroup, nil
+	}
+	if err != nil {
+		return cgroup, err
+	}
+	cgroup.IOReadBytes, cgroup.IOWriteBytes, err = parseIOStat(string(data))
+	return cgroup, err
+}
+
+// readUint читает файл с одним числом; отсутствующий файл и значение "max" дают 0.
+func readUint(root fs.FS, name string) (uint64, error) {
+	data, err := fs.ReadFile(root, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "max" {
+		return 0, nil
+	}
+	result, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %w", errInvalidCgroup, path.Base(name), err)
+	}
+	return result, nil
+}
+
+// parseIOStat суммирует по устройствам строки вида "8:0 rbytes=1 wbytes=2 rios=3 wios=4".
+func parseIOStat(data string) (read, write uint64, err error) {
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok || (key != "rbytes" && key != "wbytes") {
+				continue
+			}
+			bytes, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return 0, 0, fmt.Errorf("%w: io.stat: %w", errInvalidCgroup, err)
+			}
+			if key == "rbytes" {
+				read += bytes
+			} else {
+				write += bytes
+			}
+		}
+	}
+	return read, write, nil
+}
@@ -0,0 +1,142 @@
+//go:build linux
+
+package cgroups
+
+import (
+	"context"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCgroupFS возвращает cgroupfs с корнем и двумя сервисами.
+func fakeCgroupFS() fstest.MapFS {
+	root := fstest.MapFS{}
+	files := map[string]string{
+		"cgroup.controllers": "cpu io memory",
+		"cpu.stat":           "usage_usec 9000000\nuser_usec 6000000\nsystem_usec 3000000\n",
+
+		"system.slice/nginx.service/cpu.stat":       "usage_usec 1500\n",
+		"system.slice/nginx.service/memory.current": "4096\n",
+		"system.slice/nginx.service/memory.max":     "max\n",
+		"system.slice/nginx.service/io.stat":        "8:0 rbytes=100 wbytes=200 rios=1 wios=2\n8:16 rbytes=1 wbytes=2 rios=1 wios=1\n",
+
+		"system.slice-extra/cpu.stat": "usage_usec 1\n",
+
+		"user.slice/cpu.stat":       "usage_usec 700\n",
+		"user.slice/memory.current": "2048\n",
+		"user.slice/memory.max":     "1073741824\n",
+	}
+	for name, data := range files {
+		root[name] = &fstest.MapFile{Data: []byte(data)}
+	}
+	return root
+}
+
+func TestGetStats(t *testing.T) {
+	sys := fstest.MapFS{}
+	for name, file := range fakeCgroupFS() {
+		sys["fs/cgroup/"+name] = file
+	}
+
+	stats, err := GetStats(context.Background(), procfs.FS{Sys: sys}, "/user.slice")
+	require.NoError(t, err)
+	require.Len(t, stats.Cgroups, 1)
+	require.Equal(t, "/user.slice", stats.Cgroups[0].Path)
+}
+
+// deniedFS отвечает fs.ErrPermission на чтение каталогов dirs и файлов в них.
+type deniedFS struct {
+	fstest.MapFS
+	dirs []string
+}
+
+func (d deniedFS) denied(name string) bool {
+	for _, dir := range d.dirs {
+		if name == dir || strings.HasPrefix(name, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (d deniedFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if d.denied(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrPermission}
+	}
+	return d.MapFS.ReadDir(name)
+}
+
+func (d deniedFS) ReadFile(name string) ([]byte, error) {
+	if d.denied(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+	}
+	return d.MapFS.ReadFile(name)
+}
+
+func TestReadCgroups(t *testing.T) {
+	root := fakeCgroupFS()
+
+	t.Run("all cgroups", func(t *testing.T) {
+		stats, err := readCgroups(context.Background(), root, "")
+		require.NoError(t, err)
+		paths := make([]string, 0, len(stats.Cgroups))
+		for _, cgroup := range stats.Cgroups {
+			paths = append(paths, cgroup.Path)
+		}
+		require.Equal(t, []string{"/", "/system.slice/nginx.service", "/system.slice-extra", "/user.slice"}, paths)
+	})
+
+	t.Run("prefix filter", func(t *testing.T) {
+		stats, err := readCgroups(context.Background(), root, "/system.slice")
+		require.NoError(t, err)
+		require.Equal(t, []models.Cgroup{{
+			Path:          "/system.slice/nginx.service",
+			CPUUsageUsec:  1500,
+			MemoryCurrent: 4096,
+			IOReadBytes:   101,
+			IOWriteBytes:  202,
+		}}, stats.Cgroups)
+	})
+
+	t.Run("memory limit", func(t *testing.T) {
+		stats, err := readCgroups(context.Background(), root, "/user.slice")
+		require.NoError(t, err)
+		require.Len(t, stats.Cgroups, 1)
+		require.Equal(t, uint64(1073741824), stats.Cgroups[0].MemoryMax)
+	})
+
+	t.Run("no memory limit", func(t *testing.T) {
+		stats, err := readCgroups(context.Background(), root, "/system.slice/nginx.service")
+		require.NoError(t, err)
+		require.Len(t, stats.Cgroups, 1)
+		require.Zero(t, stats.Cgroups[0].MemoryMax)
+	})
+
+	t.Run("unreadable cgroups are skipped", func(t *testing.T) {
+		denied := deniedFS{MapFS: fakeCgroupFS(), dirs: []string{"system.slice", "user.slice"}}
+		stats, err := readCgroups(context.Background(), denied, "")
+		require.NoError(t, err)
+		paths := make([]string, 0, len(stats.Cgroups))
+		for _, cgroup := range stats.Cgroups {
+			paths = append(paths, cgroup.Path)
+		}
+		require.Equal(t, []string{"/", "/system.slice-extra"}, paths)
+	})
+
+	t.Run("cgroup v1 or missing mount", func(t *testing.T) {
+		_, err := readCgroups(context.Background(), fstest.MapFS{}, "")
+		require.ErrorContains(t, err, "cgroup v2 is not mounted")
+	})
+
+	t.Run("invalid counter", func(t *testing.T) {
+		root["user.slice/memory.current"] = &fstest.MapFile{Data: []byte("lots")}
+		_, err := readCgroups(context.Background(), root, "/user.slice")
+		require.ErrorIs(t, err, errInvalidCgroup)
+	})
+}
@@ -0,0 +1,61 @@
+package cpu
+
+import (
+	"context"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/converter"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+)
+
+func init() {
+	stats.Register(collector{})
+}
+
+type collector struct{}
+
+func (collector) Name() string {
+	return config.StatCPU
+}
+
+func (collector) StatType() pb.StatType {
+	return pb.StatType_CPU_STATS
+}
+
+func (collector) Sample(ctx context.Context, cfg config.Stats) (any, error) {
+	stats, err := GetCpuStat(ctx, procfs.New(cfg.Procfs, cfg.Sysfs))
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (collector) Aggregate(samples []metrics.Sample, weights []float64, agg metrics.Aggregation) any {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	user := make([]float64, 0, len(samples))
+	system := make([]float64, 0, len(samples))
+	idle := make([]float64, 0, len(samples))
+	for _, s := range samples {
+		stat := s.Value.(*models.CPUStat)
+		user = append(user, stat.User)
+		system = append(system, stat.System)
+		idle = append(idle, stat.Idle)
+	}
+
+	return &models.CPUStat{
+		User:   metrics.Round(metrics.AggregateValues(user, weights, agg)),
+		System: metrics.Round(metrics.AggregateValues(system, weights, agg)),
+		Idle:   metrics.Round(metrics.AggregateValues(idle, weights, agg)),
+	}
+}
+
+func (collector) ToProto(value any, _ stats.Options, response *pb.StatsResponse) {
+	response.CpuStats = converter.CPUStatToProto(value.(*models.CPUStat))
+}
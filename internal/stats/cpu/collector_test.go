@@ -0,0 +1,34 @@
+package cpu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorAggregate(t *testing.T) {
+	t.Run("empty window", func(t *testing.T) {
+		require.Nil(t, collector{}.Aggregate(nil, nil, metrics.AggregationMean))
+	})
+
+	t.Run("time weighted mean", func(t *testing.T) {
+		end := time.Now()
+		samples := []metrics.Sample{
+			{Timestamp: end.Add(-4 * time.Second), Value: &models.CPUStat{User: 10}},
+			{Timestamp: end.Add(-3 * time.Second), Value: &models.CPUStat{User: 10}},
+			{Timestamp: end.Add(-2 * time.Second), Value: &models.CPUStat{User: 10}},
+			{Timestamp: end.Add(-1*time.Second - time.Millisecond), Value: &models.CPUStat{User: 100}},
+			{Timestamp: end.Add(-time.Second), Value: &models.CPUStat{User: 100}},
+		}
+		weights := []float64{1, 1, 0.999, 0.001, 1}
+
+		result := collector{}.Aggregate(samples, weights, metrics.AggregationMean).(*models.CPUStat)
+		require.InDelta(t, 32.5, result.User, 0.1)
+
+		result = collector{}.Aggregate(samples, nil, metrics.AggregationMean).(*models.CPUStat)
+		require.InDelta(t, 46.0, result.User, 0.1)
+	})
+}
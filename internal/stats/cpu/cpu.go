@@ -1,10 +1,13 @@
 package cpu
 
 import (
+	"context"
+
 	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
 )
 
-func GetStats() (*models.CPUStat, error) {
-	cpuInfo, err := GetCpuStat()
+func GetStats(ctx context.Context, fsys procfs.FS) (*models.CPUStat, error) {
+	cpuInfo, err := GetCpuStat(ctx, fsys)
 	return cpuInfo, err
 }
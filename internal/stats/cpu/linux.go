@@ -3,34 +3,69 @@
 package cpu
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
 	"strings"
 
 	"github.com/cepmap/otus-system-monitoring/internal/models"
-	tools "github.com/cepmap/otus-system-monitoring/internal/tools"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
 )
 
+// Номера счётчиков в строке "cpu" файла /proc/stat, считая имя нулевым.
 const (
-	userPos   = 14
-	systemPos = 16
-	idlePos   = 19
+	userPos    = 1
+	systemPos  = 3
+	idlePos    = 4
+	irqPos     = 6
+	softirqPos = 7
+	stealPos   = 8
 )
 
+var errInvalidCPUStat = errors.New("invalid cpu stat")
+
+// GetCpuStat считает загрузку CPU с момента загрузки системы, как первый отчёт iostat -c.
+//
 //nolint:stylecheck,revive
-func GetCpuStat() (*models.CPUStat, error) {
-	res, err := tools.Exec("iostat", []string{"-c"})
+func GetCpuStat(ctx context.Context, fsys procfs.FS) (*models.CPUStat, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	data, err := fs.ReadFile(fsys.Proc, "stat")
 	if err != nil {
 		return nil, err
 	}
+	return parseCPUStat(string(data))
+}
 
-	fields := strings.Fields(res)
+// parseCPUStat разбирает суммарную строку "cpu user nice system idle iowait irq softirq steal ...".
+// Время гостевых систем уже входит в user и nice и отдельно не учитывается.
+func parseCPUStat(data string) (*models.CPUStat, error) {
+	line, _, _ := strings.Cut(data, "\n")
+	fields := strings.Fields(line)
+	if len(fields) <= stealPos || fields[0] != "cpu" {
+		return nil, fmt.Errorf("%w: %q", errInvalidCPUStat, line)
+	}
 
-	user := tools.ParseFloat(fields[userPos])
-	system := tools.ParseFloat(fields[systemPos])
-	idle := tools.ParseFloat(fields[idlePos])
+	var ticks [stealPos + 1]float64
+	var total float64
+	for i := userPos; i <= stealPos; i++ {
+		value, err := strconv.ParseUint(fields[i], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidCPUStat, err)
+		}
+		ticks[i] = float64(value)
+		total += ticks[i]
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("%w: no cpu time", errInvalidCPUStat)
+	}
 
 	return &models.CPUStat{
-		User:   user,
-		System: system,
-		Idle:   idle,
+		User:   ticks[userPos] / total * 100,
+		System: (ticks[systemPos] + ticks[irqPos] + ticks[softirqPos]) / total * 100,
+		Idle:   ticks[idlePos] / total * 100,
 	}, nil
 }
@@ -3,21 +3,41 @@
 package cpu
 
 import (
+	"context"
 	"testing"
+	"testing/fstest"
 
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
 	"github.com/stretchr/testify/require"
 )
 
 func TestGetStat(t *testing.T) {
 	t.Run("test success get stats", func(t *testing.T) {
-		cpu, err := GetStats()
+		cpu, err := GetStats(context.Background(), procfs.Default())
 
 		require.NoError(t, err)
-		require.NotNil(t, cpu.User)
-		require.IsType(t, float64(1), cpu.User)
-		require.NotNil(t, cpu.System)
-		require.IsType(t, float64(1), cpu.System)
-		require.NotNil(t, cpu.Idle)
-		require.IsType(t, float64(1), cpu.Idle)
+		require.GreaterOrEqual(t, cpu.User, 0.0)
+		require.GreaterOrEqual(t, cpu.System, 0.0)
+		require.Positive(t, cpu.Idle)
+		require.LessOrEqual(t, cpu.User+cpu.System+cpu.Idle, 100.0+1e-9)
 	})
+
+	t.Run("reads stat from procfs root", func(t *testing.T) {
+		proc := fstest.MapFS{"stat": {Data: []byte(
+			"cpu  300 50 100 500 20 10 15 5 40 0\ncpu0 300 50 100 500 20 10 15 5 40 0\nintr 1 2 3\n",
+		)}}
+
+		cpu, err := GetStats(context.Background(), procfs.FS{Proc: proc})
+		require.NoError(t, err)
+		require.InDelta(t, 30.0, cpu.User, 1e-9)
+		require.InDelta(t, 12.5, cpu.System, 1e-9)
+		require.InDelta(t, 50.0, cpu.Idle, 1e-9)
+	})
+}
+
+func TestParseCPUStat(t *testing.T) {
+	for _, data := range []string{"", "intr 1 2 3", "cpu 1 2 3", "cpu a 0 0 0 0 0 0 0", "cpu 0 0 0 0 0 0 0 0"} {
+		_, err := parseCPUStat(data)
+		require.ErrorIs(t, err, errInvalidCPUStat, data)
+	}
 }
@@ -0,0 +1,76 @@
+package daemon
+
+import (
+	"context"
+	"math"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/converter"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+)
+
+// Name — имя статистики в конфигурации, включается через stats.collectors.daemon_self.enabled.
+const Name = "daemon_self"
+
+// В реестре сборщик без счётчиков сервера: он описывает статистику, а снимает
+// её сборщик из NewCollector, который сервер передаёт своим подпискам.
+func init() {
+	stats.Register(collector{})
+}
+
+type collector struct {
+	source Source
+}
+
+// NewCollector возвращает сборщик со счётчиками сервера source.
+func NewCollector(source Source) stats.Collector {
+	return collector{source: source}
+}
+
+func (collector) Name() string {
+	return Name
+}
+
+func (collector) StatType() pb.StatType {
+	return pb.StatType_DAEMON_SELF
+}
+
+func (c collector) Sample(_ context.Context, _ config.Stats) (any, error) {
+	return GetStats(c.source)
+}
+
+// Aggregate агрегирует горутины, кучу и число подписок; накопительные счётчики
+// GC, очистки и сборов берутся из последнего замера.
+func (collector) Aggregate(samples []metrics.Sample, weights []float64, agg metrics.Aggregation) any {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	goroutines := make([]float64, 0, len(samples))
+	heap := make([]float64, 0, len(samples))
+	streams := make([]float64, 0, len(samples))
+	for _, s := range samples {
+		stat := s.Value.(*models.DaemonSelf)
+		goroutines = append(goroutines, float64(stat.Goroutines))
+		heap = append(heap, float64(stat.HeapBytes))
+		streams = append(streams, float64(stat.ActiveStreams))
+	}
+
+	latest := samples[len(samples)-1].Value.(*models.DaemonSelf)
+	return &models.DaemonSelf{
+		Goroutines:     int32(math.Round(metrics.AggregateValues(goroutines, weights, agg))),
+		HeapBytes:      uint64(math.Round(metrics.AggregateValues(heap, weights, agg))),
+		GCCycles:       latest.GCCycles,
+		GCPauseSeconds: latest.GCPauseSeconds,
+		ActiveStreams:  int32(math.Round(metrics.AggregateValues(streams, weights, agg))),
+		CleanerRuns:    latest.CleanerRuns,
+		Collectors:     latest.Collectors,
+	}
+}
+
+func (collector) ToProto(value any, _ stats.Options, response *pb.StatsResponse) {
+	response.DaemonSelf = converter.DaemonSelfToProto(value.(*models.DaemonSelf))
+}
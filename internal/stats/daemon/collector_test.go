@@ -0,0 +1,80 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorAggregate(t *testing.T) {
+	require.Nil(t, collector{}.Aggregate(nil, nil, metrics.AggregationMean))
+
+	collectors := []models.DaemonCollector{{Name: "cpu", Runs: 4}}
+	samples := []metrics.Sample{
+		{Value: &models.DaemonSelf{Goroutines: 10, HeapBytes: 1000, GCCycles: 1, ActiveStreams: 1, CleanerRuns: 0}},
+		{Value: &models.DaemonSelf{Goroutines: 20, HeapBytes: 3000, GCCycles: 3, ActiveStreams: 2, CleanerRuns: 1, Collectors: collectors}},
+	}
+
+	result := collector{}.Aggregate(samples, nil, metrics.AggregationMean).(*models.DaemonSelf)
+	require.Equal(t, &models.DaemonSelf{
+		Goroutines:    15,
+		HeapBytes:     2000,
+		GCCycles:      3,
+		ActiveStreams: 2,
+		CleanerRuns:   1,
+		Collectors:    collectors,
+	}, result)
+}
+
+type fakeSource struct {
+	streams int
+	storage metrics.StorageStats
+}
+
+func (s fakeSource) ActiveStreams() int {
+	return s.streams
+}
+
+func (s fakeSource) StorageStats() metrics.StorageStats {
+	return s.storage
+}
+
+func TestGetStats(t *testing.T) {
+	t.Run("without source", func(t *testing.T) {
+		stats, err := GetStats(nil)
+		require.ErrorIs(t, err, errNoSource)
+		require.Positive(t, stats.Goroutines)
+		require.Positive(t, stats.HeapBytes)
+	})
+
+	t.Run("server counters", func(t *testing.T) {
+		sc := NewCollector(fakeSource{
+			streams: 3,
+			storage: metrics.StorageStats{
+				CleanerRuns: 2,
+				Collectors: map[string]metrics.CollectorStats{
+					Name:      {Runs: 5, Errors: 1, LastDuration: 20 * time.Millisecond, Items: 5},
+					"unknown": {Runs: 1},
+				},
+			},
+		})
+
+		value, err := sc.Sample(context.Background(), config.Stats{})
+		require.NoError(t, err)
+		stats := value.(*models.DaemonSelf)
+		require.Equal(t, int32(3), stats.ActiveStreams)
+		require.Equal(t, int64(2), stats.CleanerRuns)
+		require.Len(t, stats.Collectors, 1)
+		require.Equal(t, Name, stats.Collectors[0].Name)
+		require.Equal(t, int32(pb.StatType_DAEMON_SELF), stats.Collectors[0].StatType)
+		require.Equal(t, int64(1), stats.Collectors[0].Errors)
+		require.Equal(t, int64(5), stats.Collectors[0].StoredSamples)
+		require.InDelta(t, 0.02, stats.Collectors[0].LastDurationSeconds, 1e-9)
+	})
+}
@@ -0,0 +1,97 @@
+package daemon
+
+import (
+	"errors"
+	"math"
+	rtmetrics "runtime/metrics"
+	"sort"
+
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+)
+
+// Метрики runtime, из которых собирается состояние демона.
+const (
+	goroutinesMetric = "/sched/goroutines:goroutines"
+	heapMetric       = "/memory/classes/heap/objects:bytes"
+	gcCyclesMetric   = "/gc/cycles/total:gc-cycles"
+	gcPausesMetric   = "/sched/pauses/total/gc:seconds"
+)
+
+var errNoSource = errors.New("daemon counters are not attached")
+
+// Source отдаёт счётчики, которые ведут сервер и хранилище замеров.
+type Source interface {
+	ActiveStreams() int
+	StorageStats() metrics.StorageStats
+}
+
+// GetStats читает метрики runtime и счётчики сервера source.
+// Без source отдаются только метрики runtime.
+func GetStats(source Source) (*models.DaemonSelf, error) {
+	samples := []rtmetrics.Sample{
+		{Name: goroutinesMetric},
+		{Name: heapMetric},
+		{Name: gcCyclesMetric},
+		{Name: gcPausesMetric},
+	}
+	rtmetrics.Read(samples)
+
+	result := &models.DaemonSelf{
+		Goroutines: int32(samples[0].Value.Uint64()), //nolint:gosec
+		HeapBytes:  samples[1].Value.Uint64(),
+		GCCycles:   samples[2].Value.Uint64(),
+	}
+	result.GCPauseSeconds = histogramSum(samples[3].Value.Float64Histogram())
+
+	if source == nil {
+		return result, errNoSource
+	}
+	result.ActiveStreams = int32(source.ActiveStreams()) //nolint:gosec
+	storage := source.StorageStats()
+	result.CleanerRuns = storage.CleanerRuns
+
+	names := make([]string, 0, len(storage.Collectors))
+	for name := range storage.Collectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sc, ok := stats.ByName(name)
+		if !ok {
+			continue
+		}
+		counters := storage.Collectors[name]
+		result.Collectors = append(result.Collectors, models.DaemonCollector{
+			StatType:            int32(sc.StatType()),
+			Name:                name,
+			LastDurationSeconds: counters.LastDuration.Seconds(),
+			Runs:                counters.Runs,
+			Errors:              counters.Errors,
+			StoredSamples:       int64(counters.Items),
+		})
+	}
+	return result, nil
+}
+
+// histogramSum оценивает сумму значений гистограммы по серединам корзин.
+// У крайних корзин одна из границ бесконечна, для них берётся конечная граница.
+func histogramSum(h *rtmetrics.Float64Histogram) float64 {
+	var sum float64
+	for i, count := range h.Counts {
+		if count == 0 {
+			continue
+		}
+		low, high := h.Buckets[i], h.Buckets[i+1]
+		value := (low + high) / 2
+		switch {
+		case math.IsInf(low, -1):
+			value = high
+		case math.IsInf(high, 1):
+			value = low
+		}
+		sum += value * float64(count)
+	}
+	return sum
+}
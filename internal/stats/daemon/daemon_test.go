@@ -0,0 +1,17 @@
+package daemon
+
+import (
+	"math"
+	rtmetrics "runtime/metrics"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogramSum(t *testing.T) {
+	h := &rtmetrics.Float64Histogram{
+		Counts:  []uint64{1, 0, 2, 1},
+		Buckets: []float64{math.Inf(-1), 0.001, 0.002, 0.004, math.Inf(1)},
+	}
+	require.InDelta(t, 0.001+2*0.003+0.004, histogramSum(h), 1e-12)
+}
@@ -0,0 +1,80 @@
+package disksload
+
+import (
+	"context"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/converter"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+)
+
+func init() {
+	stats.Register(collector{})
+}
+
+type collector struct{}
+
+func (collector) Name() string {
+	return config.StatDiskLoad
+}
+
+func (collector) StatType() pb.StatType {
+	return pb.StatType_DISKS_LOAD
+}
+
+func (collector) Sample(ctx context.Context, cfg config.Stats) (any, error) {
+	stats, err := GetStats(ctx, procfs.New(cfg.Procfs, cfg.Sysfs))
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (collector) Aggregate(samples []metrics.Sample, weights []float64, agg metrics.Aggregation) any {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	type diskValues struct {
+		tps     []float64
+		kps     []float64
+		weights []float64
+	}
+
+	diskOrder := make([]string, 0)
+	disks := make(map[string]*diskValues)
+
+	for i, s := range samples {
+		for _, disk := range s.Value.(*models.DisksLoad).DisksLoad {
+			if _, ok := disks[disk.FSName]; !ok {
+				disks[disk.FSName] = &diskValues{}
+				diskOrder = append(diskOrder, disk.FSName)
+			}
+			disks[disk.FSName].tps = append(disks[disk.FSName].tps, disk.Tps)
+			disks[disk.FSName].kps = append(disks[disk.FSName].kps, disk.Kps)
+			if i < len(weights) {
+				disks[disk.FSName].weights = append(disks[disk.FSName].weights, weights[i])
+			}
+		}
+	}
+
+	result := make([]models.DiskLoad, 0, len(disks))
+	for _, fsName := range diskOrder {
+		values := disks[fsName]
+		result = append(result, models.DiskLoad{
+			FSName: fsName,
+			Tps:    metrics.Round(metrics.AggregateValues(values.tps, values.weights, agg)),
+			Kps:    metrics.Round(metrics.AggregateValues(values.kps, values.weights, agg)),
+		})
+	}
+
+	return &models.DisksLoad{DisksLoad: result}
+}
+
+func (collector) ToProto(value any, _ stats.Options, response *pb.StatsResponse) {
+	response.DisksLoad = converter.DisksLoadToProto(value.(*models.DisksLoad))
+}
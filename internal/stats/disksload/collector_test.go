@@ -0,0 +1,30 @@
+package disksload
+
+import (
+	"testing"
+
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorAggregate(t *testing.T) {
+	t.Run("empty window", func(t *testing.T) {
+		require.Nil(t, collector{}.Aggregate(nil, nil, metrics.AggregationMean))
+	})
+
+	t.Run("per disk aggregation", func(t *testing.T) {
+		samples := []metrics.Sample{
+			{Value: &models.DisksLoad{DisksLoad: []models.DiskLoad{{FSName: "sda", Tps: 1, Kps: 10}, {FSName: "sdb", Tps: 5, Kps: 50}}}},
+			{Value: &models.DisksLoad{DisksLoad: []models.DiskLoad{{FSName: "sda", Tps: 3, Kps: 30}}}},
+		}
+
+		result := collector{}.Aggregate(samples, nil, metrics.AggregationMax).(*models.DisksLoad)
+		require.Len(t, result.DisksLoad, 2)
+		require.Equal(t, models.DiskLoad{FSName: "sda", Tps: 3, Kps: 30}, result.DisksLoad[0])
+		require.Equal(t, models.DiskLoad{FSName: "sdb", Tps: 5, Kps: 50}, result.DisksLoad[1])
+
+		result = collector{}.Aggregate(samples, nil, metrics.AggregationLast).(*models.DisksLoad)
+		require.Equal(t, models.DiskLoad{FSName: "sda", Tps: 3, Kps: 30}, result.DisksLoad[0])
+	})
+}
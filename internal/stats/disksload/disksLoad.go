@@ -1,10 +1,13 @@
 package disksload
 
 import (
+	"context"
+
 	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
 )
 
-func GetStats() (*models.DisksLoad, error) {
-	diskLoad, err := GetDisksLoad()
+func GetStats(ctx context.Context, fsys procfs.FS) (*models.DisksLoad, error) {
+	diskLoad, err := GetDisksLoad(ctx, fsys)
 	return diskLoad, err
 }
@@ -3,31 +3,116 @@
 package disksload
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
 	"strings"
 
 	"github.com/cepmap/otus-system-monitoring/internal/models"
-	tools "github.com/cepmap/otus-system-monitoring/internal/tools"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
 )
 
-func GetDisksLoad() (*models.DisksLoad, error) {
-	disksLoad := make([]models.DiskLoad, 0, 4)
+// Номера полей строки /proc/diskstats.
+const (
+	namePos      = 2
+	readIOsPos   = 3
+	readSectPos  = 5
+	writeIOsPos  = 7
+	discardIOPos = 14
+	minFieldsLen = 14
+)
+
+// sectorsPerKB — счётчики секторов в diskstats всегда в единицах по 512 байт.
+const sectorsPerKB = 2
+
+var errInvalidDiskStats = errors.New("invalid diskstats")
 
-	res, err := tools.ExecCommand("iostat", []string{""})
+// GetDisksLoad считает средние с момента загрузки системы tps и kB_read/s по дискам,
+// как первый отчёт iostat. Разделы и ни разу не использованные устройства пропускаются.
+func GetDisksLoad(ctx context.Context, fsys procfs.FS) (*models.DisksLoad, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	uptime, err := readUptime(fsys.Proc)
 	if err != nil {
 		return nil, err
 	}
-	lines := strings.Split(res, "\n")
-	for i := range lines {
-		fields := strings.Fields(lines[i])
-		if len(fields) < 8 || fields[0] == "Device" {
+	data, err := fs.ReadFile(fsys.Proc, "diskstats")
+	if err != nil {
+		return nil, err
+	}
+
+	// Без sysfs разделы не отличить от дисков, тогда показываются все устройства.
+	var devices map[string]struct{}
+	if entries, err := fs.ReadDir(fsys.Sys, "block"); err == nil {
+		devices = make(map[string]struct{}, len(entries))
+		for _, entry := range entries {
+			devices[entry.Name()] = struct{}{}
+		}
+	}
+
+	return parseDiskStats(string(data), uptime, devices)
+}
+
+func parseDiskStats(data string, uptime float64, devices map[string]struct{}) (*models.DisksLoad, error) {
+	disksLoad := make([]models.DiskLoad, 0, 4)
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < minFieldsLen {
 			continue
 		}
-		nDiskLoad := models.DiskLoad{
-			FSName: fields[0],
-			Kps:    tools.ParseFloat(fields[2]),
-			Tps:    tools.ParseFloat(fields[1]),
+		name := fields[namePos]
+		if devices != nil {
+			// В sysfs "/" в имени устройства заменён на "!", например cciss!c0d0.
+			if _, ok := devices[strings.ReplaceAll(name, "/", "!")]; !ok {
+				continue
+			}
+		}
+
+		counters := make(map[int]uint64, 4)
+		for _, pos := range []int{readIOsPos, readSectPos, writeIOsPos, discardIOPos} {
+			// Счётчиков discard нет в ядрах до 4.18.
+			if pos >= len(fields) {
+				continue
+			}
+			value, err := strconv.ParseUint(fields[pos], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %w", errInvalidDiskStats, name, err)
+			}
+			counters[pos] = value
 		}
-		disksLoad = append(disksLoad, nDiskLoad)
+
+		ios := counters[readIOsPos] + counters[writeIOsPos] + counters[discardIOPos]
+		if ios == 0 {
+			continue
+		}
+		disksLoad = append(disksLoad, models.DiskLoad{
+			FSName: name,
+			Tps:    float64(ios) / uptime,
+			Kps:    float64(counters[readSectPos]) / sectorsPerKB / uptime,
+		})
 	}
 	return &models.DisksLoad{DisksLoad: disksLoad}, nil
 }
+
+// readUptime возвращает время с момента загрузки системы в секундах.
+func readUptime(proc fs.FS) (float64, error) {
+	data, err := fs.ReadFile(proc, "uptime")
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("%w: empty uptime", errInvalidDiskStats)
+	}
+	uptime, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: uptime: %w", errInvalidDiskStats, err)
+	}
+	if uptime <= 0 {
+		return 0, fmt.Errorf("%w: uptime %v", errInvalidDiskStats, uptime)
+	}
+	return uptime, nil
+}
@@ -3,55 +3,75 @@
 package disksload
 
 import (
+	"context"
+	"io/fs"
 	"testing"
+	"testing/fstest"
 
 	"github.com/cepmap/otus-system-monitoring/internal/models"
-	"github.com/cepmap/otus-system-monitoring/internal/tools"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-type MockTools struct {
-	mock.Mock
-}
+const diskstats = `   8       0 sda 100 5 2000 50 23 7 400 30 0 60 80 10 0 600 5 0 0
+   8       1 sda1 90 5 1800 45 20 7 380 28 0 55 73 0 0 0 0 0 0
+   8      16 sdb 400 0 8000 10 56 0 100 5 0 12 15
+   7       0 loop0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0
+`
 
-func (m *MockTools) Exec(command string, args []string) (string, error) {
-	_ = command
-	_ = args
-	return `Device            tps    kB_read/s    kB_wrtn/s    kB_dscd/s    kB_read    kB_wrtn    kB_dscd
-sda              1.23    456.78       789.01          0.00     123456     789012          0
-sdb              4.56    789.01       123.45          0.00     456789     123456          0`, nil
+func testFS() procfs.FS {
+	return procfs.FS{
+		Proc: fstest.MapFS{
+			"uptime":    {Data: []byte("10.00 35.50\n")},
+			"diskstats": {Data: []byte(diskstats)},
+		},
+		Sys: fstest.MapFS{
+			"block/sda":   {Mode: fs.ModeDir},
+			"block/sdb":   {Mode: fs.ModeDir},
+			"block/loop0": {Mode: fs.ModeDir},
+		},
+	}
 }
 
 func TestGetDisksLoad(t *testing.T) {
-	t.Run("should parse iostat output correctly", func(t *testing.T) {
-		mockTools := new(MockTools)
-		tools.ExecCommand = mockTools.Exec
-		mockTools.On("Exec", "iostat", []string{""}).Return([]string{""})
-
-		result, err := GetDisksLoad()
+	t.Run("should parse diskstats correctly", func(t *testing.T) {
+		result, err := GetDisksLoad(context.Background(), testFS())
 
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Len(t, result.DisksLoad, 2)
+		require.NoError(t, err)
+		require.Len(t, result.DisksLoad, 2)
 
 		assert.Equal(t, "sda", result.DisksLoad[0].FSName)
-		assert.Equal(t, 1.23, result.DisksLoad[0].Tps)
-		assert.Equal(t, 456.78, result.DisksLoad[0].Kps)
+		assert.InDelta(t, 13.3, result.DisksLoad[0].Tps, 1e-9)
+		assert.InDelta(t, 100.0, result.DisksLoad[0].Kps, 1e-9)
 
 		assert.Equal(t, "sdb", result.DisksLoad[1].FSName)
-		assert.Equal(t, 4.56, result.DisksLoad[1].Tps)
-		assert.Equal(t, 789.01, result.DisksLoad[1].Kps)
+		assert.InDelta(t, 45.6, result.DisksLoad[1].Tps, 1e-9)
+		assert.InDelta(t, 400.0, result.DisksLoad[1].Kps, 1e-9)
+	})
+
+	t.Run("without sysfs partitions are kept", func(t *testing.T) {
+		fsys := testFS()
+		fsys.Sys = fstest.MapFS{}
+
+		result, err := GetDisksLoad(context.Background(), fsys)
+		require.NoError(t, err)
+		require.Len(t, result.DisksLoad, 3)
+		assert.Equal(t, "sda1", result.DisksLoad[1].FSName)
+	})
+
+	t.Run("invalid counters", func(t *testing.T) {
+		fsys := testFS()
+		fsys.Proc.(fstest.MapFS)["diskstats"] = &fstest.MapFile{Data: []byte("8 0 sda x 0 0 0 0 0 0 0 0 0 0 0 0 0\n")}
+
+		_, err := GetDisksLoad(context.Background(), fsys)
+		require.ErrorIs(t, err, errInvalidDiskStats)
 	})
 }
 
 func TestGetStats(t *testing.T) {
 	t.Run("should return disk stats", func(t *testing.T) {
-		mockTools := new(MockTools)
-		tools.ExecCommand = mockTools.Exec
-		mockTools.On("Exec", "iostat", []string{""}).Return([]string{""})
-
-		result, err := GetStats()
+		result, err := GetStats(context.Background(), procfs.Default())
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -0,0 +1,160 @@
+package diskstat
+
+import (
+	"context"
+	"math"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/converter"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+)
+
+// fillPredictionPeriod — минимальная история для прогноза заполнения диска.
+const fillPredictionPeriod = time.Hour
+
+func init() {
+	stats.Register(collector{})
+}
+
+type collector struct{}
+
+func (collector) Name() string {
+	return config.StatDiskInfo
+}
+
+func (collector) StatType() pb.StatType {
+	return pb.StatType_DISK_USAGE
+}
+
+func (collector) Sample(ctx context.Context, cfg config.Stats) (any, error) {
+	stats, err := GetStats(ctx, procfs.New(cfg.Procfs, cfg.Sysfs), procfs.HostRoot(cfg.Procfs))
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (collector) Aggregate(samples []metrics.Sample, weights []float64, agg metrics.Aggregation) any {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	type fsValues struct {
+		latest          models.DiskStat
+		timestamps      []time.Time
+		weights         []float64
+		used            []float64
+		available       []float64
+		percent         []float64
+		inodesUsed      []float64
+		inodesAvailable []float64
+		inodesPercent   []float64
+	}
+
+	fsOrder := make([]string, 0)
+	filesystems := make(map[string]*fsValues)
+
+	for i, s := range samples {
+		for _, disk := range s.Value.(*models.DiskStats).DiskStats {
+			key := disk.FileSystem + " " + disk.MountPoint
+			if _, ok := filesystems[key]; !ok {
+				filesystems[key] = &fsValues{}
+				fsOrder = append(fsOrder, key)
+			}
+			values := filesystems[key]
+			values.latest = disk
+			values.timestamps = append(values.timestamps, s.Timestamp)
+			if i < len(weights) {
+				values.weights = append(values.weights, weights[i])
+			}
+			values.used = append(values.used, float64(disk.Usage.Used))
+			values.available = append(values.available, float64(disk.Usage.Available))
+			values.percent = append(values.percent, disk.Usage.UsagePercent)
+			values.inodesUsed = append(values.inodesUsed, float64(disk.Inodes.Used))
+			values.inodesAvailable = append(values.inodesAvailable, float64(disk.Inodes.Available))
+			values.inodesPercent = append(values.inodesPercent, disk.Inodes.UsagePercent)
+		}
+	}
+
+	result := make([]models.DiskStat, 0, len(filesystems))
+	for _, key := range fsOrder {
+		values := filesystems[key]
+		// Занятое место хранится в килобайтах, скорость роста считаем в байтах.
+		usedGrowth, _, _ := metrics.LinearRegression(values.timestamps, values.used)
+		inodesGrowth, _, _ := metrics.LinearRegression(values.timestamps, values.inodesUsed)
+
+		result = append(result, models.DiskStat{
+			FileSystem: values.latest.FileSystem,
+			MountPoint: values.latest.MountPoint,
+			Usage: models.DiskUsage{
+				Used:         uint64(math.Round(metrics.AggregateValues(values.used, values.weights, agg))),
+				Available:    uint64(math.Round(metrics.AggregateValues(values.available, values.weights, agg))),
+				Usage:        values.latest.Usage.Usage,
+				UsagePercent: metrics.Round(metrics.AggregateValues(values.percent, values.weights, agg)),
+				GrowthRate:   metrics.Round(usedGrowth * 1024),
+			},
+			Inodes: models.InodeUsage{
+				Used:         uint64(math.Round(metrics.AggregateValues(values.inodesUsed, values.weights, agg))),
+				Available:    uint64(math.Round(metrics.AggregateValues(values.inodesAvailable, values.weights, agg))),
+				Usage:        values.latest.Inodes.Usage,
+				UsagePercent: metrics.Round(metrics.AggregateValues(values.inodesPercent, values.weights, agg)),
+				GrowthRate:   metrics.Round(inodesGrowth),
+			},
+		})
+	}
+
+	return &models.DiskStats{DiskStats: result}
+}
+
+func (collector) ForecastPeriod() time.Duration {
+	return fillPredictionPeriod
+}
+
+// Forecast дополняет агрегированные данные оценкой времени до заполнения
+// файловой системы и таблицы inode по истории замеров.
+func (collector) Forecast(value any, history []metrics.Sample, now time.Time) {
+	stats := value.(*models.DiskStats)
+
+	type fsHistory struct {
+		timestamps []time.Time
+		used       []float64
+		inodesUsed []float64
+		capacity   float64
+		inodes     float64
+	}
+
+	filesystems := make(map[string]*fsHistory)
+	for _, s := range history {
+		for _, disk := range s.Value.(*models.DiskStats).DiskStats {
+			key := disk.FileSystem + " " + disk.MountPoint
+			if _, ok := filesystems[key]; !ok {
+				filesystems[key] = &fsHistory{}
+			}
+			values := filesystems[key]
+			values.timestamps = append(values.timestamps, s.Timestamp)
+			values.used = append(values.used, float64(disk.Usage.Used))
+			values.inodesUsed = append(values.inodesUsed, float64(disk.Inodes.Used))
+			values.capacity = float64(disk.Usage.Used + disk.Usage.Available)
+			values.inodes = float64(disk.Inodes.Used + disk.Inodes.Available)
+		}
+	}
+
+	for i := range stats.DiskStats {
+		disk := &stats.DiskStats[i]
+		values, ok := filesystems[disk.FileSystem+" "+disk.MountPoint]
+		if !ok {
+			continue
+		}
+		disk.Usage.SecondsToFull = math.Round(metrics.SecondsToFull(values.timestamps, values.used, values.capacity, now))
+		disk.Inodes.SecondsToFull = math.Round(metrics.SecondsToFull(values.timestamps, values.inodesUsed, values.inodes, now))
+	}
+}
+
+func (collector) ToProto(value any, _ stats.Options, response *pb.StatsResponse) {
+	response.DiskStats = converter.DiskStatsToProto(value.(*models.DiskStats))
+}
@@ -0,0 +1,75 @@
+package diskstat
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorAggregate(t *testing.T) {
+	t.Run("empty window", func(t *testing.T) {
+		require.Nil(t, collector{}.Aggregate(nil, nil, metrics.AggregationMean))
+	})
+
+	t.Run("windowed usage and growth rate", func(t *testing.T) {
+		start := time.Now()
+		diskAt := func(used, inodes uint64, percent float64) *models.DiskStats {
+			return &models.DiskStats{DiskStats: []models.DiskStat{{
+				FileSystem: "/dev/sda1",
+				MountPoint: "/",
+				Usage: models.DiskUsage{
+					Used:         used,
+					Available:    4000 - used,
+					Usage:        fmt.Sprintf("%.0f%%", percent),
+					UsagePercent: percent,
+				},
+				Inodes: models.InodeUsage{Used: inodes, Available: 1000 - inodes},
+			}}}
+		}
+		samples := []metrics.Sample{
+			{Timestamp: start, Value: diskAt(1000, 100, 25)},
+			{Timestamp: start.Add(time.Second), Value: diskAt(1500, 110, 38)},
+			{Timestamp: start.Add(2 * time.Second), Value: diskAt(2000, 120, 50)},
+		}
+
+		result := collector{}.Aggregate(samples, nil, metrics.AggregationMean).(*models.DiskStats)
+		require.Len(t, result.DiskStats, 1)
+
+		disk := result.DiskStats[0]
+		require.Equal(t, "/dev/sda1", disk.FileSystem)
+		require.Equal(t, "/", disk.MountPoint)
+		require.Equal(t, uint64(1500), disk.Usage.Used)
+		require.Equal(t, uint64(2500), disk.Usage.Available)
+		require.Equal(t, "50%", disk.Usage.Usage)
+		require.InDelta(t, 37.67, disk.Usage.UsagePercent, 1e-9)
+		require.InDelta(t, 500*1024.0, disk.Usage.GrowthRate, 1e-9)
+		require.Equal(t, uint64(110), disk.Inodes.Used)
+		require.InDelta(t, 10.0, disk.Inodes.GrowthRate, 1e-9)
+	})
+}
+
+func TestCollectorForecast(t *testing.T) {
+	start := time.Now()
+	diskAt := func(used, inodes uint64) *models.DiskStats {
+		return &models.DiskStats{DiskStats: []models.DiskStat{{
+			FileSystem: "/dev/sda1",
+			MountPoint: "/var",
+			Usage:      models.DiskUsage{Used: used, Available: 1000 - used},
+			Inodes:     models.InodeUsage{Used: inodes, Available: 100 - inodes},
+		}}}
+	}
+	history := []metrics.Sample{
+		{Timestamp: start, Value: diskAt(500, 10)},
+		{Timestamp: start.Add(10 * time.Second), Value: diskAt(600, 10)},
+		{Timestamp: start.Add(20 * time.Second), Value: diskAt(700, 10)},
+	}
+
+	stats := diskAt(700, 10)
+	collector{}.Forecast(stats, history, start.Add(20*time.Second))
+	require.Equal(t, 30.0, stats.DiskStats[0].Usage.SecondsToFull)
+	require.Zero(t, stats.DiskStats[0].Inodes.SecondsToFull)
+}
@@ -1,10 +1,13 @@
 package diskstat
 
 import (
+	"context"
+
 	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
 )
 
-func GetStats() (*models.DiskStats, error) {
-	diskStat, err := GetDiskStats()
+func GetStats(ctx context.Context, fsys procfs.FS, hostRoot string) (*models.DiskStats, error) {
+	diskStat, err := GetDiskStats(ctx, fsys, hostRoot)
 	return diskStat, err
 }
@@ -3,97 +3,173 @@
 package diskstat
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 
 	"github.com/cepmap/otus-system-monitoring/internal/models"
-	tools "github.com/cepmap/otus-system-monitoring/internal/tools"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
 )
 
-func parseUint(str string) (uint64, error) {
-	val, err := strconv.ParseUint(str, 10, 64)
-	if err != nil {
-		return 0, err
-	}
-	return val, nil
+// excludedTypes — файловые системы в памяти, которые не показывает и df.
+var excludedTypes = map[string]bool{"tmpfs": true, "devtmpfs": true, "udev": true}
+
+type mount struct {
+	device string
+	point  string
+	fsType string
 }
 
-func GetDiskStats() (*models.DiskStats, error) {
-	dfOut, err := getDiskInfo()
+// GetDiskStats читает список файловых систем из procfs и их заполнение через statfs(2).
+// Точки монтирования берутся из пространства имён init (1/mounts), а statfs
+// вызывается для них внутри hostRoot, поэтому из контейнера с /host/proc
+// и корнем машины в /host видны файловые системы машины, а не контейнера.
+// Место отдаётся в килобайтах, как у df -k.
+func GetDiskStats(ctx context.Context, fsys procfs.FS, hostRoot string) (*models.DiskStats, error) {
+	mounts, err := readMounts(fsys)
 	if err != nil {
-		return nil, fmt.Errorf("error getting disk info: %w", err)
+		return nil, fmt.Errorf("error reading mounts: %w", err)
 	}
 
-	lines := strings.Split(strings.TrimSpace(dfOut), "\n")
-	if len(lines) < 2 {
-		return nil, fmt.Errorf("unexpected df output format: too few lines")
+	output := make([]models.DiskStat, 0, len(mounts))
+	for _, m := range mounts {
+		st, err := statfs(ctx, filepath.Join(hostRoot, m.point))
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		// Размонтированные во время обхода и недоступные файловые системы пропускаем, как df.
+		if err != nil || st.Blocks == 0 {
+			continue
+		}
+		output = append(output, diskStat(m, st))
 	}
-	devices := lines[1:]
-	output := make([]models.DiskStat, 0, len(devices))
+	return &models.DiskStats{DiskStats: output}, nil
+}
 
-	dfInodeOut, err := getDiskInodeInfo()
+// readMounts читает таблицу монтирования init, а если она недоступна — текущего процесса.
+// Повторно смонтированная точка учитывается один раз, по последней записи.
+func readMounts(fsys procfs.FS) ([]mount, error) {
+	data, err := fs.ReadFile(fsys.Proc, "1/mounts")
 	if err != nil {
-		return nil, err
+		if data, err = fs.ReadFile(fsys.Proc, "mounts"); err != nil {
+			return nil, err
+		}
 	}
+	return parseMounts(string(data)), nil
+}
 
-	for i, disk := range devices {
-		diskArr := strings.Fields(disk)
-		diskInodeArr := strings.Fields(dfInodeOut[i])
-
-		if len(diskArr) < 6 || len(diskInodeArr) < 6 {
+func parseMounts(data string) []mount {
+	var mounts []mount
+	index := make(map[string]int)
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || excludedTypes[fields[2]] {
 			continue
 		}
-
-		nDisk := models.DiskStat{
-			FileSystem: diskArr[0],
-			Usage:      models.DiskUsage{},
-			Inodes:     models.InodeUsage{},
-		}
-
-		used, err := parseUint(diskArr[3])
-		if err != nil {
-			return nil, err
+		m := mount{device: unescape(fields[0]), point: unescape(fields[1]), fsType: fields[2]}
+		if i, ok := index[m.point]; ok {
+			mounts[i] = m
+			continue
 		}
-		nDisk.Usage.Used = used
-		nDisk.Usage.Usage = diskArr[5]
+		index[m.point] = len(mounts)
+		mounts = append(mounts, m)
+	}
+	return mounts
+}
 
-		inodesUsed, err := parseUint(diskInodeArr[3])
-		if err != nil {
-			return nil, err
+// unescape раскрывает восьмеричные последовательности вида \040, которыми
+// ядро заменяет пробелы и табуляции в путях.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if code, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(code))
+				i += 3
+				continue
+			}
 		}
-		nDisk.Inodes.Used = inodesUsed
-
-		nDisk.Inodes.Usage = diskInodeArr[5]
-
-		output = append(output, nDisk)
+		b.WriteByte(s[i])
 	}
+	return b.String()
+}
 
-	return &models.DiskStats{DiskStats: output}, nil
+// statfs не прерывается отменой контекста: зависшая сетевая файловая система
+// оставляет горутину ждать, а сбор возвращает ошибку контекста.
+func statfs(ctx context.Context, path string) (syscall.Statfs_t, error) {
+	type result struct {
+		st  syscall.Statfs_t
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var st syscall.Statfs_t
+		err := syscall.Statfs(path, &st)
+		done <- result{st: st, err: err}
+	}()
+	select {
+	case r := <-done:
+		return r.st, r.err
+	case <-ctx.Done():
+		return syscall.Statfs_t{}, ctx.Err()
+	}
 }
 
-func getDiskInfo() (string, error) {
-	result, err := tools.Exec("df", []string{
-		"-T", "-k", "--exclude-type=tmpfs",
-		"--exclude-type=devtmpfs", "--exclude-type=udev",
-	})
-	if err != nil {
-		return "", err
+func diskStat(m mount, st syscall.Statfs_t) models.DiskStat {
+	blockSize := uint64(st.Frsize) //nolint:gosec
+	if blockSize == 0 {
+		blockSize = uint64(st.Bsize) //nolint:gosec
+	}
+	used := (st.Blocks - st.Bfree) * blockSize / 1024
+	available := st.Bavail * blockSize / 1024
+	inodesUsed := st.Files - st.Ffree
+
+	usagePercent := percent(used, used+available)
+	inodesPercent := percent(inodesUsed, st.Files)
+	inodesUsage := "-"
+	if st.Files > 0 {
+		inodesUsage = formatPercent(inodesPercent)
+	}
+
+	return models.DiskStat{
+		FileSystem: m.device,
+		MountPoint: m.point,
+		Usage: models.DiskUsage{
+			Used:         used,
+			Available:    available,
+			Usage:        formatPercent(usagePercent),
+			UsagePercent: usagePercent,
+		},
+		Inodes: models.InodeUsage{
+			Used:         inodesUsed,
+			Available:    st.Ffree,
+			Usage:        inodesUsage,
+			UsagePercent: inodesPercent,
+		},
 	}
-	return result, nil
 }
 
-func getDiskInodeInfo() ([]string, error) {
-	result, err := tools.Exec("df", []string{
-		"-T", "-k", "-i", "--exclude-type=tmpfs",
-		"--exclude-type=devtmpfs", "--exclude-type=udev",
-	})
-	if err != nil {
-		return nil, err
+// percent считает долю с округлением вверх, как df.
+func percent(used, total uint64) float64 {
+	if total == 0 {
+		return 0
 	}
-	lines := strings.Split(result, "\n")
-	if len(lines) < 2 {
-		return nil, fmt.Errorf("unexpected df output format: too few lines")
+	result := used * 100 / total
+	if used*100%total != 0 {
+		result++
 	}
-	return lines[1:], nil
+	return float64(result)
+}
+
+func formatPercent(value float64) string {
+	return strconv.FormatFloat(value, 'f', 0, 64) + "%"
 }
@@ -3,15 +3,18 @@
 package diskstat
 
 import (
-	"strings"
+	"context"
+	"syscall"
 	"testing"
+	"testing/fstest"
 
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
 	"github.com/stretchr/testify/require"
 )
 
 func TestGetDiskStats(t *testing.T) {
 	t.Run("test success get disk stats", func(t *testing.T) {
-		stats, err := GetDiskStats()
+		stats, err := GetDiskStats(context.Background(), procfs.Default(), "/")
 
 		require.NoError(t, err)
 		require.NotNil(t, stats)
@@ -20,55 +23,60 @@ func TestGetDiskStats(t *testing.T) {
 		firstDisk := stats.DiskStats[0]
 
 		require.NotEmpty(t, firstDisk.FileSystem)
-		require.IsType(t, "", firstDisk.FileSystem)
-
-		require.NotNil(t, firstDisk.Usage)
-		require.IsType(t, uint64(1), firstDisk.Usage.Used)
+		require.NotEmpty(t, firstDisk.MountPoint)
 		require.NotEmpty(t, firstDisk.Usage.Usage)
-		require.IsType(t, "", firstDisk.Usage.Usage)
-
-		require.NotNil(t, firstDisk.Inodes)
-		require.IsType(t, uint64(1), firstDisk.Inodes.Used)
+		require.Positive(t, firstDisk.Usage.Used+firstDisk.Usage.Available)
 		require.NotEmpty(t, firstDisk.Inodes.Usage)
-		require.IsType(t, "", firstDisk.Inodes.Usage)
 	})
-}
 
-func TestParseUint(t *testing.T) {
-	t.Run("test valid uint parsing", func(t *testing.T) {
-		val, err := parseUint("123")
+	t.Run("mount points under host root", func(t *testing.T) {
+		proc := fstest.MapFS{"1/mounts": {Data: []byte("/dev/sda1 / ext4 rw 0 0\n" +
+			"/dev/sdb1 /no/such/mount ext4 rw 0 0\n")}}
+		stats, err := GetDiskStats(context.Background(), procfs.FS{Proc: proc}, t.TempDir())
 		require.NoError(t, err)
-		require.Equal(t, uint64(123), val)
+		require.Len(t, stats.DiskStats, 1)
+		require.Equal(t, "/dev/sda1", stats.DiskStats[0].FileSystem)
+		require.Equal(t, "/", stats.DiskStats[0].MountPoint)
 	})
 
-	t.Run("test invalid uint parsing", func(t *testing.T) {
-		val, err := parseUint("invalid")
-		require.Error(t, err)
-		require.Equal(t, uint64(0), val)
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := GetDiskStats(ctx, procfs.Default(), "/")
+		require.ErrorIs(t, err, context.Canceled)
 	})
 }
 
-func TestGetDiskInfo(t *testing.T) {
-	t.Run("test get disk info success", func(t *testing.T) {
-		info, err := getDiskInfo()
+func TestParseMounts(t *testing.T) {
+	mounts := parseMounts("/dev/sda1 / ext4 rw 0 0\n" +
+		"tmpfs /run tmpfs rw 0 0\n" +
+		"/dev/sdb1 /mnt/my\\040disk xfs rw 0 0\n" +
+		"/dev/sdc1 /mnt/my\\040disk ext4 rw 0 0\n" +
+		"broken\n")
+	require.Equal(t, []mount{
+		{device: "/dev/sda1", point: "/", fsType: "ext4"},
+		{device: "/dev/sdc1", point: "/mnt/my disk", fsType: "ext4"},
+	}, mounts)
+
+	t.Run("falls back to own mounts", func(t *testing.T) {
+		mounts, err := readMounts(procfs.FS{Proc: fstest.MapFS{"mounts": {Data: []byte("/dev/sda1 / ext4 rw 0 0\n")}}})
 		require.NoError(t, err)
-		require.NotEmpty(t, info)
-		for _, line := range strings.Split(info, "\n") {
-			fields := strings.Fields(line)
-			require.GreaterOrEqual(t, len(fields), 6, "each line should have at least 6 fields")
-		}
+		require.Len(t, mounts, 1)
 	})
 }
 
-func TestGetDiskInodeInfo(t *testing.T) {
-	t.Run("test get disk inode info success", func(t *testing.T) {
-		info, err := getDiskInodeInfo()
-		require.NoError(t, err)
-		require.NotEmpty(t, info)
+func TestDiskStat(t *testing.T) {
+	st := syscall.Statfs_t{Frsize: 4096, Blocks: 1000, Bfree: 400, Bavail: 300, Files: 100, Ffree: 33}
+	stat := diskStat(mount{device: "/dev/sda1", point: "/"}, st)
+	require.Equal(t, uint64(2400), stat.Usage.Used)
+	require.Equal(t, uint64(1200), stat.Usage.Available)
+	require.Equal(t, "67%", stat.Usage.Usage)
+	require.Equal(t, 67.0, stat.Usage.UsagePercent)
+	require.Equal(t, uint64(67), stat.Inodes.Used)
+	require.Equal(t, uint64(33), stat.Inodes.Available)
+	require.Equal(t, "67%", stat.Inodes.Usage)
 
-		for _, line := range info {
-			fields := strings.Fields(line)
-			require.GreaterOrEqual(t, len(fields), 6, "each line should have at least 6 fields")
-		}
-	})
+	stat = diskStat(mount{}, syscall.Statfs_t{Frsize: 4096, Blocks: 10, Bfree: 10, Bavail: 10})
+	require.Equal(t, "-", stat.Inodes.Usage)
+	require.Equal(t, "0%", stat.Usage.Usage)
 }
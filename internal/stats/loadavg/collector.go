@@ -0,0 +1,69 @@
+package loadavg
+
+import (
+	"context"
+	"math"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/converter"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+)
+
+func init() {
+	stats.Register(collector{})
+}
+
+type collector struct{}
+
+func (collector) Name() string {
+	return config.StatLoadAverage
+}
+
+func (collector) StatType() pb.StatType {
+	return pb.StatType_LOAD_AVERAGE
+}
+
+func (collector) Sample(ctx context.Context, cfg config.Stats) (any, error) {
+	stats, err := GetStats(ctx, procfs.New(cfg.Procfs, cfg.Sysfs))
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (collector) Aggregate(samples []metrics.Sample, weights []float64, agg metrics.Aggregation) any {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	load1Min := make([]float64, 0, len(samples))
+	load5Min := make([]float64, 0, len(samples))
+	load15Min := make([]float64, 0, len(samples))
+	runnable := make([]float64, 0, len(samples))
+	total := make([]float64, 0, len(samples))
+	for _, s := range samples {
+		stat := s.Value.(*models.LoadAverage)
+		load1Min = append(load1Min, stat.Load1Min)
+		load5Min = append(load5Min, stat.Load5Min)
+		load15Min = append(load15Min, stat.Load15Min)
+		runnable = append(runnable, float64(stat.RunnableTasks))
+		total = append(total, float64(stat.TotalTasks))
+	}
+
+	return &models.LoadAverage{
+		Load1Min:      metrics.Round(metrics.AggregateValues(load1Min, weights, agg)),
+		Load5Min:      metrics.Round(metrics.AggregateValues(load5Min, weights, agg)),
+		Load15Min:     metrics.Round(metrics.AggregateValues(load15Min, weights, agg)),
+		RunnableTasks: int32(math.Round(metrics.AggregateValues(runnable, weights, agg))),
+		TotalTasks:    int32(math.Round(metrics.AggregateValues(total, weights, agg))),
+		LastPid:       samples[len(samples)-1].Value.(*models.LoadAverage).LastPid,
+	}
+}
+
+func (collector) ToProto(value any, _ stats.Options, response *pb.StatsResponse) {
+	response.LoadAverage = converter.LoadAverageToProto(value.(*models.LoadAverage))
+}
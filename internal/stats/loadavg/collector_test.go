@@ -0,0 +1,21 @@
+package loadavg
+
+import (
+	"testing"
+
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorAggregate(t *testing.T) {
+	require.Nil(t, collector{}.Aggregate(nil, nil, metrics.AggregationMean))
+
+	samples := []metrics.Sample{
+		{Value: &models.LoadAverage{Load1Min: 1, RunnableTasks: 1, TotalTasks: 200, LastPid: 100}},
+		{Value: &models.LoadAverage{Load1Min: 2, RunnableTasks: 4, TotalTasks: 210, LastPid: 120}},
+	}
+
+	result := collector{}.Aggregate(samples, nil, metrics.AggregationMean).(*models.LoadAverage)
+	require.Equal(t, &models.LoadAverage{Load1Min: 1.5, RunnableTasks: 3, TotalTasks: 205, LastPid: 120}, result)
+}
@@ -1,33 +1,48 @@
+//go:build linux
+
 package loadavg
 
 import (
-	"strings"
+	"context"
+	"errors"
+	"io/fs"
+	"syscall"
 
 	"github.com/cepmap/otus-system-monitoring/internal/models"
-	tools "github.com/cepmap/otus-system-monitoring/internal/tools"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
 )
 
-const (
-	load1MinPos  = 0
-	load5MinPos  = 1
-	load15MinPos = 2
-)
+// sysinfoLoadScale — масштаб load average в sysinfo, 1 << SI_LOAD_SHIFT.
+const sysinfoLoadScale = 1 << 16
 
-func GetStatsOs() (*models.LoadAverage, error) {
-	res, err := tools.Exec("cat", []string{"/proc/loadavg"})
-	if err != nil {
+// GetStatsOs читает loadavg из procfs, а если /proc не смонтирован — sysinfo(2).
+// У настроенного каталога procfs отсутствие loadavg — ошибка конфигурации,
+// sysinfo тогда показал бы загрузку не той машины.
+func GetStatsOs(ctx context.Context, fsys procfs.FS) (*models.LoadAverage, error) {
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	fields := strings.Fields(res)
-
-	load1Min := tools.ParseFloat(fields[load1MinPos])
-	load5Min := tools.ParseFloat(fields[load5MinPos])
-	load15Min := tools.ParseFloat(fields[load15MinPos])
+	data, err := fs.ReadFile(fsys.Proc, "loadavg")
+	if errors.Is(err, fs.ErrNotExist) && fsys.DefaultProc() {
+		return getSysinfo()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseLoadAvg(string(data))
+}
 
+// getSysinfo не знает о выполняемых задачах и последнем PID, они остаются нулевыми.
+func getSysinfo() (*models.LoadAverage, error) {
+	var info syscall.Sysinfo_t
+	if err := syscall.Sysinfo(&info); err != nil {
+		return nil, err
+	}
 	return &models.LoadAverage{
-		Load1Min:  load1Min,
-		Load5Min:  load5Min,
-		Load15Min: load15Min,
+		Load1Min:   float64(info.Loads[0]) / sysinfoLoadScale,
+		Load5Min:   float64(info.Loads[1]) / sysinfoLoadScale,
+		Load15Min:  float64(info.Loads[2]) / sysinfoLoadScale,
+		TotalTasks: int32(info.Procs),
 	}, nil
 }
@@ -3,21 +3,48 @@
 package loadavg
 
 import (
+	"context"
+	"io/fs"
 	"testing"
+	"testing/fstest"
 
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
 	"github.com/stretchr/testify/require"
 )
 
 func TestGetStat(t *testing.T) {
 	t.Run("test success get stats", func(t *testing.T) {
-		load, err := GetStats()
+		load, err := GetStats(context.Background(), procfs.Default())
 
 		require.NoError(t, err)
-		require.NotNil(t, load.Load1Min)
-		require.IsType(t, float64(1), load.Load1Min)
-		require.NotNil(t, load.Load5Min)
-		require.IsType(t, float64(1), load.Load5Min)
-		require.NotNil(t, load.Load1Min)
-		require.IsType(t, float64(1), load.Load1Min)
+		require.GreaterOrEqual(t, load.Load1Min, 0.0)
+		require.GreaterOrEqual(t, load.Load5Min, 0.0)
+		require.GreaterOrEqual(t, load.Load15Min, 0.0)
+		require.Positive(t, load.TotalTasks)
+		require.Positive(t, load.LastPid)
+	})
+
+	t.Run("reads loadavg from procfs root", func(t *testing.T) {
+		proc := fstest.MapFS{"loadavg": {Data: []byte("2.50 1.25 0.75 3/456 7890\n")}}
+
+		load, err := GetStats(context.Background(), procfs.FS{Proc: proc})
+		require.NoError(t, err)
+		require.Equal(t, 2.5, load.Load1Min)
+		require.Equal(t, int32(456), load.TotalTasks)
+		require.Equal(t, int32(7890), load.LastPid)
+	})
+
+	t.Run("sysinfo fallback without procfs", func(t *testing.T) {
+		fsys := procfs.FS{Proc: fstest.MapFS{}, ProcRoot: procfs.DefaultProcRoot}
+		load, err := GetStats(context.Background(), fsys)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, load.Load1Min, 0.0)
+		require.Positive(t, load.TotalTasks)
+		require.Zero(t, load.LastPid)
+	})
+
+	t.Run("missing loadavg under configured root", func(t *testing.T) {
+		_, err := GetStats(context.Background(), procfs.New(t.TempDir(), ""))
+		require.ErrorIs(t, err, fs.ErrNotExist)
 	})
 }
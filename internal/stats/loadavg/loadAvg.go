@@ -1,9 +1,73 @@
 package loadavg
 
-import "github.com/cepmap/otus-system-monitoring/internal/models"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 
-func GetStats() (*models.LoadAverage, error) {
-	loadAvg, err := GetStatsOs()
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+)
+
+const (
+	load1MinPos  = 0
+	load5MinPos  = 1
+	load15MinPos = 2
+	tasksPos     = 3
+	lastPidPos   = 4
+	loadAvgLen   = 5
+)
+
+var errInvalidLoadAvg = errors.New("invalid loadavg")
+
+func GetStats(ctx context.Context, fsys procfs.FS) (*models.LoadAverage, error) {
+	loadAvg, err := GetStatsOs(ctx, fsys)
 
 	return loadAvg, err
 }
+
+// parseLoadAvg разбирает строку /proc/loadavg вида "0.12 0.08 0.05 1/234 5678".
+func parseLoadAvg(data string) (*models.LoadAverage, error) {
+	fields := strings.Fields(data)
+	if len(fields) < loadAvgLen {
+		return nil, fmt.Errorf("%w: expected %d fields, got %d", errInvalidLoadAvg, loadAvgLen, len(fields))
+	}
+
+	var loads [3]float64
+	for i, pos := range []int{load1MinPos, load5MinPos, load15MinPos} {
+		load, err := strconv.ParseFloat(fields[pos], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidLoadAvg, err)
+		}
+		loads[i] = load
+	}
+
+	runnable, total, ok := strings.Cut(fields[tasksPos], "/")
+	if !ok {
+		return nil, fmt.Errorf("%w: tasks %q", errInvalidLoadAvg, fields[tasksPos])
+	}
+	var tasks [2]int64
+	for i, value := range []string{runnable, total} {
+		parsed, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidLoadAvg, err)
+		}
+		tasks[i] = parsed
+	}
+
+	lastPid, err := strconv.ParseInt(fields[lastPidPos], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidLoadAvg, err)
+	}
+
+	return &models.LoadAverage{
+		Load1Min:      loads[0],
+		Load5Min:      loads[1],
+		Load15Min:     loads[2],
+		RunnableTasks: int32(tasks[0]),
+		TotalTasks:    int32(tasks[1]),
+		LastPid:       int32(lastPid),
+	}, nil
+}
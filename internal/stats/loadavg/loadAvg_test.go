@@ -0,0 +1,30 @@
+package loadavg
+
+import (
+	"testing"
+
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLoadAvg(t *testing.T) {
+	t.Run("valid line", func(t *testing.T) {
+		load, err := parseLoadAvg("0.12 0.08 0.05 1/234 5678\n")
+		require.NoError(t, err)
+		require.Equal(t, &models.LoadAverage{
+			Load1Min:      0.12,
+			Load5Min:      0.08,
+			Load15Min:     0.05,
+			RunnableTasks: 1,
+			TotalTasks:    234,
+			LastPid:       5678,
+		}, load)
+	})
+
+	t.Run("malformed input", func(t *testing.T) {
+		for _, data := range []string{"", "0.12 0.08", "0.12 0.08 0.05 1-234 5678", "a 0.08 0.05 1/234 5678", "0.12 0.08 0.05 1/234 x"} {
+			_, err := parseLoadAvg(data)
+			require.ErrorIs(t, err, errInvalidLoadAvg, data)
+		}
+	})
+}
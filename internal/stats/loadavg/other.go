@@ -0,0 +1,17 @@
+//go:build !linux
+
+package loadavg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+)
+
+func GetStatsOs(_ context.Context, _ procfs.FS) (*models.LoadAverage, error) {
+	return nil, fmt.Errorf("load average on %s: %w", runtime.GOOS, errors.ErrUnsupported)
+}
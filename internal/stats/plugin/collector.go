@@ -0,0 +1,123 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/converter"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+	"github.com/cepmap/otus-system-monitoring/internal/tools"
+)
+
+const defaultMaxOutput = 64 * 1024
+
+func init() {
+	stats.Register(collector{})
+}
+
+// collector запускает все плагины из конфигурации и собирает их метрики в один замер.
+type collector struct{}
+
+func (collector) Name() string {
+	return config.StatCustom
+}
+
+func (collector) StatType() pb.StatType {
+	return pb.StatType_CUSTOM
+}
+
+func (collector) Sample(ctx context.Context, cfg config.Stats) (any, error) {
+	results := make([][]models.CustomMetric, len(cfg.Plugins))
+	errs := make([]error, len(cfg.Plugins))
+
+	var wg sync.WaitGroup
+	for i, plugin := range cfg.Plugins {
+		wg.Add(1)
+		go func(i int, plugin config.Plugin) {
+			defer wg.Done()
+			results[i], errs[i] = run(ctx, plugin)
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("plugin %s: %w", plugin.Name, errs[i])
+			}
+		}(i, plugin)
+	}
+	wg.Wait()
+
+	var values []models.CustomMetric
+	for _, result := range results {
+		values = append(values, result...)
+	}
+	if values == nil {
+		return nil, errors.Join(errs...)
+	}
+	return &models.CustomMetrics{Metrics: values}, errors.Join(errs...)
+}
+
+func run(ctx context.Context, plugin config.Plugin) ([]models.CustomMetric, error) {
+	if plugin.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, plugin.Timeout)
+		defer cancel()
+	}
+	maxOutput := plugin.MaxOutput
+	if maxOutput <= 0 {
+		maxOutput = defaultMaxOutput
+	}
+
+	output, err := tools.ExecLimit(ctx, plugin.Command, plugin.Args, maxOutput)
+	if err != nil {
+		return nil, err
+	}
+	return parseOutput(plugin.Name, output)
+}
+
+func (collector) Aggregate(samples []metrics.Sample, weights []float64, agg metrics.Aggregation) any {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	type key struct{ plugin, name string }
+	type metricValues struct {
+		values  []float64
+		weights []float64
+	}
+
+	order := make([]key, 0)
+	series := make(map[key]*metricValues)
+
+	for i, s := range samples {
+		for _, metric := range s.Value.(*models.CustomMetrics).Metrics {
+			k := key{plugin: metric.Plugin, name: metric.Name}
+			if _, ok := series[k]; !ok {
+				series[k] = &metricValues{}
+				order = append(order, k)
+			}
+			series[k].values = append(series[k].values, metric.Value)
+			if i < len(weights) {
+				series[k].weights = append(series[k].weights, weights[i])
+			}
+		}
+	}
+
+	result := make([]models.CustomMetric, 0, len(series))
+	for _, k := range order {
+		values := series[k]
+		result = append(result, models.CustomMetric{
+			Plugin: k.plugin,
+			Name:   k.name,
+			Value:  metrics.Round(metrics.AggregateValues(values.values, values.weights, agg)),
+		})
+	}
+
+	return &models.CustomMetrics{Metrics: result}
+}
+
+func (collector) ToProto(value any, _ stats.Options, response *pb.StatsResponse) {
+	response.CustomMetrics = converter.CustomMetricsToProto(value.(*models.CustomMetrics))
+}
@@ -0,0 +1,69 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/tools"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorSample(t *testing.T) {
+	t.Run("partial failure keeps other plugins", func(t *testing.T) {
+		cfg := config.Stats{Plugins: []config.Plugin{
+			{Name: "queue", Command: "echo", Args: []string{"depth=3"}},
+			{Name: "slow", Command: "sleep", Args: []string{"5"}, Timeout: 100 * time.Millisecond},
+			{Name: "noisy", Command: "head", Args: []string{"-c", "4096", "/dev/zero"}, MaxOutput: 1024},
+		}}
+
+		start := time.Now()
+		value, err := collector{}.Sample(context.Background(), cfg)
+		require.Less(t, time.Since(start), 2*time.Second)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorIs(t, err, tools.ErrOutputTooLarge)
+		require.ErrorContains(t, err, "plugin slow")
+		require.Equal(t, &models.CustomMetrics{Metrics: []models.CustomMetric{
+			{Plugin: "queue", Name: "depth", Value: 3},
+		}}, value)
+	})
+
+	t.Run("all plugins failed", func(t *testing.T) {
+		cfg := config.Stats{Plugins: []config.Plugin{{Name: "bad", Command: "echo", Args: []string{"oops"}}}}
+		value, err := collector{}.Sample(context.Background(), cfg)
+		require.ErrorIs(t, err, ErrInvalidOutput)
+		require.Nil(t, value)
+	})
+
+	t.Run("failure reports stderr", func(t *testing.T) {
+		cfg := config.Stats{Plugins: []config.Plugin{
+			{Name: "db", Command: "sh", Args: []string{"-c", "echo no such table >&2; exit 1"}},
+		}}
+		_, err := collector{}.Sample(context.Background(), cfg)
+		require.ErrorContains(t, err, "plugin db")
+		require.ErrorContains(t, err, "no such table")
+	})
+}
+
+func TestCollectorAggregate(t *testing.T) {
+	require.Nil(t, collector{}.Aggregate(nil, nil, metrics.AggregationMean))
+
+	samples := []metrics.Sample{
+		{Value: &models.CustomMetrics{Metrics: []models.CustomMetric{
+			{Plugin: "queue", Name: "depth", Value: 2},
+			{Plugin: "raid", Name: "degraded", Value: 0},
+		}}},
+		{Value: &models.CustomMetrics{Metrics: []models.CustomMetric{
+			{Plugin: "queue", Name: "depth", Value: 4},
+		}}},
+	}
+
+	result := collector{}.Aggregate(samples, nil, metrics.AggregationMean).(*models.CustomMetrics)
+	require.Equal(t, []models.CustomMetric{
+		{Plugin: "queue", Name: "depth", Value: 3},
+		{Plugin: "raid", Name: "degraded", Value: 0},
+	}, result.Metrics)
+}
@@ -0,0 +1,71 @@
+package plugin
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+)
+
+var ErrInvalidOutput = errors.New("invalid plugin output")
+
+// parseOutput разбирает вывод плагина: JSON-объект {"name": value}
+// или строки "name=value"; пустые строки и строки с # пропускаются.
+func parseOutput(plugin, output string) ([]models.CustomMetric, error) {
+	if strings.HasPrefix(output, "{") {
+		return parseJSON(plugin, output)
+	}
+	return parseKeyValue(plugin, output)
+}
+
+func parseJSON(plugin, output string) ([]models.CustomMetric, error) {
+	var values map[string]float64
+	if err := json.Unmarshal([]byte(output), &values); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOutput, err)
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		if name == "" {
+			return nil, fmt.Errorf("%w: empty metric name", ErrInvalidOutput)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]models.CustomMetric, 0, len(names))
+	for _, name := range names {
+		result = append(result, models.CustomMetric{Plugin: plugin, Name: name, Value: values[name]})
+	}
+	return result, nil
+}
+
+func parseKeyValue(plugin, output string) ([]models.CustomMetric, error) {
+	var result []models.CustomMetric
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(text, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: line %d: expected name=value", ErrInvalidOutput, line)
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidOutput, line, err)
+		}
+		result = append(result, models.CustomMetric{Plugin: plugin, Name: name, Value: parsed})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOutput, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,35 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutput(t *testing.T) {
+	t.Run("key value", func(t *testing.T) {
+		result, err := parseOutput("queue", "# comment\ndepth = 42\n\nlag=1.5")
+		require.NoError(t, err)
+		require.Equal(t, []models.CustomMetric{
+			{Plugin: "queue", Name: "depth", Value: 42},
+			{Plugin: "queue", Name: "lag", Value: 1.5},
+		}, result)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		result, err := parseOutput("raid", `{"failed_disks": 0, "degraded": 1}`)
+		require.NoError(t, err)
+		require.Equal(t, []models.CustomMetric{
+			{Plugin: "raid", Name: "degraded", Value: 1},
+			{Plugin: "raid", Name: "failed_disks", Value: 0},
+		}, result)
+	})
+
+	t.Run("invalid output", func(t *testing.T) {
+		for _, output := range []string{"depth", "=1", "depth=high", `{"depth": "high"}`, `{"": 1}`} {
+			_, err := parseOutput("queue", output)
+			require.ErrorIs(t, err, ErrInvalidOutput, output)
+		}
+	})
+}
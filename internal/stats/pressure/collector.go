@@ -0,0 +1,96 @@
+package pressure
+
+import (
+	"context"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/converter"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+)
+
+// Name — имя статистики в конфигурации, включается через stats.collectors.pressure.enabled.
+const Name = "pressure"
+
+func init() {
+	stats.Register(collector{})
+}
+
+type collector struct{}
+
+func (collector) Name() string {
+	return Name
+}
+
+func (collector) StatType() pb.StatType {
+	return pb.StatType_PRESSURE
+}
+
+func (collector) Sample(ctx context.Context, cfg config.Stats) (any, error) {
+	stats, err := GetStats(ctx, procfs.New(cfg.Procfs, cfg.Sysfs))
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// Aggregate усредняет avg10/avg60/avg300, а вместо счётчика total
+// возвращает время простоя, накопленное между первым и последним замером окна.
+func (collector) Aggregate(samples []metrics.Sample, weights []float64, agg metrics.Aggregation) any {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	first := samples[0].Value.(*models.Pressure)
+	latest := samples[len(samples)-1].Value.(*models.Pressure)
+	if !latest.Supported {
+		return &models.Pressure{Supported: false}
+	}
+
+	stall := func(pick func(p *models.Pressure) models.PressureStall) models.PressureStall {
+		avg10 := make([]float64, 0, len(samples))
+		avg60 := make([]float64, 0, len(samples))
+		avg300 := make([]float64, 0, len(samples))
+		for _, s := range samples {
+			value := pick(s.Value.(*models.Pressure))
+			avg10 = append(avg10, value.Avg10)
+			avg60 = append(avg60, value.Avg60)
+			avg300 = append(avg300, value.Avg300)
+		}
+
+		var total uint64
+		// После смены поддержки PSI или сброса счётчика прирост не считаем.
+		if start, end := pick(first).Total, pick(latest).Total; first.Supported && end >= start {
+			total = end - start
+		}
+		return models.PressureStall{
+			Avg10:  metrics.Round(metrics.AggregateValues(avg10, weights, agg)),
+			Avg60:  metrics.Round(metrics.AggregateValues(avg60, weights, agg)),
+			Avg300: metrics.Round(metrics.AggregateValues(avg300, weights, agg)),
+			Total:  total,
+		}
+	}
+
+	return &models.Pressure{
+		Supported: true,
+		CPU: models.PressureResource{
+			Some: stall(func(p *models.Pressure) models.PressureStall { return p.CPU.Some }),
+			Full: stall(func(p *models.Pressure) models.PressureStall { return p.CPU.Full }),
+		},
+		Memory: models.PressureResource{
+			Some: stall(func(p *models.Pressure) models.PressureStall { return p.Memory.Some }),
+			Full: stall(func(p *models.Pressure) models.PressureStall { return p.Memory.Full }),
+		},
+		IO: models.PressureResource{
+			Some: stall(func(p *models.Pressure) models.PressureStall { return p.IO.Some }),
+			Full: stall(func(p *models.Pressure) models.PressureStall { return p.IO.Full }),
+		},
+	}
+}
+
+func (collector) ToProto(value any, _ stats.Options, response *pb.StatsResponse) {
+	response.Pressure = converter.PressureToProto(value.(*models.Pressure))
+}
@@ -0,0 +1,40 @@
+package pressure
+
+import (
+	"testing"
+
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorAggregate(t *testing.T) {
+	t.Run("empty window", func(t *testing.T) {
+		require.Nil(t, collector{}.Aggregate(nil, nil, metrics.AggregationMean))
+	})
+
+	t.Run("averages and total delta", func(t *testing.T) {
+		cpuAt := func(avg10 float64, total uint64) *models.Pressure {
+			return &models.Pressure{
+				Supported: true,
+				CPU:       models.PressureResource{Some: models.PressureStall{Avg10: avg10, Total: total}},
+			}
+		}
+		samples := []metrics.Sample{
+			{Value: cpuAt(1, 1000)},
+			{Value: cpuAt(2, 1500)},
+			{Value: cpuAt(3, 4000)},
+		}
+
+		result := collector{}.Aggregate(samples, nil, metrics.AggregationMean).(*models.Pressure)
+		require.True(t, result.Supported)
+		require.Equal(t, 2.0, result.CPU.Some.Avg10)
+		require.Equal(t, uint64(3000), result.CPU.Some.Total)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		samples := []metrics.Sample{{Value: &models.Pressure{Supported: false}}}
+		result := collector{}.Aggregate(samples, nil, metrics.AggregationMean)
+		require.Equal(t, &models.Pressure{Supported: false}, result)
+	})
+}
@@ -0,0 +1,92 @@
+//go:build linux
+
+package pressure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+)
+
+var errInvalidPressure = errors.New("invalid pressure data")
+
+// readPressure читает pressure/{cpu,memory,io} из procfs. Без PSI в ядре каталога нет,
+// а при psi=0 чтение возвращает EOPNOTSUPP: в обоих случаях это не ошибка сбора.
+func readPressure(ctx context.Context, proc fs.FS) (*models.Pressure, error) {
+	result := &models.Pressure{Supported: true}
+	resources := []struct {
+		name     string
+		resource *models.PressureResource
+	}{
+		{"cpu", &result.CPU},
+		{"memory", &result.Memory},
+		{"io", &result.IO},
+	}
+
+	for _, r := range resources {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		data, err := fs.ReadFile(proc, path.Join("pressure", r.name))
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.EOPNOTSUPP) {
+			return &models.Pressure{Supported: false}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := parseResource(string(data), r.resource); err != nil {
+			return nil, fmt.Errorf("%s: %w", r.name, err)
+		}
+	}
+	return result, nil
+}
+
+// parseResource разбирает строки вида "some avg10=0.00 avg60=0.00 avg300=0.00 total=0".
+// Строки full для cpu нет в ядрах до 5.13, тогда значения остаются нулевыми.
+func parseResource(data string, resource *models.PressureResource) error {
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var stall *models.PressureStall
+		switch fields[0] {
+		case "some":
+			stall = &resource.Some
+		case "full":
+			stall = &resource.Full
+		default:
+			return fmt.Errorf("%w: unknown line %q", errInvalidPressure, fields[0])
+		}
+
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return fmt.Errorf("%w: %q", errInvalidPressure, field)
+			}
+			var err error
+			switch key {
+			case "avg10":
+				stall.Avg10, err = strconv.ParseFloat(value, 64)
+			case "avg60":
+				stall.Avg60, err = strconv.ParseFloat(value, 64)
+			case "avg300":
+				stall.Avg300, err = strconv.ParseFloat(value, 64)
+			case "total":
+				stall.Total, err = strconv.ParseUint(value, 10, 64)
+			}
+			if err != nil {
+				return fmt.Errorf("%w: %s: %w", errInvalidPressure, key, err)
+			}
+		}
+	}
+	return nil
+}
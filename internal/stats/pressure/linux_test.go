@@ -0,0 +1,52 @@
+//go:build linux
+
+package pressure
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStats(t *testing.T) {
+	stats, err := GetStats(context.Background(), procfs.Default())
+	require.NoError(t, err)
+	require.NotNil(t, stats)
+}
+
+func TestReadPressure(t *testing.T) {
+	t.Run("supported", func(t *testing.T) {
+		proc := fstest.MapFS{
+			"pressure/cpu":    {Data: []byte("some avg10=1.50 avg60=0.75 avg300=0.10 total=123456\n")},
+			"pressure/memory": {Data: []byte("some avg10=0.00 avg60=0.00 avg300=0.00 total=10\nfull avg10=0.00 avg60=0.00 avg300=0.00 total=5\n")},
+			"pressure/io":     {Data: []byte("some avg10=4.00 avg60=2.00 avg300=1.00 total=9000\nfull avg10=3.00 avg60=1.50 avg300=0.50 total=7000\n")},
+		}
+
+		stats, err := readPressure(context.Background(), proc)
+		require.NoError(t, err)
+		require.True(t, stats.Supported)
+		require.Equal(t, models.PressureStall{Avg10: 1.5, Avg60: 0.75, Avg300: 0.1, Total: 123456}, stats.CPU.Some)
+		require.Zero(t, stats.CPU.Full)
+		require.Equal(t, uint64(5), stats.Memory.Full.Total)
+		require.Equal(t, models.PressureStall{Avg10: 3, Avg60: 1.5, Avg300: 0.5, Total: 7000}, stats.IO.Full)
+	})
+
+	t.Run("unsupported kernel", func(t *testing.T) {
+		stats, err := readPressure(context.Background(), fstest.MapFS{})
+		require.NoError(t, err)
+		require.Equal(t, &models.Pressure{Supported: false}, stats)
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		proc := fstest.MapFS{}
+		for _, name := range []string{"cpu", "memory", "io"} {
+			proc["pressure/"+name] = &fstest.MapFile{Data: []byte("some avg10=high\n")}
+		}
+		_, err := readPressure(context.Background(), proc)
+		require.ErrorIs(t, err, errInvalidPressure)
+	})
+}
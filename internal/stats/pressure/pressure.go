@@ -0,0 +1,12 @@
+package pressure
+
+import (
+	"context"
+
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+)
+
+func GetStats(ctx context.Context, fsys procfs.FS) (*models.Pressure, error) {
+	return readPressure(ctx, fsys.Proc)
+}
@@ -1,34 +1,38 @@
 package printer
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
 	"github.com/cepmap/otus-system-monitoring/internal/stats/cpu"
 	"github.com/cepmap/otus-system-monitoring/internal/stats/disksload"
 	"github.com/cepmap/otus-system-monitoring/internal/stats/diskstat"
 	"github.com/cepmap/otus-system-monitoring/internal/stats/loadavg"
 )
 
-func PrintStats() {
-	res, err := disksload.GetStats()
+func PrintStats(ctx context.Context) {
+	fsys := procfs.Default()
+
+	res, err := disksload.GetStats(ctx, fsys)
 	if err != nil {
 		return
 	}
 	fmt.Println(res)
 
-	res1, err := cpu.GetCpuStat()
+	res1, err := cpu.GetCpuStat(ctx, fsys)
 	if err != nil {
 		return
 	}
 	fmt.Println(res1)
 
-	res2, err := loadavg.GetStats()
+	res2, err := loadavg.GetStats(ctx, fsys)
 	if err != nil {
 		return
 	}
 	fmt.Println(res2)
 
-	res3, err := diskstat.GetStats()
+	res3, err := diskstat.GetStats(ctx, fsys, "/")
 	if err != nil {
 		return
 	}
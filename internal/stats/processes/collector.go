@@ -0,0 +1,257 @@
+package processes
+
+import (
+	"context"
+	"io/fs"
+	"math"
+	"os/user"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/converter"
+	"github.com/cepmap/otus-system-monitoring/internal/hostinfo"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+)
+
+// Name — имя статистики в конфигурации, включается через stats.collectors.top_processes.enabled.
+const Name = "top_processes"
+
+const (
+	defaultTopN = 10
+	// maxUsers ограничивает кэш имён пользователей.
+	maxUsers = 1024
+	// minCPUInterval — более частые замеры не пересчитывают загрузку CPU,
+	// иначе одновременные подписки получали бы дельты за миллисекунды.
+	minCPUInterval = 100 * time.Millisecond
+)
+
+func init() {
+	stats.Register(newCollector())
+}
+
+type procKey struct {
+	pid       int32
+	startTime uint64
+}
+
+type cpuState struct {
+	ticks   uint64
+	at      time.Time
+	percent float64
+}
+
+// collector хранит счётчики предыдущего замера, по ним считается загрузка CPU.
+// Имена пользователей кэшируются для каталога procfs usersRoot.
+type collector struct {
+	mu        sync.Mutex
+	prev      map[procKey]cpuState
+	users     map[string]string
+	usersRoot string
+}
+
+func newCollector() *collector {
+	return &collector{
+		prev:  make(map[procKey]cpuState),
+		users: make(map[string]string),
+	}
+}
+
+func (*collector) Name() string {
+	return Name
+}
+
+func (*collector) StatType() pb.StatType {
+	return pb.StatType_TOP_PROCESSES
+}
+
+// Sample хранит только лидеров по CPU и памяти, чтобы окно не разрасталось
+// на хостах с тысячами процессов. Первый замер процесса показывает нулевую загрузку CPU.
+func (c *collector) Sample(ctx context.Context, cfg config.Stats) (any, error) {
+	procs, err := GetStats(ctx, procfs.New(cfg.Procfs, cfg.Sysfs))
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// PID и UID берутся из procfs машины, значит и имена — из её /etc.
+	etc := hostinfo.HostEtc(cfg.Procfs)
+	if cfg.Procfs != c.usersRoot {
+		clear(c.users)
+		c.usersRoot = cfg.Procfs
+	}
+
+	now := time.Now()
+	next := make(map[procKey]cpuState, len(procs))
+	result := make([]models.Process, 0, len(procs))
+	for _, proc := range procs {
+		key := procKey{pid: proc.Pid, startTime: proc.StartTime}
+		state := cpuState{ticks: proc.CPUTicks, at: now}
+		if prev, ok := c.prev[key]; ok {
+			elapsed := now.Sub(prev.at)
+			switch {
+			case elapsed < minCPUInterval:
+				state = prev
+			case proc.CPUTicks >= prev.ticks:
+				state.percent = float64(proc.CPUTicks-prev.ticks) / clockTicks() / elapsed.Seconds() * 100
+			}
+		}
+		next[key] = state
+
+		result = append(result, models.Process{
+			Pid:        proc.Pid,
+			Command:    proc.Command,
+			User:       c.userName(etc, proc.UID),
+			Threads:    proc.Threads,
+			CPUPercent: metrics.Round(state.percent),
+			RSS:        proc.RSS,
+		})
+	}
+	c.prev = next
+
+	return &models.Processes{Processes: leaders(result, stats.MaxTopN)}, nil
+}
+
+func (c *collector) userName(etc fs.FS, uid string) string {
+	if name, ok := c.users[uid]; ok {
+		return name
+	}
+	name := lookupUser(etc, uid)
+	if len(c.users) >= maxUsers {
+		clear(c.users)
+	}
+	c.users[uid] = name
+	return name
+}
+
+// lookupUser ищет пользователя в etc/passwd машины, а без etc — через os/user,
+// который учитывает и NSS текущей системы. Неизвестный uid остаётся числом.
+func lookupUser(etc fs.FS, uid string) string {
+	if etc == nil {
+		if u, err := user.LookupId(uid); err == nil {
+			return u.Username
+		}
+		return uid
+	}
+
+	data, err := fs.ReadFile(etc, "passwd")
+	if err != nil {
+		return uid
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) > 2 && fields[2] == uid {
+			return fields[0]
+		}
+	}
+	return uid
+}
+
+func (*collector) Aggregate(samples []metrics.Sample, weights []float64, agg metrics.Aggregation) any {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	type key struct {
+		pid     int32
+		command string
+	}
+	type procValues struct {
+		latest  models.Process
+		cpu     []float64
+		rss     []float64
+		weights []float64
+	}
+
+	// Процесс усредняется только по замерам, в которых он есть: короткоживущий
+	// процесс не должен размываться замерами, когда его ещё или уже не было.
+	// Рейтинг top_n строится в ToProto уже по агрегированным значениям.
+	order := make([]key, 0)
+	processes := make(map[key]*procValues)
+
+	for i, s := range samples {
+		for _, proc := range s.Value.(*models.Processes).Processes {
+			k := key{pid: proc.Pid, command: proc.Command}
+			if _, ok := processes[k]; !ok {
+				processes[k] = &procValues{}
+				order = append(order, k)
+			}
+			values := processes[k]
+			values.latest = proc
+			values.cpu = append(values.cpu, proc.CPUPercent)
+			values.rss = append(values.rss, float64(proc.RSS))
+			if i < len(weights) {
+				values.weights = append(values.weights, weights[i])
+			}
+		}
+	}
+
+	result := make([]models.Process, 0, len(processes))
+	for _, k := range order {
+		values := processes[k]
+		proc := values.latest
+		proc.CPUPercent = metrics.Round(metrics.AggregateValues(values.cpu, values.weights, agg))
+		proc.RSS = uint64(math.Round(metrics.AggregateValues(values.rss, values.weights, agg)))
+		result = append(result, proc)
+	}
+
+	return &models.Processes{Processes: result}
+}
+
+func (*collector) ToProto(value any, opts stats.Options, response *pb.StatsResponse) {
+	n := opts.TopN
+	if n <= 0 {
+		n = defaultTopN
+	}
+	processes := value.(*models.Processes).Processes
+	response.TopProcesses = &pb.TopProcesses{
+		ByCpu:    converter.ProcessesToProto(byCPU(processes, n)),
+		ByMemory: converter.ProcessesToProto(byMemory(processes, n)),
+	}
+}
+
+// leaders оставляет процессы, входящие в первые n по CPU или по памяти.
+func leaders(processes []models.Process, n int) []models.Process {
+	seen := make(map[int32]struct{}, 2*n)
+	result := make([]models.Process, 0, 2*n)
+	for _, top := range [][]models.Process{byCPU(processes, n), byMemory(processes, n)} {
+		for _, proc := range top {
+			if _, ok := seen[proc.Pid]; !ok {
+				seen[proc.Pid] = struct{}{}
+				result = append(result, proc)
+			}
+		}
+	}
+	return result
+}
+
+func byCPU(processes []models.Process, n int) []models.Process {
+	return topBy(processes, n, func(a, b models.Process) bool { return a.CPUPercent > b.CPUPercent })
+}
+
+func byMemory(processes []models.Process, n int) []models.Process {
+	return topBy(processes, n, func(a, b models.Process) bool { return a.RSS > b.RSS })
+}
+
+func topBy(processes []models.Process, n int, greater func(a, b models.Process) bool) []models.Process {
+	sorted := make([]models.Process, len(processes))
+	copy(sorted, processes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if greater(sorted[i], sorted[j]) {
+			return true
+		}
+		if greater(sorted[j], sorted[i]) {
+			return false
+		}
+		return sorted[i].Pid < sorted[j].Pid
+	})
+	return sorted[:min(n, len(sorted))]
+}
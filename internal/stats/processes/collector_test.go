@@ -0,0 +1,77 @@
+package processes
+
+import (
+	"strconv"
+	"testing"
+	"testing/fstest"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorAggregate(t *testing.T) {
+	c := newCollector()
+	require.Nil(t, c.Aggregate(nil, nil, metrics.AggregationMean))
+
+	samples := []metrics.Sample{
+		{Value: &models.Processes{Processes: []models.Process{
+			{Pid: 1, Command: "postgres", User: "postgres", Threads: 4, CPUPercent: 10, RSS: 300},
+			{Pid: 2, Command: "java", User: "app", Threads: 40, CPUPercent: 90, RSS: 100},
+		}}},
+		{Value: &models.Processes{Processes: []models.Process{
+			{Pid: 1, Command: "postgres", User: "postgres", Threads: 5, CPUPercent: 30, RSS: 500},
+		}}},
+	}
+
+	result := c.Aggregate(samples, nil, metrics.AggregationMean).(*models.Processes)
+	require.Equal(t, []models.Process{
+		{Pid: 1, Command: "postgres", User: "postgres", Threads: 5, CPUPercent: 20, RSS: 400},
+		{Pid: 2, Command: "java", User: "app", Threads: 40, CPUPercent: 90, RSS: 100},
+	}, result.Processes)
+
+	response := &pb.StatsResponse{}
+	c.ToProto(result, stats.Options{TopN: 1}, response)
+	require.Len(t, response.TopProcesses.ByCpu, 1)
+	require.Equal(t, "java", response.TopProcesses.ByCpu[0].Command)
+	require.Len(t, response.TopProcesses.ByMemory, 1)
+	require.Equal(t, "postgres", response.TopProcesses.ByMemory[0].Command)
+}
+
+func TestLeaders(t *testing.T) {
+	processes := []models.Process{
+		{Pid: 1, CPUPercent: 50, RSS: 1},
+		{Pid: 2, CPUPercent: 1, RSS: 50},
+		{Pid: 3, CPUPercent: 2, RSS: 2},
+		{Pid: 4, CPUPercent: 50, RSS: 50},
+	}
+
+	require.Equal(t, []int32{1, 4, 2}, pids(leaders(processes, 2)))
+	require.Len(t, leaders(processes, 10), 4)
+}
+
+func pids(processes []models.Process) []int32 {
+	result := make([]int32, 0, len(processes))
+	for _, proc := range processes {
+		result = append(result, proc.Pid)
+	}
+	return result
+}
+
+func TestLookupUser(t *testing.T) {
+	etc := fstest.MapFS{"passwd": {Data: []byte("root:x:0:0:root:/root:/bin/bash\n" +
+		"postgres:x:999:999::/var/lib/postgresql:/bin/sh\n")}}
+	require.Equal(t, "postgres", lookupUser(etc, "999"))
+	require.Equal(t, "1234", lookupUser(etc, "1234"))
+	require.Equal(t, "999", lookupUser(fstest.MapFS{}, "999"))
+	require.Equal(t, "root", lookupUser(nil, "0"))
+
+	c := newCollector()
+	for uid := range maxUsers + 1 {
+		c.userName(etc, strconv.Itoa(uid))
+	}
+	require.LessOrEqual(t, len(c.users), maxUsers)
+	require.Equal(t, "postgres", c.userName(etc, "999"))
+}
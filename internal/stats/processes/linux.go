@@ -0,0 +1,166 @@
+//go:build linux
+
+package processes
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// atClockTicks — номер записи AT_CLKTCK во вспомогательном векторе процесса.
+	atClockTicks      = 17
+	defaultClockTicks = 100
+)
+
+// clockTicks возвращает USER_HZ, в нём ядро отдаёт время процессов. Значение
+// общее для всего ядра, поэтому читается из вектора собственного процесса.
+var clockTicks = sync.OnceValue(func() float64 {
+	data, err := os.ReadFile("/proc/self/auxv")
+	if err != nil {
+		return defaultClockTicks
+	}
+	return parseClockTicks(data)
+})
+
+// parseClockTicks ищет AT_CLKTCK в auxv: пары машинных слов тип-значение,
+// список заканчивается типом 0.
+func parseClockTicks(auxv []byte) float64 {
+	word := strconv.IntSize / 8
+	read := func(b []byte) uint64 {
+		if word == 8 {
+			return binary.NativeEndian.Uint64(b)
+		}
+		return uint64(binary.NativeEndian.Uint32(b))
+	}
+
+	for i := 0; i+2*word <= len(auxv); i += 2 * word {
+		key, value := read(auxv[i:]), read(auxv[i+word:])
+		if key == 0 {
+			break
+		}
+		if key == atClockTicks && value > 0 {
+			return float64(value)
+		}
+	}
+	return defaultClockTicks
+}
+
+// Номера полей /proc/<pid>/stat после имени команды, считая state нулевым.
+const (
+	utimePos     = 11
+	stimePos     = 12
+	startTimePos = 19
+)
+
+var errInvalidStat = errors.New("invalid process stat")
+
+func readProcesses(ctx context.Context, proc fs.FS) ([]ProcStat, error) {
+	entries, err := fs.ReadDir(proc, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ProcStat, 0, len(entries))
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		pid, err := strconv.ParseInt(entry.Name(), 10, 32)
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		stat, err := readProcess(proc, entry.Name())
+		if err != nil {
+			// Процесс мог завершиться между чтением каталога и его файлов.
+			continue
+		}
+		stat.Pid = int32(pid)
+		result = append(result, stat)
+	}
+	return result, nil
+}
+
+func readProcess(proc fs.FS, dir string) (ProcStat, error) {
+	var stat ProcStat
+
+	data, err := fs.ReadFile(proc, path.Join(dir, "stat"))
+	if err != nil {
+		return stat, err
+	}
+	if err := parseStat(string(data), &stat); err != nil {
+		return stat, err
+	}
+
+	data, err = fs.ReadFile(proc, path.Join(dir, "status"))
+	if err != nil {
+		return stat, err
+	}
+	parseStatus(string(data), &stat)
+	return stat, nil
+}
+
+// parseStat разбирает /proc/<pid>/stat. Имя команды в скобках может содержать
+// пробелы и скобки, поэтому поля отсчитываются от последней закрывающей скобки.
+func parseStat(data string, stat *ProcStat) error {
+	open := strings.IndexByte(data, '(')
+	closing := strings.LastIndexByte(data, ')')
+	if open < 0 || closing < open {
+		return errInvalidStat
+	}
+	stat.Command = data[open+1 : closing]
+
+	fields := strings.Fields(data[closing+1:])
+	if len(fields) <= startTimePos {
+		return fmt.Errorf("%w: %d fields", errInvalidStat, len(fields))
+	}
+	utime, err := strconv.ParseUint(fields[utimePos], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: utime: %w", errInvalidStat, err)
+	}
+	stime, err := strconv.ParseUint(fields[stimePos], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: stime: %w", errInvalidStat, err)
+	}
+	stat.CPUTicks = utime + stime
+	stat.StartTime, err = strconv.ParseUint(fields[startTimePos], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: starttime: %w", errInvalidStat, err)
+	}
+	return nil
+}
+
+// parseStatus достаёт из /proc/<pid>/status владельца, число потоков и RSS.
+// У потоков ядра нет VmRSS, для них RSS остаётся нулевым.
+func parseStatus(data string, stat *ProcStat) {
+	for _, line := range strings.Split(data, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		switch key {
+		case "Uid":
+			stat.UID = fields[0]
+		case "Threads":
+			if threads, err := strconv.ParseInt(fields[0], 10, 32); err == nil {
+				stat.Threads = int32(threads)
+			}
+		case "VmRSS":
+			if rss, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+				stat.RSS = rss * 1024
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+//go:build linux
+
+package processes
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+	"github.com/stretchr/testify/require"
+)
+
+// writeProc добавляет в proc каталог процесса с файлами stat и status.
+func writeProc(proc fstest.MapFS, pid int, command string, ticks uint64, rssKB uint64) {
+	stat := fmt.Sprintf("%d (%s) S 1 1 1 0 -1 4194560 100 0 0 0 %d 0 0 0 20 0 3 0 4242 1000 10",
+		pid, command, ticks)
+	status := fmt.Sprintf("Name:\t%s\nUid:\t0\t0\t0\t0\nThreads:\t3\nVmRSS:\t%d kB\n", command, rssKB)
+	proc[fmt.Sprintf("%d/stat", pid)] = &fstest.MapFile{Data: []byte(stat)}
+	proc[fmt.Sprintf("%d/status", pid)] = &fstest.MapFile{Data: []byte(status)}
+}
+
+func TestGetStats(t *testing.T) {
+	procs, err := GetStats(context.Background(), procfs.Default())
+	require.NoError(t, err)
+
+	var self *ProcStat
+	for i := range procs {
+		if int(procs[i].Pid) == os.Getpid() {
+			self = &procs[i]
+		}
+	}
+	require.NotNil(t, self)
+	require.NotEmpty(t, self.Command)
+	require.Positive(t, self.Threads)
+	require.Positive(t, self.RSS)
+}
+
+func TestReadProcesses(t *testing.T) {
+	proc := fstest.MapFS{
+		"sys": {Mode: fs.ModeDir},
+		"43":  {Mode: fs.ModeDir},
+	}
+	writeProc(proc, 42, "tmux: server (1)", 250, 2048)
+
+	procs, err := readProcesses(context.Background(), proc)
+	require.NoError(t, err)
+	require.Equal(t, []ProcStat{{
+		Pid:       42,
+		StartTime: 4242,
+		Command:   "tmux: server (1)",
+		UID:       "0",
+		Threads:   3,
+		RSS:       2048 * 1024,
+		CPUTicks:  250,
+	}}, procs)
+}
+
+func TestParseStat(t *testing.T) {
+	var stat ProcStat
+	require.ErrorIs(t, parseStat("42 bash S 1", &stat), errInvalidStat)
+	require.ErrorIs(t, parseStat("42 (bash) S 1 1", &stat), errInvalidStat)
+}
+
+func TestParseClockTicks(t *testing.T) {
+	auxv := func(pairs ...uint64) []byte {
+		var data []byte
+		for _, v := range pairs {
+			if strconv.IntSize == 64 {
+				data = binary.NativeEndian.AppendUint64(data, v)
+			} else {
+				data = binary.NativeEndian.AppendUint32(data, uint32(v))
+			}
+		}
+		return data
+	}
+
+	require.Equal(t, 250.0, parseClockTicks(auxv(6, 4096, atClockTicks, 250, 0, 0)))
+	require.Equal(t, 100.0, parseClockTicks(auxv(6, 4096, 0, 0, atClockTicks, 250)))
+	require.Equal(t, 100.0, parseClockTicks(nil))
+	require.Positive(t, clockTicks())
+}
+
+func TestCollectorSample(t *testing.T) {
+	// Сборщик открывает procfs по пути из конфигурации, поэтому фикстура копируется
+	// на диск как корень машины: procfs в proc, пользователи в etc/passwd.
+	procRoot := func(ticks uint64) string {
+		host := fstest.MapFS{"etc/passwd": {Data: []byte("root:x:0:0:root:/root:/bin/bash\n")}}
+		proc := fstest.MapFS{}
+		writeProc(proc, 7, "worker", ticks, 1024)
+		for name, file := range proc {
+			host["proc/"+name] = file
+		}
+		root := t.TempDir()
+		require.NoError(t, os.CopyFS(root, host))
+		return filepath.Join(root, "proc")
+	}
+	c := newCollector()
+
+	value, err := c.Sample(context.Background(), config.Stats{Procfs: procRoot(100)})
+	require.NoError(t, err)
+	first := value.(*models.Processes).Processes
+	require.Len(t, first, 1)
+	require.Zero(t, first[0].CPUPercent)
+	require.Equal(t, "root", first[0].User)
+	require.Equal(t, uint64(1024*1024), first[0].RSS)
+
+	// Пересчёт загрузки не раньше minCPUInterval.
+	time.Sleep(2 * minCPUInterval)
+	value, err = c.Sample(context.Background(), config.Stats{Procfs: procRoot(110)})
+	require.NoError(t, err)
+	cpu := value.(*models.Processes).Processes[0].CPUPercent
+	require.Positive(t, cpu)
+	require.LessOrEqual(t, cpu, 50.0)
+}
@@ -0,0 +1,24 @@
+package processes
+
+import (
+	"context"
+
+	"github.com/cepmap/otus-system-monitoring/internal/procfs"
+)
+
+// ProcStat — счётчики одного процесса на момент чтения /proc.
+type ProcStat struct {
+	Pid       int32
+	StartTime uint64
+	Command   string
+	UID       string
+	Threads   int32
+	// RSS в байтах.
+	RSS uint64
+	// CPUTicks — суммарное время процесса в user и system, в тиках.
+	CPUTicks uint64
+}
+
+func GetStats(ctx context.Context, fsys procfs.FS) ([]ProcStat, error) {
+	return readProcesses(ctx, fsys.Proc)
+}
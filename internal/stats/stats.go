@@ -0,0 +1,90 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/metrics"
+)
+
+// Collector описывает один вид статистики: как её снять, агрегировать за окно
+// и положить в ответ клиенту. Пакеты статистики регистрируют свои сборщики в init.
+type Collector interface {
+	// Name — имя статистики в конфигурации и ключ хранилища замеров.
+	Name() string
+	StatType() pb.StatType
+	// Sample снимает один замер. Если удалось собрать только часть данных,
+	// возвращается и значение, и ошибка.
+	Sample(ctx context.Context, cfg config.Stats) (any, error)
+	metrics.Aggregator
+	// ToProto заполняет ответ агрегированным значением, полученным от Aggregate.
+	ToProto(value any, opts Options, response *pb.StatsResponse)
+}
+
+// MaxTopN ограничивает количество записей, которое клиент может запросить в рейтингах.
+const MaxTopN = 100
+
+// Options — параметры запроса клиента, которые влияют на содержимое ответа.
+type Options struct {
+	TopN int
+}
+
+var (
+	mu         sync.RWMutex
+	collectors = make(map[string]Collector)
+)
+
+// Register добавляет сборщик в реестр. Повторная регистрация имени или типа — ошибка программы.
+func Register(c Collector) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := collectors[c.Name()]; ok {
+		panic(fmt.Sprintf("stats: collector %s registered twice", c.Name()))
+	}
+	for _, registered := range collectors {
+		if registered.StatType() == c.StatType() {
+			panic(fmt.Sprintf("stats: stat type %s registered twice", c.StatType()))
+		}
+	}
+	collectors[c.Name()] = c
+}
+
+func Lookup(statType pb.StatType) (Collector, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, c := range collectors {
+		if c.StatType() == statType {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+func ByName(name string) (Collector, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	c, ok := collectors[name]
+	return c, ok
+}
+
+// All возвращает зарегистрированные сборщики в порядке типов статистики.
+func All() []Collector {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	result := make([]Collector, 0, len(collectors))
+	for _, c := range collectors {
+		result = append(result, c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StatType() < result[j].StatType()
+	})
+	return result
+}
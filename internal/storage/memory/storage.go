@@ -6,7 +6,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/cepmap/otus-system-monitoring/internal/config"
 	"github.com/cepmap/otus-system-monitoring/internal/logger"
 	"github.com/cepmap/otus-system-monitoring/internal/storage"
 )
@@ -22,8 +21,9 @@ type MemoryStorage struct {
 	size int64
 }
 
-func New() *MemoryStorage {
-	return &MemoryStorage{rwm: sync.RWMutex{}, list: list.New(), size: config.DaemonConfig.Stats.Limit + 1}
+// New создаёт хранилище, в котором держится не больше size последних замеров.
+func New(size int64) *MemoryStorage {
+	return &MemoryStorage{rwm: sync.RWMutex{}, list: list.New(), size: size}
 }
 
 func (ms *MemoryStorage) SetSize(owner string, newsize int64) {
@@ -31,6 +31,9 @@ func (ms *MemoryStorage) SetSize(owner string, newsize int64) {
 	defer ms.rwm.Unlock()
 
 	ms.size = newsize
+	for int64(ms.list.Len()) > ms.size {
+		ms.list.Remove(ms.list.Back())
+	}
 	logger.Info(fmt.Sprintf("[%s] changed size of storage. New size: %d", owner, newsize))
 }
 
@@ -41,7 +44,7 @@ func (ms *MemoryStorage) Push(s interface{}, t time.Time) {
 	if ms.size == 0 {
 		return
 	}
-	if ms.list.Len() == int(ms.size) {
+	for int64(ms.list.Len()) >= ms.size {
 		ms.list.Remove(ms.list.Back())
 	}
 	ms.list.PushFront(element{timestamp: t, data: s})
@@ -65,6 +68,24 @@ func (ms *MemoryStorage) GetElementsAt(t time.Time) <-chan interface{} {
 	return elemCh
 }
 
+func (ms *MemoryStorage) GetItemsAt(t time.Time) <-chan storage.Item {
+	itemCh := make(chan storage.Item)
+	go func() {
+		ms.rwm.RLock()
+		defer close(itemCh)
+		defer ms.rwm.RUnlock()
+		for last := ms.list.Front(); last != nil; last = last.Next() {
+			elem := last.Value.(element)
+			if t.After(elem.timestamp) {
+				return
+			}
+			itemCh <- storage.Item{Timestamp: elem.timestamp, Data: elem.data}
+		}
+	}()
+
+	return itemCh
+}
+
 func (ms *MemoryStorage) GetElements(num int64) <-chan interface{} {
 	elemCh := make(chan interface{})
 	go func() {
@@ -143,20 +164,24 @@ func (ms *MemoryStorage) Remove(value interface{}) bool {
 	return false
 }
 
-func (ms *MemoryStorage) Clean(t time.Time) {
+// Clean удаляет замеры старше t и возвращает их количество.
+func (ms *MemoryStorage) Clean(t time.Time) int {
 	ms.rwm.Lock()
 	defer ms.rwm.Unlock()
 
+	count := 0
 	for e := ms.list.Back(); e != nil; {
 		elem := e.Value.(element)
 		if t.After(elem.timestamp) {
 			next := e.Prev()
 			ms.list.Remove(e)
 			e = next
+			count++
 		} else {
 			e = e.Prev()
 		}
 	}
+	return count
 }
 
 var _ storage.Storage = (*MemoryStorage)(nil)
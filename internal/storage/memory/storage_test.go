@@ -5,25 +5,41 @@ import (
 	"testing"
 	"time"
 
-	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/storage"
 	"github.com/stretchr/testify/require"
 )
 
 func TestStorage(t *testing.T) {
 	t.Parallel()
 
-	config.DaemonConfig = &config.Config{}
-	config.DaemonConfig.Stats.Limit = 1000
+	const size = 1001
 
 	t.Run("change size storage", func(t *testing.T) {
-		ms := New()
+		ms := New(size)
 		sizeStart := ms.size
 		ms.SetSize("self", ms.size+100)
 
 		require.NotEqual(t, sizeStart, ms.size)
 	})
+	t.Run("shrink storage", func(t *testing.T) {
+		ms := New(size)
+		start := time.Now()
+		for i := 0; i < 10; i++ {
+			ms.Push(i, start.Add(time.Duration(i)*time.Second))
+		}
+
+		ms.SetSize("self", 3)
+		require.Equal(t, 3, ms.list.Len())
+
+		ms.Push(10, start.Add(10*time.Second))
+		values := make([]interface{}, 0, 3)
+		for value := range ms.GetElementsAt(start) {
+			values = append(values, value)
+		}
+		require.Equal(t, []interface{}{10, 9, 8}, values)
+	})
 	t.Run("date at", func(t *testing.T) {
-		ms := New()
+		ms := New(size)
 		data := struct{ some string }{some: "some"}
 		for i := 0; i < 200; i++ {
 			ms.Push(data, time.Now())
@@ -35,11 +51,28 @@ func TestStorage(t *testing.T) {
 		}
 		require.Equal(t, 0, actC)
 	})
+	t.Run("items at", func(t *testing.T) {
+		ms := New(size)
+		start := time.Now()
+		ms.Push("old", start.Add(-time.Minute))
+		ms.Push("first", start)
+		ms.Push("second", start.Add(time.Second))
+
+		items := make([]storage.Item, 0, 2)
+		for item := range ms.GetItemsAt(start) {
+			items = append(items, item)
+		}
+
+		require.Equal(t, []storage.Item{
+			{Timestamp: start.Add(time.Second), Data: "second"},
+			{Timestamp: start, Data: "first"},
+		}, items)
+	})
 	t.Run("storage size limit", func(t *testing.T) {
 		dStart := time.Now()
 		tSize := 50
 
-		ms := New()
+		ms := New(size)
 		ms.SetSize("self", int64(tSize))
 
 		data := struct{ some string }{some: "some"}
@@ -60,10 +93,10 @@ func TestStorage(t *testing.T) {
 		dStart := time.Now()
 		tSize := 500
 
-		ms1 := New()
+		ms1 := New(size)
 		ms1.SetSize("self", int64(tSize))
 
-		ms2 := New()
+		ms2 := New(size)
 		ms2.SetSize("self", int64(tSize))
 
 		data := struct{ some string }{some: "some"}
@@ -7,12 +7,20 @@ import (
 
 var ErrEpmtyStorage = errors.New("empty storage")
 
+type Item struct {
+	Timestamp time.Time
+	Data      interface{}
+}
+
 type Storage interface {
 	Push(item interface{}, timestamp time.Time)
 	GetElementsAt(from time.Time) <-chan interface{}
+	GetItemsAt(from time.Time) <-chan Item
 	GetTimestamp(item interface{}) (time.Time, bool)
 	Remove(item interface{}) bool
+	Clean(before time.Time) int
 	GetElements(int64) <-chan interface{}
 	StoreAt() <-chan interface{}
+	SetSize(owner string, size int64)
 	Show()
 }
@@ -1,23 +1,96 @@
 package tools
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strconv"
 	"strings"
+	"time"
 )
 
-var ExecCommand = Exec
+// WaitDelay — время, которое даётся процессу на завершение после отмены контекста,
+// прежде чем Exec перестанет ждать его вывод. Процесс в D-состоянии (например, df
+// на зависшем NFS) не реагирует даже на SIGKILL, а фоновый потомок держит открытым
+// канал вывода и после выхода самой команды.
+const WaitDelay = time.Second
 
-func Exec(command string, args []string) (string, error) {
-	cmd := exec.Command(command, args...)
+// maxStderr ограничивает часть stderr, которая попадает в ошибку команды.
+const maxStderr = 4 * 1024
+
+// Exec запускает команду и убивает её при отмене ctx.
+func Exec(ctx context.Context, command string, args []string) (string, error) {
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.WaitDelay = WaitDelay
 	output, err := cmd.Output()
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", fmt.Errorf("%s: %w", command, ctxErr)
+		}
 		return "", err
 	}
 	return strings.TrimSpace(string(output)), nil
 }
 
+var ErrOutputTooLarge = errors.New("output is too large")
+
+// ExecLimit запускает команду как Exec, но не хранит больше limit байт вывода.
+// Вывод сверх лимита дочитывается и отбрасывается, чтобы команда не зависла на записи.
+// Если команда завершилась с ошибкой, в ошибку добавляется начало её stderr.
+func ExecLimit(ctx context.Context, command string, args []string, limit int64) (string, error) {
+	stdout := NewLimitedBuffer(limit)
+	stderr := NewLimitedBuffer(maxStderr)
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.WaitDelay = WaitDelay
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", fmt.Errorf("%s: %w", command, ctxErr)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %w: %s", command, err, msg)
+		}
+		return "", err
+	}
+	if stdout.Overflow() {
+		return "", fmt.Errorf("%s: %w: limit is %d bytes", command, ErrOutputTooLarge, limit)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// LimitedBuffer хранит не больше limit байт записанного, остальное отбрасывает.
+// Он не встраивает bytes.Buffer: иначе io.Copy возьмёт его ReadFrom в обход лимита.
+type LimitedBuffer struct {
+	buf      bytes.Buffer
+	limit    int64
+	overflow bool
+}
+
+func NewLimitedBuffer(limit int64) *LimitedBuffer {
+	return &LimitedBuffer{limit: limit}
+}
+
+func (b *LimitedBuffer) Write(p []byte) (int, error) {
+	if rest := b.limit - int64(b.buf.Len()); int64(len(p)) > rest {
+		b.overflow = true
+		b.buf.Write(p[:max(rest, 0)])
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *LimitedBuffer) String() string {
+	return b.buf.String()
+}
+
+// Overflow сообщает, что часть записанного не поместилась в буфер.
+func (b *LimitedBuffer) Overflow() bool {
+	return b.overflow
+}
+
 func CheckCommand(name string) error {
 	_, err := exec.LookPath(name)
 	if err != nil {
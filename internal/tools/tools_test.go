@@ -1,9 +1,13 @@
 package tools
 
 import (
+	"context"
 	"errors"
 	"os/exec"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
 )
 
 type TestCommand struct {
@@ -104,6 +108,36 @@ func TestExec(t *testing.T) {
 	}
 }
 
+func TestExecTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := Exec(ctx, "sleep", []string{"5"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestExecLimit(t *testing.T) {
+	t.Run("output within limit", func(t *testing.T) {
+		output, err := ExecLimit(context.Background(), "echo", []string{"hello"}, 16)
+		require.NoError(t, err)
+		require.Equal(t, "hello", output)
+	})
+
+	t.Run("output over limit", func(t *testing.T) {
+		_, err := ExecLimit(context.Background(), "head", []string{"-c", "100000", "/dev/zero"}, 1024)
+		require.ErrorIs(t, err, ErrOutputTooLarge)
+	})
+
+	t.Run("failure includes stderr", func(t *testing.T) {
+		_, err := ExecLimit(context.Background(), "sh", []string{"-c", "echo connection refused >&2; exit 3"}, 1024)
+		var exitErr *exec.ExitError
+		require.ErrorAs(t, err, &exitErr)
+		require.ErrorContains(t, err, "exit status 3: connection refused")
+	})
+}
+
 func TestParse(t *testing.T) {
 	tests := []struct {
 		name     string
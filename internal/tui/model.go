@@ -0,0 +1,273 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+)
+
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+
+	barWidth = 30
+	// maxRows ограничивает число строк в списках процессов и cgroups.
+	maxRows = 5
+
+	keyCtrlC = 3
+
+	colorReset  = "\x1b[0m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+	colorBold   = "\x1b[1m"
+	colorDim    = "\x1b[2m"
+	colorInvert = "\x1b[7m"
+)
+
+const footer = "q quit  +/- interval  [/] averaging  a aggregation  1-9 toggle stats"
+
+// Model — состояние дашборда: параметры подписки и последний ответ сервера.
+type Model struct {
+	Addr     string
+	Request  *pb.StatsRequest
+	Response *pb.StatsResponse
+	// Status описывает состояние соединения, пустой — поток работает.
+	Status string
+}
+
+// HandleKey применяет нажатую клавишу. changed сообщает, что запрос изменился
+// и поток нужно открыть заново, quit — что пользователь выходит.
+func (m *Model) HandleKey(key byte) (changed, quit bool) {
+	req := m.Request
+	switch {
+	case key == 'q' || key == keyCtrlC:
+		return false, true
+	case key == '+' || key == '=':
+		req.IntervalN++
+	case key == '-' && req.IntervalN > 1:
+		req.IntervalN--
+	case key == ']':
+		req.AveragingPeriodM++
+	case key == '[' && req.AveragingPeriodM > 1:
+		req.AveragingPeriodM--
+	case key == 'a':
+		req.Aggregation = pb.Aggregation((int32(req.Aggregation) + 1) % int32(len(pb.Aggregation_name)))
+	case key >= '1' && key <= '9':
+		statType := pb.StatType(key - '1')
+		if _, ok := pb.StatType_name[int32(statType)]; !ok {
+			return false, false
+		}
+		return m.toggle(statType), false
+	default:
+		return false, false
+	}
+	return true, false
+}
+
+// toggle включает или выключает тип статистики; последний включённый не выключается.
+func (m *Model) toggle(statType pb.StatType) bool {
+	types := m.Request.StatTypes
+	for i, t := range types {
+		if t == statType {
+			if len(types) == 1 {
+				return false
+			}
+			m.Request.StatTypes = append(types[:i:i], types[i+1:]...)
+			return true
+		}
+	}
+	m.Request.StatTypes = append(types, statType)
+	return true
+}
+
+func (m *Model) enabled(statType pb.StatType) bool {
+	for _, t := range m.Request.StatTypes {
+		if t == statType {
+			return true
+		}
+	}
+	return false
+}
+
+// Render строит кадр из строк не длиннее width; подсказка по клавишам всегда
+// остаётся последней строкой.
+func (m *Model) Render(width, height int) []string {
+	lines := m.header()
+	lines = append(lines, "")
+	if m.Response == nil {
+		lines = append(lines, colorDim+"waiting for data..."+colorReset)
+	} else {
+		lines = append(lines, m.body()...)
+	}
+
+	if len(lines) > height-1 {
+		lines = lines[:max(height-1, 0)]
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, colorDim+footer+colorReset)
+
+	for i, line := range lines {
+		lines[i] = truncate(line, width)
+	}
+	return lines
+}
+
+func (m *Model) header() []string {
+	req := m.Request
+	title := fmt.Sprintf("%ssysmon%s %s  interval %ds  averaging %ds  %s",
+		colorBold, colorReset, m.Addr, req.IntervalN, req.AveragingPeriodM, req.Aggregation)
+	if m.Response != nil {
+		title += "  " + time.Unix(m.Response.GetTimestamp(), 0).Format(time.TimeOnly)
+	}
+	if m.Status != "" {
+		title += "  " + colorYellow + m.Status + colorReset
+	}
+
+	toggles := make([]string, 0, len(pb.StatType_name))
+	for i := range int32(len(pb.StatType_name)) {
+		statType := pb.StatType(i)
+		label := fmt.Sprintf("%d:%s", i+1, statType)
+		if m.enabled(statType) {
+			label = colorInvert + label + colorReset
+		}
+		toggles = append(toggles, label)
+	}
+	return []string{title, strings.Join(toggles, " ")}
+}
+
+func (m *Model) body() []string {
+	resp := m.Response
+	var lines []string
+	section := func(title string, rows ...string) {
+		if len(rows) > 0 {
+			lines = append(lines, colorBold+title+colorReset)
+			lines = append(lines, rows...)
+		}
+	}
+
+	if la := resp.GetLoadAverage(); la != nil {
+		section("Load average", fmt.Sprintf("  %.2f %.2f %.2f   tasks %d/%d",
+			la.GetLoad1Min(), la.GetLoad5Min(), la.GetLoad15Min(), la.GetRunnableTasks(), la.GetTotalTasks()))
+	}
+
+	if cpu := resp.GetCpuStats(); cpu != nil {
+		section("CPU",
+			fmt.Sprintf("  user   %s %5.1f%%", bar(cpu.GetUser()), cpu.GetUser()),
+			fmt.Sprintf("  system %s %5.1f%%", bar(cpu.GetSystem()), cpu.GetSystem()),
+			fmt.Sprintf("  idle   %s %5.1f%%", bar(cpu.GetIdle()), cpu.GetIdle()))
+	}
+
+	var rows []string
+	for _, disk := range resp.GetDisksLoad().GetDisksLoad() {
+		rows = append(rows, fmt.Sprintf("  %-16s %10.2f %12.2f", clip(disk.GetFsName(), 16), disk.GetTps(), disk.GetKps()))
+	}
+	if len(rows) > 0 {
+		section("Disk I/O", append([]string{fmt.Sprintf("  %-16s %10s %12s", "DEVICE", "TPS", "KB/S")}, rows...)...)
+	}
+
+	rows = nil
+	for _, disk := range resp.GetDiskStats().GetDiskStats() {
+		usage := disk.GetUsage().GetUsagePercent()
+		rows = append(rows, fmt.Sprintf("  %-20s %s %5.1f%%  inodes %5.1f%%",
+			clip(disk.GetMountPoint(), 20), bar(usage), usage, disk.GetInodes().GetUsagePercent()))
+	}
+	section("Filesystems", rows...)
+
+	if pressure := resp.GetPressure(); pressure.GetSupported() {
+		section("Pressure (some avg10)", fmt.Sprintf("  cpu %.2f  memory %.2f  io %.2f",
+			pressure.GetCpu().GetSome().GetAvg10(),
+			pressure.GetMemory().GetSome().GetAvg10(),
+			pressure.GetIo().GetSome().GetAvg10()))
+	}
+
+	rows = nil
+	for _, process := range head(resp.GetTopProcesses().GetByCpu()) {
+		rows = append(rows, fmt.Sprintf("  %7d %-20s %6.1f%% %10d KB",
+			process.GetPid(), clip(process.GetCommand(), 20), process.GetCpuPercent(), process.GetRssBytes()/1024))
+	}
+	section("Top processes by CPU", rows...)
+
+	rows = nil
+	for _, cgroup := range head(resp.GetCgroups().GetCgroups()) {
+		rows = append(rows, fmt.Sprintf("  %-30s %6.1f%% %10d KB",
+			clip(cgroup.GetPath(), 30), cgroup.GetCpuPercent(), cgroup.GetMemoryCurrentBytes()/1024))
+	}
+	section("Cgroups", rows...)
+
+	rows = nil
+	for _, metric := range resp.GetCustomMetrics() {
+		rows = append(rows, fmt.Sprintf("  %s/%s %g", metric.GetPlugin(), metric.GetName(), metric.GetValue()))
+	}
+	section("Custom metrics", rows...)
+
+	if self := resp.GetDaemonSelf(); self != nil {
+		section("Daemon", fmt.Sprintf("  goroutines %d  heap %d KB  streams %d",
+			self.GetGoroutines(), self.GetHeapBytes()/1024, self.GetActiveStreams()))
+	}
+
+	rows = nil
+	for _, collectErr := range resp.GetErrors() {
+		rows = append(rows, fmt.Sprintf("  %s%s: %s (%d failures)%s", colorRed,
+			collectErr.GetStatType(), collectErr.GetMessage(), collectErr.GetConsecutiveFailures(), colorReset))
+	}
+	if disabled := resp.GetDisabledStatTypes(); len(disabled) > 0 {
+		rows = append(rows, fmt.Sprintf("  %sdisabled on server: %v%s", colorYellow, disabled, colorReset))
+	}
+	section("Problems", rows...)
+
+	return lines
+}
+
+func head[T any](items []T) []T {
+	return items[:min(len(items), maxRows)]
+}
+
+// bar рисует полосу заполнения для процента; цвет зависит от заполнения.
+func bar(percent float64) string {
+	filled := int(min(max(percent, 0), 100) / 100 * barWidth)
+	color := colorGreen
+	switch {
+	case percent >= 90:
+		color = colorRed
+	case percent >= 70:
+		color = colorYellow
+	}
+	return "[" + color + strings.Repeat("#", filled) + colorReset + strings.Repeat(".", barWidth-filled) + "]"
+}
+
+// clip укорачивает имя до ширины колонки, оставляя конец: он различает пути.
+func clip(name string, width int) string {
+	runes := []rune(name)
+	if len(runes) <= width {
+		return name
+	}
+	return "…" + string(runes[len(runes)-width+1:])
+}
+
+// truncate обрезает строку до width видимых символов, не считая ANSI-последовательности.
+func truncate(line string, width int) string {
+	visible := 0
+	for i := 0; i < len(line); {
+		if line[i] == '\x1b' {
+			end := strings.IndexByte(line[i:], 'm')
+			if end < 0 {
+				return line[:i]
+			}
+			i += end + 1
+			continue
+		}
+		if visible == width {
+			return line[:i] + colorReset
+		}
+		_, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+		visible++
+	}
+	return line
+}
@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/stretchr/testify/require"
+)
+
+var ansi = regexp.MustCompile("\x1b\\[[0-9;?]*[a-zA-Z]")
+
+func plain(lines []string) []string {
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = ansi.ReplaceAllString(line, "")
+	}
+	return result
+}
+
+func testModel() *Model {
+	return &Model{
+		Addr: "localhost:8080",
+		Request: &pb.StatsRequest{
+			IntervalN:        1,
+			AveragingPeriodM: 2,
+			StatTypes:        []pb.StatType{pb.StatType_LOAD_AVERAGE, pb.StatType_CPU_STATS},
+		},
+	}
+}
+
+func TestHandleKey(t *testing.T) {
+	t.Run("interval and averaging", func(t *testing.T) {
+		m := testModel()
+		for _, key := range []byte("++-]][") {
+			changed, quit := m.HandleKey(key)
+			require.True(t, changed)
+			require.False(t, quit)
+		}
+		require.Equal(t, int32(2), m.Request.IntervalN)
+		require.Equal(t, int32(3), m.Request.AveragingPeriodM)
+
+		changed, _ := m.HandleKey('-')
+		require.True(t, changed)
+		changed, _ = m.HandleKey('-')
+		require.False(t, changed, "интервал не меньше секунды")
+		require.Equal(t, int32(1), m.Request.IntervalN)
+	})
+
+	t.Run("aggregation cycles", func(t *testing.T) {
+		m := testModel()
+		for range len(pb.Aggregation_name) - 1 {
+			m.HandleKey('a')
+		}
+		require.Equal(t, pb.Aggregation_LAST, m.Request.Aggregation)
+		m.HandleKey('a')
+		require.Equal(t, pb.Aggregation_MEAN, m.Request.Aggregation)
+	})
+
+	t.Run("toggle stat types", func(t *testing.T) {
+		m := testModel()
+		changed, _ := m.HandleKey('3')
+		require.True(t, changed)
+		require.Equal(t, []pb.StatType{pb.StatType_LOAD_AVERAGE, pb.StatType_CPU_STATS, pb.StatType_DISKS_LOAD},
+			m.Request.StatTypes)
+
+		m.HandleKey('1')
+		m.HandleKey('2')
+		require.Equal(t, []pb.StatType{pb.StatType_DISKS_LOAD}, m.Request.StatTypes)
+		changed, _ = m.HandleKey('3')
+		require.False(t, changed, "последний тип не выключается")
+		require.Equal(t, []pb.StatType{pb.StatType_DISKS_LOAD}, m.Request.StatTypes)
+	})
+
+	t.Run("quit and unknown keys", func(t *testing.T) {
+		m := testModel()
+		_, quit := m.HandleKey('q')
+		require.True(t, quit)
+		_, quit = m.HandleKey(keyCtrlC)
+		require.True(t, quit)
+		changed, quit := m.HandleKey('x')
+		require.False(t, changed)
+		require.False(t, quit)
+	})
+}
+
+func TestRender(t *testing.T) {
+	t.Run("waiting", func(t *testing.T) {
+		m := testModel()
+		m.Status = "connecting"
+		lines := plain(m.Render(120, 10))
+		require.Len(t, lines, 10)
+		require.Contains(t, lines[0], "localhost:8080  interval 1s  averaging 2s  MEAN  connecting")
+		require.Contains(t, lines[1], "1:LOAD_AVERAGE 2:CPU_STATS 3:DISKS_LOAD")
+		require.Equal(t, "waiting for data...", lines[3])
+		require.Equal(t, footer, lines[9])
+	})
+
+	t.Run("dashboard", func(t *testing.T) {
+		m := testModel()
+		m.Response = &pb.StatsResponse{
+			LoadAverage: &pb.LoadAverage{Load1Min: 0.5, Load5Min: 0.25, Load15Min: 0.1, RunnableTasks: 2, TotalTasks: 300},
+			CpuStats:    &pb.CPUStat{User: 50, System: 10, Idle: 40},
+			DisksLoad:   &pb.DisksLoad{DisksLoad: []*pb.DiskLoad{{FsName: "sda", Tps: 1.5, Kps: 100}}},
+			DiskStats: &pb.DiskStats{DiskStats: []*pb.DiskStat{{
+				MountPoint: "/", Usage: &pb.DiskUsage{UsagePercent: 95}, Inodes: &pb.InodeUsage{UsagePercent: 10},
+			}}},
+			Errors: []*pb.CollectionError{{StatType: pb.StatType_CPU_STATS, Message: "boom", ConsecutiveFailures: 3}},
+		}
+		out := strings.Join(plain(m.Render(120, 40)), "\n")
+		require.Contains(t, out, "  0.50 0.25 0.10   tasks 2/300")
+		require.Contains(t, out, "  user   [###############...............]  50.0%")
+		require.Contains(t, out, "  sda                    1.50       100.00")
+		require.Contains(t, out, "  /                    [############################..]  95.0%  inodes  10.0%")
+		require.Contains(t, out, "  CPU_STATS: boom (3 failures)")
+	})
+
+	t.Run("fits the terminal", func(t *testing.T) {
+		m := testModel()
+		m.Response = &pb.StatsResponse{CpuStats: &pb.CPUStat{User: 100}}
+		lines := m.Render(20, 5)
+		require.Len(t, lines, 5)
+		for _, line := range plain(lines) {
+			require.LessOrEqual(t, utf8.RuneCountInString(line), 20)
+		}
+		require.Equal(t, footer[:20], plain(lines)[4])
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	require.Equal(t, "abc", truncate("abc", 5))
+	require.Equal(t, "ab"+colorReset, truncate("abc", 2))
+	require.Equal(t, colorRed+"ab"+colorReset, truncate(colorRed+"abc"+colorReset, 2))
+	require.Equal(t, "жё"+colorReset, truncate("жёл", 2))
+}
+
+func TestClip(t *testing.T) {
+	require.Equal(t, "/home", clip("/home", 5))
+	require.Equal(t, "…/data", clip("/mnt/data", 6))
+}
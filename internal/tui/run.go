@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/pkg/statsclient"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+)
+
+type event struct {
+	// generation — номер подписки; ответы прежних подписок отбрасываются.
+	generation int64
+	resp       *pb.StatsResponse
+	status     string
+}
+
+// Run показывает дашборд во весь экран терминала до нажатия q или отмены ctx.
+// Изменение параметров с клавиатуры переоткрывает поток с новым запросом.
+func Run(ctx context.Context, addr string, req *pb.StatsRequest, opts ...statsclient.Option) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events := make(chan event)
+	emit := func(e event) {
+		select {
+		case events <- e:
+		case <-ctx.Done():
+		}
+	}
+
+	// Переподключается только поток текущей подписки: прежние отменены.
+	var generation atomic.Int64
+	opts = append(opts, statsclient.OnRetry(func(err error, delay time.Duration) {
+		emit(event{
+			generation: generation.Load(),
+			status:     fmt.Sprintf("reconnecting in %s: %v", delay.Round(time.Millisecond), err),
+		})
+	}))
+	client, err := statsclient.New(addr, opts...)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	restore, err := makeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("failed to switch terminal to raw mode: %w", err)
+	}
+	defer restore()
+
+	fmt.Fprint(os.Stdout, enterScreen)
+	defer fmt.Fprint(os.Stdout, leaveScreen)
+
+	keys := make(chan byte)
+	go readKeys(ctx, os.Stdin, keys)
+
+	resize := make(chan os.Signal, 1)
+	notifyResize(resize)
+	defer signal.Stop(resize)
+
+	model := &Model{Addr: addr, Request: proto.Clone(req).(*pb.StatsRequest), Status: "connecting"}
+	streamCancel := func() {}
+	subscribe := func() {
+		streamCancel()
+		var streamCtx context.Context
+		streamCtx, streamCancel = context.WithCancel(ctx)
+		current := generation.Add(1)
+		request := proto.Clone(model.Request).(*pb.StatsRequest)
+		go func() {
+			err := client.Stream(streamCtx, request, func(resp *pb.StatsResponse) error {
+				emit(event{generation: current, resp: resp})
+				return nil
+			})
+			if streamCtx.Err() == nil {
+				emit(event{generation: current, status: fmt.Sprintf("stream closed: %v", err)})
+			}
+		}()
+	}
+	defer func() { streamCancel() }()
+
+	redraw := func() {
+		width, height := termSize(int(os.Stdout.Fd()))
+		fmt.Fprint(os.Stdout, "\x1b[H"+strings.Join(model.Render(width, height), "\x1b[K\r\n")+"\x1b[J")
+	}
+
+	subscribe()
+	redraw()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-resize:
+			// redraw заново узнаёт размер терминала.
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			changed, quit := model.HandleKey(key)
+			if quit {
+				return nil
+			}
+			if changed {
+				model.Response = nil
+				model.Status = "connecting"
+				subscribe()
+			}
+		case e := <-events:
+			if e.generation != generation.Load() {
+				continue
+			}
+			if e.resp != nil {
+				model.Response = e.resp
+				model.Status = ""
+			} else {
+				model.Status = e.status
+			}
+		}
+		redraw()
+	}
+}
+
+func readKeys(ctx context.Context, r io.Reader, keys chan<- byte) {
+	defer close(keys)
+	buf := make([]byte, 16)
+	for {
+		n, err := r.Read(buf)
+		for _, key := range buf[:n] {
+			select {
+			case keys <- key:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
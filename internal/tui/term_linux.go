@@ -0,0 +1,44 @@
+//go:build linux
+
+package tui
+
+import (
+	"os"
+	"os/signal"
+
+	"golang.org/x/sys/unix"
+)
+
+// makeRaw переводит терминал в посимвольный режим без эха и без обработки
+// Ctrl+C ядром: клавиши читаются по одной, выход обрабатывает сам дашборд.
+func makeRaw(fd int) (func(), error) {
+	saved, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := *saved
+	raw.Lflag &^= unix.ICANON | unix.ECHO | unix.ISIG
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, unix.TCSETS, &raw); err != nil {
+		return nil, err
+	}
+	return func() {
+		_ = unix.IoctlSetTermios(fd, unix.TCSETS, saved)
+	}, nil
+}
+
+// termSize возвращает размер терминала, а если его не узнать — 80x24.
+func termSize(fd int) (int, int) {
+	ws, err := unix.IoctlGetWinsize(fd, unix.TIOCGWINSZ)
+	if err != nil || ws.Col == 0 || ws.Row == 0 {
+		return defaultWidth, defaultHeight
+	}
+	return int(ws.Col), int(ws.Row)
+}
+
+// notifyResize отправляет в ch сигнал об изменении размера терминала.
+func notifyResize(ch chan<- os.Signal) {
+	signal.Notify(ch, unix.SIGWINCH)
+}
@@ -0,0 +1,20 @@
+//go:build !linux
+
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+)
+
+func makeRaw(_ int) (func(), error) {
+	return nil, fmt.Errorf("terminal raw mode on %s: %w", runtime.GOOS, errors.ErrUnsupported)
+}
+
+func termSize(_ int) (int, int) {
+	return defaultWidth, defaultHeight
+}
+
+func notifyResize(_ chan<- os.Signal) {}
@@ -0,0 +1,21 @@
+package statsclient
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// backoff возвращает задержку перед попыткой attempt: она удваивается от minDelay
+// до maxDelay, а случайный разброс в половину задержки не даёт клиентам,
+// потерявшим один сервер, переподключаться одновременно.
+func backoff(attempt int, minDelay, maxDelay time.Duration) time.Duration {
+	delay := maxDelay
+	if attempt < 32 {
+		delay = min(minDelay<<attempt, maxDelay)
+	}
+	if delay <= 0 {
+		delay = maxDelay
+	}
+	half := delay / 2
+	return delay - half + rand.N(half+1) //nolint:gosec
+}
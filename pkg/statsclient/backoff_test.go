@@ -0,0 +1,31 @@
+package statsclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff(t *testing.T) {
+	minDelay, maxDelay := 100*time.Millisecond, time.Second
+
+	t.Run("doubles with jitter", func(t *testing.T) {
+		for attempt, want := range []time.Duration{100, 200, 400, 800} {
+			want *= time.Millisecond
+			for range 100 {
+				delay := backoff(attempt, minDelay, maxDelay)
+				require.GreaterOrEqual(t, delay, want/2)
+				require.LessOrEqual(t, delay, want)
+			}
+		}
+	})
+
+	t.Run("capped by max", func(t *testing.T) {
+		for _, attempt := range []int{4, 10, 63, 1000} {
+			delay := backoff(attempt, minDelay, maxDelay)
+			require.GreaterOrEqual(t, delay, maxDelay/2)
+			require.LessOrEqual(t, delay, maxDelay)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package statsclient
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/converter"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+)
+
+type Host = models.Host
+
+// Limits — ограничения сервера на запрос статистики.
+type Limits struct {
+	MaxAveragingPeriod time.Duration
+	MinInterval        time.Duration
+	// MaxStreams — наибольшее число открытых потоков, 0 — без ограничения.
+	MaxStreams int
+	MaxTopN    int
+}
+
+// Version — версия сборки демона.
+type Version struct {
+	Release   string
+	BuildDate string
+	GitHash   string
+}
+
+// Capabilities описывает, что умеет сервер, чтобы проверить запрос до подписки.
+type Capabilities struct {
+	StatTypes    []StatTypeInfo
+	Limits       Limits
+	Aggregations []Aggregation
+	Host         Host
+	Version      Version
+}
+
+// Enabled сообщает, что сервер собирает статистику statType.
+func (c Capabilities) Enabled(statType StatType) bool {
+	for _, info := range c.StatTypes {
+		if info.Type == statType {
+			return info.Enabled
+		}
+	}
+	return false
+}
+
+// Check проверяет запрос по возможностям сервера, чтобы не подписываться
+// на выключенную статистику или за пределами ограничений.
+func (c Capabilities) Check(req *pb.StatsRequest) error {
+	var disabled []string
+	for _, statType := range req.GetStatTypes() {
+		if !c.Enabled(statType) {
+			disabled = append(disabled, strings.ToLower(statType.String()))
+		}
+	}
+	if len(disabled) > 0 {
+		return fmt.Errorf("%w: server does not collect %s", ErrInvalidRequest, strings.Join(disabled, ", "))
+	}
+
+	interval := time.Duration(req.GetIntervalN()) * time.Second
+	if c.Limits.MinInterval > 0 && interval < c.Limits.MinInterval {
+		return fmt.Errorf("%w: interval %s is below server minimum %s", ErrInvalidRequest, interval, c.Limits.MinInterval)
+	}
+	period := time.Duration(req.GetAveragingPeriodM()) * time.Second
+	if c.Limits.MaxAveragingPeriod > 0 && period > c.Limits.MaxAveragingPeriod {
+		return fmt.Errorf("%w: averaging period %s exceeds server maximum %s",
+			ErrInvalidRequest, period, c.Limits.MaxAveragingPeriod)
+	}
+	if c.Limits.MaxTopN > 0 && int(req.GetTopN()) > c.Limits.MaxTopN {
+		return fmt.Errorf("%w: top n %d exceeds server maximum %d", ErrInvalidRequest, req.GetTopN(), c.Limits.MaxTopN)
+	}
+	if len(c.Aggregations) > 0 && !slices.Contains(c.Aggregations, req.GetAggregation()) {
+		return fmt.Errorf("%w: server does not support aggregation %s",
+			ErrInvalidRequest, strings.ToLower(req.GetAggregation().String()))
+	}
+	return nil
+}
+
+// Capabilities запрашивает у сервера его возможности и ограничения.
+func (c *Client) Capabilities(ctx context.Context) (Capabilities, error) {
+	if c.opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.timeout)
+		defer cancel()
+	}
+
+	resp, err := c.service.GetCapabilities(ctx, &pb.GetCapabilitiesRequest{})
+	if err != nil {
+		return Capabilities{}, err
+	}
+	return capabilitiesFromProto(resp), nil
+}
+
+func capabilitiesFromProto(resp *pb.Capabilities) Capabilities {
+	limits := resp.GetLimits()
+	result := Capabilities{
+		StatTypes: statTypesFromProto(resp.GetStatTypes()),
+		Limits: Limits{
+			MaxAveragingPeriod: time.Duration(limits.GetMaxAveragingPeriod()) * time.Second,
+			MinInterval:        time.Duration(limits.GetMinInterval()) * time.Second,
+			MaxStreams:         int(limits.GetMaxStreams()),
+			MaxTopN:            int(limits.GetMaxTopN()),
+		},
+		Aggregations: resp.GetAggregations(),
+		Version: Version{
+			Release:   resp.GetVersion().GetRelease(),
+			BuildDate: resp.GetVersion().GetBuildDate(),
+			GitHash:   resp.GetVersion().GetGitHash(),
+		},
+	}
+	if host := converter.HostFromProto(resp.GetHost()); host != nil {
+		result.Host = *host
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package statsclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilities(t *testing.T) {
+	srv := &fakeServer{capabilities: &pb.Capabilities{
+		StatTypes: []*pb.StatTypeInfo{
+			{StatType: pb.StatType_CPU_STATS, Name: "cpu", Enabled: true},
+			{StatType: pb.StatType_CGROUPS, Name: "cgroups"},
+		},
+		Limits:       &pb.Limits{MaxAveragingPeriod: 500, MinInterval: 1, MaxStreams: 10, MaxTopN: 100},
+		Aggregations: []pb.Aggregation{pb.Aggregation_MEAN, pb.Aggregation_P95},
+		Host:         &pb.HostInfo{Hostname: "db-01", Kernel: "6.1.0", CpuCount: 4, BootTime: 1700000000},
+		Version:      &pb.DaemonVersion{Release: "v1.2.0", BuildDate: "2026-10-01", GitHash: "abc123"},
+	}}
+	client := startFakeServer(t, srv)
+
+	capabilities, err := client.Capabilities(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, Limits{
+		MaxAveragingPeriod: 500 * time.Second,
+		MinInterval:        time.Second,
+		MaxStreams:         10,
+		MaxTopN:            100,
+	}, capabilities.Limits)
+	require.Equal(t, []Aggregation{AggregationMean, AggregationP95}, capabilities.Aggregations)
+	require.Equal(t, Host{Hostname: "db-01", Kernel: "6.1.0", CPUCount: 4, BootTime: time.Unix(1700000000, 0)},
+		capabilities.Host)
+	require.Equal(t, Version{Release: "v1.2.0", BuildDate: "2026-10-01", GitHash: "abc123"}, capabilities.Version)
+
+	require.True(t, capabilities.Enabled(StatCPU))
+	require.False(t, capabilities.Enabled(StatCgroups))
+	require.False(t, capabilities.Enabled(StatPressure))
+}
+
+func TestCapabilitiesCheck(t *testing.T) {
+	capabilities := Capabilities{
+		StatTypes: []StatTypeInfo{
+			{Type: StatCPU, Name: "cpu", Enabled: true},
+			{Type: StatCgroups, Name: "cgroups"},
+		},
+		Limits:       Limits{MaxAveragingPeriod: time.Minute, MinInterval: time.Second, MaxTopN: 100},
+		Aggregations: []Aggregation{AggregationMean},
+	}
+	request := func(modify func(req *pb.StatsRequest)) *pb.StatsRequest {
+		req := &pb.StatsRequest{IntervalN: 1, AveragingPeriodM: 5, StatTypes: []StatType{StatCPU}, TopN: 10}
+		if modify != nil {
+			modify(req)
+		}
+		return req
+	}
+
+	require.NoError(t, capabilities.Check(request(nil)))
+	for name, modify := range map[string]func(req *pb.StatsRequest){
+		"disabled stat":     func(req *pb.StatsRequest) { req.StatTypes = append(req.StatTypes, StatCgroups) },
+		"unknown stat":      func(req *pb.StatsRequest) { req.StatTypes = []StatType{StatPressure} },
+		"short interval":    func(req *pb.StatsRequest) { req.IntervalN = 0 },
+		"long period":       func(req *pb.StatsRequest) { req.AveragingPeriodM = 61 },
+		"large top n":       func(req *pb.StatsRequest) { req.TopN = 101 },
+		"other aggregation": func(req *pb.StatsRequest) { req.Aggregation = AggregationP95 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, capabilities.Check(request(modify)), ErrInvalidRequest)
+		})
+	}
+}
@@ -0,0 +1,260 @@
+// Package statsclient — клиент демона статистики, который переживает обрывы
+// соединения: переподключается с экспоненциальной задержкой и продолжает поток
+// с последнего полученного ответа. Subscribe и Snapshot отдают ответы,
+// разложенные в модели демона; Stream — в исходном виде.
+package statsclient
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"google.golang.org/grpc"
+	grpcbackoff "google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultMinBackoff       = 500 * time.Millisecond
+	defaultMaxBackoff       = 30 * time.Second
+	defaultKeepaliveTime    = 30 * time.Second
+	defaultKeepaliveTimeout = 10 * time.Second
+)
+
+type options struct {
+	minBackoff     time.Duration
+	maxBackoff     time.Duration
+	keepalive      keepalive.ClientParameters
+	tls            *tls.Config
+	credentials    credentials.PerRPCCredentials
+	timeout        time.Duration
+	connectTimeout time.Duration
+	dialOptions    []grpc.DialOption
+	onRetry        func(err error, delay time.Duration)
+}
+
+type Option func(*options)
+
+// WithBackoff задаёт начальную и максимальную задержку перед переподключением.
+func WithBackoff(minDelay, maxDelay time.Duration) Option {
+	return func(o *options) {
+		o.minBackoff = minDelay
+		o.maxBackoff = maxDelay
+	}
+}
+
+// WithKeepalive задаёт период keepalive-пингов и время ожидания ответа на них.
+// Сервер не принимает пинги чаще, чем раз в 5 секунд.
+func WithKeepalive(interval, timeout time.Duration) Option {
+	return func(o *options) {
+		o.keepalive.Time = interval
+		o.keepalive.Timeout = timeout
+	}
+}
+
+// WithTLS включает TLS с заданной конфигурацией. По умолчанию соединение не шифруется.
+func WithTLS(cfg *tls.Config) Option {
+	return func(o *options) {
+		o.tls = cfg
+	}
+}
+
+// WithBearerToken добавляет к каждому запросу заголовок authorization с токеном.
+// Токен передаётся только по TLS.
+func WithBearerToken(token string) Option {
+	return WithPerRPCCredentials(bearerToken(token))
+}
+
+// WithPerRPCCredentials задаёт учётные данные, которые передаются с каждым запросом.
+func WithPerRPCCredentials(creds credentials.PerRPCCredentials) Option {
+	return func(o *options) {
+		o.credentials = creds
+	}
+}
+
+// WithTimeout ограничивает время получения одного снимка в Snapshot.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithConnectTimeout ограничивает время установки соединения с сервером.
+func WithConnectTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.connectTimeout = timeout
+	}
+}
+
+// WithDialOptions добавляет параметры gRPC-соединения.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// OnRetry задаёт функцию, которая вызывается перед каждым переподключением.
+func OnRetry(fn func(err error, delay time.Duration)) Option {
+	return func(o *options) {
+		o.onRetry = fn
+	}
+}
+
+type Client struct {
+	conn    *grpc.ClientConn
+	service pb.StatsServiceClient
+	opts    options
+}
+
+func New(addr string, opts ...Option) (*Client, error) {
+	o := options{
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+		keepalive: keepalive.ClientParameters{
+			Time:                defaultKeepaliveTime,
+			Timeout:             defaultKeepaliveTimeout,
+			PermitWithoutStream: true,
+		},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.minBackoff <= 0 || o.maxBackoff < o.minBackoff {
+		return nil, fmt.Errorf("invalid backoff %s..%s", o.minBackoff, o.maxBackoff)
+	}
+
+	if o.credentials != nil && o.credentials.RequireTransportSecurity() && o.tls == nil {
+		return nil, errors.New("credentials require TLS")
+	}
+
+	transport := insecure.NewCredentials()
+	if o.tls != nil {
+		transport = credentials.NewTLS(o.tls)
+	}
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(transport),
+		grpc.WithKeepaliveParams(o.keepalive),
+	}
+	if o.credentials != nil {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(o.credentials))
+	}
+	if o.connectTimeout > 0 {
+		dialOptions = append(dialOptions, grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           grpcbackoff.DefaultConfig,
+			MinConnectTimeout: o.connectTimeout,
+		}))
+	}
+	dialOptions = append(dialOptions, o.dialOptions...)
+	conn, err := grpc.NewClient(addr, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", addr, err)
+	}
+	return &Client{conn: conn, service: pb.NewStatsServiceClient(conn), opts: o}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (bearerToken) RequireTransportSecurity() bool {
+	return true
+}
+
+// handlerError отличает ошибку обработчика ответа от ошибки потока.
+type handlerError struct {
+	err error
+}
+
+func (e *handlerError) Error() string {
+	return e.err.Error()
+}
+
+// Stream подписывается на статистику и передаёт каждый ответ в handle. При
+// обрыве потока Stream переподключается и запрашивает у сервера ответы,
+// пропущенные после последнего полученного. Если сервер отклоняет такой Since,
+// поток продолжается без пропущенных ответов. Возвращается, когда отменён ctx,
+// handle вернул ошибку или сервер отклонил запрос.
+func (c *Client) Stream(ctx context.Context, req *pb.StatsRequest, handle func(*pb.StatsResponse) error) error {
+	req = proto.Clone(req).(*pb.StatsRequest)
+	resumed := false
+	for attempt := 0; ; attempt++ {
+		received, err := c.stream(ctx, req, handle)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var handleErr *handlerError
+		if errors.As(err, &handleErr) {
+			return handleErr.err
+		}
+		if resumed && req.GetSince() != 0 && status.Code(err) == codes.InvalidArgument {
+			// После перезапуска сервер мог потерять историю или его часы ушли
+			// назад: пропущенные ответы уже не получить, подписываемся заново.
+			req.Since = 0
+		} else if permanent(err) {
+			return err
+		}
+		resumed = true
+		if received {
+			attempt = 0
+		}
+
+		delay := backoff(attempt, c.opts.minBackoff, c.opts.maxBackoff)
+		if c.opts.onRetry != nil {
+			c.opts.onRetry(err, delay)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// stream читает один поток до ошибки и сдвигает req.Since на время последнего ответа.
+func (c *Client) stream(ctx context.Context, req *pb.StatsRequest, handle func(*pb.StatsResponse) error) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.service.GetStats(ctx, req)
+	if err != nil {
+		return false, err
+	}
+
+	received := false
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+		received = true
+		req.Since = resp.GetTimestamp()
+		if err := handle(resp); err != nil {
+			return received, &handlerError{err: err}
+		}
+	}
+}
+
+// permanent сообщает, что повтор запроса не поможет.
+func permanent(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.Unimplemented,
+		codes.Unauthenticated, codes.PermissionDenied:
+		return true
+	default:
+		return false
+	}
+}
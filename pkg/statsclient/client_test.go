@@ -0,0 +1,210 @@
+package statsclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeServer отдаёт на каждый вызов GetStats заранее заданные ответы и ошибку.
+type fakeServer struct {
+	pb.UnimplementedStatsServiceServer
+
+	mu           sync.Mutex
+	calls        []*pb.StatsRequest
+	metadata     []metadata.MD
+	sessions     []fakeSession
+	types        []*pb.StatTypeInfo
+	capabilities *pb.Capabilities
+}
+
+type fakeSession struct {
+	timestamps []int64
+	err        error
+}
+
+func (s *fakeServer) GetStats(req *pb.StatsRequest, stream pb.StatsService_GetStatsServer) error {
+	s.mu.Lock()
+	s.calls = append(s.calls, req)
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	s.metadata = append(s.metadata, md)
+	session := fakeSession{err: status.Error(codes.InvalidArgument, "no more sessions")}
+	if len(s.calls) <= len(s.sessions) {
+		session = s.sessions[len(s.calls)-1]
+	}
+	s.mu.Unlock()
+
+	for _, ts := range session.timestamps {
+		if err := stream.Send(&pb.StatsResponse{Timestamp: ts}); err != nil {
+			return err
+		}
+	}
+	return session.err
+}
+
+func (s *fakeServer) ListStatTypes(context.Context, *pb.ListStatTypesRequest) (*pb.ListStatTypesResponse, error) {
+	return &pb.ListStatTypesResponse{StatTypes: s.types}, nil
+}
+
+func (s *fakeServer) GetCapabilities(context.Context, *pb.GetCapabilitiesRequest) (*pb.Capabilities, error) {
+	return s.capabilities, nil
+}
+
+func startFakeServer(t *testing.T, srv *fakeServer, opts ...Option) *Client {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	pb.RegisterStatsServiceServer(grpcServer, srv)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	opts = append([]Option{
+		WithBackoff(time.Millisecond, 10*time.Millisecond),
+		WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		})),
+	}, opts...)
+	client, err := New("passthrough:///bufnet", opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestStream(t *testing.T) {
+	request := &pb.StatsRequest{IntervalN: 1, AveragingPeriodM: 1, StatTypes: []pb.StatType{pb.StatType_CPU_STATS}}
+
+	t.Run("reconnects and resumes", func(t *testing.T) {
+		srv := &fakeServer{sessions: []fakeSession{
+			{timestamps: []int64{100, 101}, err: status.Error(codes.Unavailable, "restart")},
+			{err: status.Error(codes.Unavailable, "still down")},
+			{timestamps: []int64{102}, err: status.Error(codes.Internal, "crash")},
+			{err: status.Error(codes.FailedPrecondition, "shutdown")},
+		}}
+		var retries int
+		client := startFakeServer(t, srv, OnRetry(func(error, time.Duration) { retries++ }))
+
+		var timestamps []int64
+		err := client.Stream(context.Background(), request, func(resp *pb.StatsResponse) error {
+			timestamps = append(timestamps, resp.GetTimestamp())
+			return nil
+		})
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.Equal(t, []int64{100, 101, 102}, timestamps)
+		require.Equal(t, 3, retries)
+
+		require.Len(t, srv.calls, 4)
+		require.Zero(t, srv.calls[0].GetSince())
+		require.Equal(t, int64(101), srv.calls[1].GetSince())
+		require.Equal(t, int64(101), srv.calls[2].GetSince())
+		require.Equal(t, int64(102), srv.calls[3].GetSince())
+		require.Zero(t, request.GetSince(), "исходный запрос не меняется")
+	})
+
+	t.Run("rejected since after restart", func(t *testing.T) {
+		srv := &fakeServer{sessions: []fakeSession{
+			{timestamps: []int64{100, 101}, err: status.Error(codes.Unavailable, "restart")},
+			{err: status.Error(codes.InvalidArgument, "since must not be negative or in the future")},
+			{timestamps: []int64{50}, err: status.Error(codes.FailedPrecondition, "shutdown")},
+		}}
+		client := startFakeServer(t, srv)
+
+		var timestamps []int64
+		err := client.Stream(context.Background(), request, func(resp *pb.StatsResponse) error {
+			timestamps = append(timestamps, resp.GetTimestamp())
+			return nil
+		})
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.Equal(t, []int64{100, 101, 50}, timestamps)
+
+		require.Len(t, srv.calls, 3)
+		require.Equal(t, int64(101), srv.calls[1].GetSince())
+		require.Zero(t, srv.calls[2].GetSince())
+	})
+
+	t.Run("rejected initial since is permanent", func(t *testing.T) {
+		srv := &fakeServer{sessions: []fakeSession{
+			{err: status.Error(codes.InvalidArgument, "since must not be negative or in the future")},
+		}}
+		client := startFakeServer(t, srv)
+
+		req := proto.Clone(request).(*pb.StatsRequest)
+		req.Since = 1 << 40
+		err := client.Stream(context.Background(), req, func(*pb.StatsResponse) error { return nil })
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Len(t, srv.calls, 1)
+	})
+
+	t.Run("handler error stops stream", func(t *testing.T) {
+		srv := &fakeServer{sessions: []fakeSession{{timestamps: []int64{100, 101}}}}
+		client := startFakeServer(t, srv)
+
+		errStop := errors.New("stop")
+		err := client.Stream(context.Background(), request, func(*pb.StatsResponse) error {
+			return errStop
+		})
+		require.ErrorIs(t, err, errStop)
+		require.Len(t, srv.calls, 1)
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		srv := &fakeServer{sessions: []fakeSession{
+			{err: status.Error(codes.Unavailable, "down")},
+			{err: status.Error(codes.Unavailable, "down")},
+		}}
+		ctx, cancel := context.WithCancel(context.Background())
+		client := startFakeServer(t, srv, WithBackoff(time.Hour, time.Hour), OnRetry(func(error, time.Duration) {
+			cancel()
+		}))
+
+		err := client.Stream(ctx, request, func(*pb.StatsResponse) error { return nil })
+		require.ErrorIs(t, err, context.Canceled)
+		require.Len(t, srv.calls, 1)
+	})
+}
+
+// plainCredentials передаёт токен без TLS, как допустимо на bufconn.
+type plainCredentials string
+
+func (c plainCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(c)}, nil
+}
+
+func (plainCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func TestNew(t *testing.T) {
+	t.Run("invalid backoff", func(t *testing.T) {
+		_, err := New("localhost:0", WithBackoff(time.Second, time.Millisecond))
+		require.Error(t, err)
+	})
+
+	t.Run("bearer token requires tls", func(t *testing.T) {
+		_, err := New("localhost:0", WithBearerToken("secret"))
+		require.ErrorContains(t, err, "credentials require TLS")
+	})
+
+	t.Run("per rpc credentials", func(t *testing.T) {
+		srv := &fakeServer{}
+		client := startFakeServer(t, srv, WithPerRPCCredentials(plainCredentials("secret")))
+
+		err := client.Stream(context.Background(), &pb.StatsRequest{}, func(*pb.StatsResponse) error { return nil })
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Equal(t, []string{"Bearer secret"}, srv.metadata[0].Get("authorization"))
+	})
+}
@@ -0,0 +1,93 @@
+package statsclient_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/cepmap/otus-system-monitoring/internal/config"
+	"github.com/cepmap/otus-system-monitoring/internal/logger"
+	"github.com/cepmap/otus-system-monitoring/internal/network/server"
+	"github.com/cepmap/otus-system-monitoring/pkg/statsclient"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startDaemon запускает демон в процессе и подключает к нему клиента через bufconn.
+func startDaemon() (*statsclient.Client, func()) {
+	logger.SetWriter(io.Discard)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cfg := &config.Config{Stats: config.Stats{Limit: 100, LoadAverage: true, Cpu: true}}
+	daemon := server.NewStatsDaemonServer(ctx, cfg)
+	listener := bufconn.Listen(1 << 20)
+	go func() {
+		_ = daemon.Serve(listener)
+	}()
+
+	client, err := statsclient.New("passthrough:///bufnet",
+		statsclient.WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		})),
+	)
+	if err != nil {
+		panic(err)
+	}
+	return client, func() {
+		_ = client.Close()
+		cancel()
+		daemon.Stop()
+	}
+}
+
+func ExampleClient_Subscribe() {
+	client, stop := startDaemon()
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	snapshots, err := client.Subscribe(ctx, statsclient.Request{
+		Stats:           []statsclient.StatType{statsclient.StatLoadAverage, statsclient.StatCPU},
+		Interval:        time.Second,
+		AveragingPeriod: 2 * time.Second,
+		Aggregation:     statsclient.AggregationMax,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for snapshot := range snapshots {
+		if snapshot.Err != nil {
+			fmt.Println(snapshot.Err)
+			return
+		}
+		fmt.Println(snapshot.Aggregation, snapshot.LoadAverage != nil, snapshot.CPU != nil)
+		break
+	}
+	// Output: MAX true true
+}
+
+func ExampleClient_Snapshot() {
+	client, stop := startDaemon()
+	defer stop()
+
+	snapshot, err := client.Snapshot(context.Background(), statsclient.Request{
+		Stats:           []statsclient.StatType{statsclient.StatLoadAverage},
+		AveragingPeriod: 2 * time.Second,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(snapshot.LoadAverage.TotalTasks > 0)
+	for _, coverage := range snapshot.Coverage {
+		fmt.Println(coverage.StatType, coverage.SampleCount > 0)
+	}
+	// Output:
+	// true
+	// LOAD_AVERAGE true
+}
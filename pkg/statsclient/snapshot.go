@@ -0,0 +1,131 @@
+package statsclient
+
+import (
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/cepmap/otus-system-monitoring/internal/converter"
+	"github.com/cepmap/otus-system-monitoring/internal/models"
+)
+
+// Псевдонимы открывают внешним модулям типы из internal-пакетов демона.
+type (
+	StatsRequest  = pb.StatsRequest
+	StatsResponse = pb.StatsResponse
+	StatType      = pb.StatType
+	Aggregation   = pb.Aggregation
+
+	LoadAverage     = models.LoadAverage
+	CPUStat         = models.CPUStat
+	DisksLoad       = models.DisksLoad
+	DiskLoad        = models.DiskLoad
+	DiskStats       = models.DiskStats
+	DiskStat        = models.DiskStat
+	CustomMetrics   = models.CustomMetrics
+	CustomMetric    = models.CustomMetric
+	TopProcesses    = models.TopProcesses
+	Process         = models.Process
+	Pressure        = models.Pressure
+	Cgroups         = models.Cgroups
+	Cgroup          = models.Cgroup
+	DaemonSelf      = models.DaemonSelf
+	DaemonCollector = models.DaemonCollector
+)
+
+const (
+	StatLoadAverage  = pb.StatType_LOAD_AVERAGE
+	StatCPU          = pb.StatType_CPU_STATS
+	StatDisksLoad    = pb.StatType_DISKS_LOAD
+	StatDiskUsage    = pb.StatType_DISK_USAGE
+	StatCustom       = pb.StatType_CUSTOM
+	StatTopProcesses = pb.StatType_TOP_PROCESSES
+	StatPressure     = pb.StatType_PRESSURE
+	StatCgroups      = pb.StatType_CGROUPS
+	StatDaemonSelf   = pb.StatType_DAEMON_SELF
+)
+
+const (
+	AggregationMean = pb.Aggregation_MEAN
+	AggregationMin  = pb.Aggregation_MIN
+	AggregationMax  = pb.Aggregation_MAX
+	AggregationP50  = pb.Aggregation_P50
+	AggregationP95  = pb.Aggregation_P95
+	AggregationP99  = pb.Aggregation_P99
+	AggregationLast = pb.Aggregation_LAST
+)
+
+// Coverage — сколько замеров попало в окно усреднения и какую его долю они покрывают.
+type Coverage struct {
+	StatType    StatType
+	SampleCount int
+	Coverage    float64
+}
+
+// CollectionError описывает идущие подряд неудачные сборы статистики на сервере.
+type CollectionError struct {
+	StatType            StatType
+	Message             string
+	Since               time.Time
+	ConsecutiveFailures int
+}
+
+// Snapshot — ответ сервера, разложенный в модели демона. Поля статистики,
+// не запрошенной или ещё не собранной, равны nil.
+type Snapshot struct {
+	Timestamp     time.Time
+	Aggregation   Aggregation
+	LoadAverage   *LoadAverage
+	CPU           *CPUStat
+	DisksLoad     *DisksLoad
+	DiskStats     *DiskStats
+	CustomMetrics *CustomMetrics
+	TopProcesses  *TopProcesses
+	Pressure      *Pressure
+	Cgroups       *Cgroups
+	DaemonSelf    *DaemonSelf
+	Coverage      []Coverage
+	// Disabled — запрошенная статистика, выключенная в конфигурации сервера.
+	Disabled []StatType
+	Errors   []CollectionError
+	// Backfill — снимок восстановлен сервером по истории после переподключения.
+	Backfill bool
+
+	// Err — причина завершения подписки. Снимок с Err последний в канале,
+	// остальные его поля пусты.
+	Err error
+}
+
+// SnapshotFromProto раскладывает ответ сервера в модели демона.
+func SnapshotFromProto(resp *pb.StatsResponse) Snapshot {
+	snapshot := Snapshot{
+		Timestamp:     time.Unix(resp.GetTimestamp(), 0),
+		Aggregation:   resp.GetAggregation(),
+		LoadAverage:   converter.LoadAverageFromProto(resp.GetLoadAverage()),
+		CPU:           converter.CPUStatFromProto(resp.GetCpuStats()),
+		DisksLoad:     converter.DisksLoadFromProto(resp.GetDisksLoad()),
+		DiskStats:     converter.DiskStatsFromProto(resp.GetDiskStats()),
+		CustomMetrics: converter.CustomMetricsFromProto(resp.GetCustomMetrics()),
+		TopProcesses:  converter.TopProcessesFromProto(resp.GetTopProcesses()),
+		Pressure:      converter.PressureFromProto(resp.GetPressure()),
+		Cgroups:       converter.CgroupsFromProto(resp.GetCgroups()),
+		DaemonSelf:    converter.DaemonSelfFromProto(resp.GetDaemonSelf()),
+		Disabled:      resp.GetDisabledStatTypes(),
+		Backfill:      resp.GetBackfill(),
+	}
+	for _, coverage := range resp.GetCoverage() {
+		snapshot.Coverage = append(snapshot.Coverage, Coverage{
+			StatType:    coverage.GetStatType(),
+			SampleCount: int(coverage.GetSampleCount()),
+			Coverage:    coverage.GetCoverage(),
+		})
+	}
+	for _, collectErr := range resp.GetErrors() {
+		snapshot.Errors = append(snapshot.Errors, CollectionError{
+			StatType:            collectErr.GetStatType(),
+			Message:             collectErr.GetMessage(),
+			Since:               time.Unix(collectErr.GetSince(), 0),
+			ConsecutiveFailures: int(collectErr.GetConsecutiveFailures()),
+		})
+	}
+	return snapshot
+}
@@ -0,0 +1,98 @@
+package statsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+)
+
+var ErrUnknownStatType = errors.New("unknown stat type")
+
+// StatTypeInfo описывает тип статистики сервера. Name — имя статистики
+// в конфигурации сервера, пустое, если сервер её не собирает.
+type StatTypeInfo struct {
+	Type    StatType
+	Name    string
+	Enabled bool
+}
+
+// ListStatTypes запрашивает у сервера все типы статистики и признак их включения.
+func (c *Client) ListStatTypes(ctx context.Context) ([]StatTypeInfo, error) {
+	if c.opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.timeout)
+		defer cancel()
+	}
+
+	resp, err := c.service.ListStatTypes(ctx, &pb.ListStatTypesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return statTypesFromProto(resp.GetStatTypes()), nil
+}
+
+func statTypesFromProto(types []*pb.StatTypeInfo) []StatTypeInfo {
+	result := make([]StatTypeInfo, 0, len(types))
+	for _, info := range types {
+		result = append(result, StatTypeInfo{Type: info.GetStatType(), Name: info.GetName(), Enabled: info.GetEnabled()})
+	}
+	return result
+}
+
+// ParseStatTypes разбирает имена типов статистики без учёта регистра, например
+// "cpu_stats" или "LOAD_AVERAGE". Допускается однозначное начало имени: "cpu".
+// Повторы отбрасываются, порядок сохраняется.
+func ParseStatTypes(names []string) ([]StatType, error) {
+	var result []StatType
+	seen := make(map[StatType]bool)
+	for _, name := range names {
+		statType, err := parseStatType(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		if !seen[statType] {
+			seen[statType] = true
+			result = append(result, statType)
+		}
+	}
+	return result, nil
+}
+
+func parseStatType(name string) (StatType, error) {
+	upper := strings.ToUpper(name)
+	if value, ok := pb.StatType_value[upper]; ok {
+		return StatType(value), nil
+	}
+
+	var matches []string
+	if upper != "" {
+		for known := range pb.StatType_value {
+			if strings.HasPrefix(known, upper) {
+				matches = append(matches, known)
+			}
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return StatType(pb.StatType_value[matches[0]]), nil
+	case 0:
+		return 0, fmt.Errorf("%w %q, expected one of %s", ErrUnknownStatType, name, strings.Join(statTypeNames(), ", "))
+	default:
+		sort.Strings(matches)
+		return 0, fmt.Errorf("%w %q, ambiguous between %s",
+			ErrUnknownStatType, name, strings.ToLower(strings.Join(matches, ", ")))
+	}
+}
+
+// statTypeNames возвращает имена типов статистики в порядке номеров.
+func statTypeNames() []string {
+	names := make([]string, 0, len(pb.StatType_name))
+	for i := range int32(len(pb.StatType_name)) {
+		names = append(names, strings.ToLower(pb.StatType_name[i]))
+	}
+	return names
+}
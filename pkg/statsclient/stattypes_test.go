@@ -0,0 +1,43 @@
+package statsclient
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatTypes(t *testing.T) {
+	t.Run("names and prefixes", func(t *testing.T) {
+		types, err := ParseStatTypes([]string{"cpu", "LOAD_AVERAGE", " disk_usage ", "cpu_stats", "top"})
+		require.NoError(t, err)
+		require.Equal(t, []StatType{StatCPU, StatLoadAverage, StatDiskUsage, StatTopProcesses}, types)
+	})
+
+	for name, input := range map[string]string{
+		"unknown":   "network",
+		"ambiguous": "disk",
+		"empty":     "",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseStatTypes([]string{"cpu", input})
+			require.ErrorIs(t, err, ErrUnknownStatType)
+		})
+	}
+}
+
+func TestListStatTypes(t *testing.T) {
+	srv := &fakeServer{types: []*pb.StatTypeInfo{
+		{StatType: pb.StatType_LOAD_AVERAGE, Name: "load_average", Enabled: true},
+		{StatType: pb.StatType_CGROUPS, Name: "cgroups"},
+	}}
+	client := startFakeServer(t, srv)
+
+	types, err := client.ListStatTypes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []StatTypeInfo{
+		{Type: StatLoadAverage, Name: "load_average", Enabled: true},
+		{Type: StatCgroups, Name: "cgroups"},
+	}, types)
+}
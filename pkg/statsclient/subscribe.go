@@ -0,0 +1,109 @@
+package statsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+)
+
+var ErrInvalidRequest = errors.New("invalid request")
+
+// Request описывает подписку на статистику. Интервалы округляются до секунд.
+type Request struct {
+	Stats []StatType
+	// Interval — период отправки снимков, по умолчанию 1 секунда.
+	Interval time.Duration
+	// AveragingPeriod — окно усреднения, по умолчанию равно Interval.
+	AveragingPeriod time.Duration
+	Aggregation     Aggregation
+	// TopN — количество процессов в TopProcesses, 0 — значение сервера по умолчанию.
+	TopN int
+}
+
+func (r Request) toProto() (*pb.StatsRequest, error) {
+	if len(r.Stats) == 0 {
+		return nil, fmt.Errorf("%w: no stats requested", ErrInvalidRequest)
+	}
+	interval := r.Interval
+	if interval == 0 {
+		interval = time.Second
+	}
+	averagingPeriod := r.AveragingPeriod
+	if averagingPeriod == 0 {
+		averagingPeriod = interval
+	}
+	if interval < time.Second || averagingPeriod < time.Second {
+		return nil, fmt.Errorf("%w: interval and averaging period must be at least 1s", ErrInvalidRequest)
+	}
+	if r.TopN < 0 {
+		return nil, fmt.Errorf("%w: negative top n", ErrInvalidRequest)
+	}
+
+	//nolint:gosec
+	return &pb.StatsRequest{
+		IntervalN:        int32(interval.Round(time.Second) / time.Second),
+		AveragingPeriodM: int32(averagingPeriod.Round(time.Second) / time.Second),
+		StatTypes:        r.Stats,
+		Aggregation:      r.Aggregation,
+		TopN:             int32(r.TopN),
+	}, nil
+}
+
+// Subscribe подписывается на статистику и отдаёт снимки в канал, переподключаясь
+// при обрывах так же, как Stream. Канал закрывается после отмены ctx; если
+// подписку завершил сервер, последним в канал приходит снимок с Err.
+func (c *Client) Subscribe(ctx context.Context, req Request) (<-chan Snapshot, error) {
+	request, err := req.toProto()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make(chan Snapshot)
+	go func() {
+		defer close(snapshots)
+		send := func(snapshot Snapshot) error {
+			select {
+			case snapshots <- snapshot:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		err := c.Stream(ctx, request, func(resp *pb.StatsResponse) error {
+			return send(SnapshotFromProto(resp))
+		})
+		if ctx.Err() == nil {
+			_ = send(Snapshot{Err: err})
+		}
+	}()
+	return snapshots, nil
+}
+
+// Snapshot возвращает один свежий снимок: сервер отправляет его, как только
+// наберёт замеры за окно усреднения.
+func (c *Client) Snapshot(ctx context.Context, req Request) (Snapshot, error) {
+	if c.opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.timeout)
+		defer cancel()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	snapshots, err := c.Subscribe(ctx, req)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	snapshot, ok := <-snapshots
+	if !ok {
+		return Snapshot{}, ctx.Err()
+	}
+	if snapshot.Err != nil {
+		return Snapshot{}, snapshot.Err
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,147 @@
+package statsclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/cepmap/otus-system-monitoring/internal/api/stats_service"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequest(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		req, err := Request{Stats: []StatType{StatCPU}}.toProto()
+		require.NoError(t, err)
+		require.Equal(t, int32(1), req.GetIntervalN())
+		require.Equal(t, int32(1), req.GetAveragingPeriodM())
+	})
+
+	t.Run("rounded to seconds", func(t *testing.T) {
+		req, err := Request{
+			Stats:           []StatType{StatCPU, StatTopProcesses},
+			Interval:        5 * time.Second,
+			AveragingPeriod: 1500 * time.Millisecond,
+			Aggregation:     AggregationP95,
+			TopN:            3,
+		}.toProto()
+		require.NoError(t, err)
+		require.Equal(t, int32(5), req.GetIntervalN())
+		require.Equal(t, int32(2), req.GetAveragingPeriodM())
+		require.Equal(t, pb.Aggregation_P95, req.GetAggregation())
+		require.Equal(t, int32(3), req.GetTopN())
+	})
+
+	for name, req := range map[string]Request{
+		"no stats":          {},
+		"short interval":    {Stats: []StatType{StatCPU}, Interval: time.Millisecond},
+		"short averaging":   {Stats: []StatType{StatCPU}, AveragingPeriod: time.Millisecond},
+		"negative interval": {Stats: []StatType{StatCPU}, Interval: -time.Second},
+		"negative top n":    {Stats: []StatType{StatCPU}, TopN: -1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := req.toProto()
+			require.ErrorIs(t, err, ErrInvalidRequest)
+		})
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	request := Request{Stats: []StatType{StatCPU}}
+
+	t.Run("snapshots then error", func(t *testing.T) {
+		srv := &fakeServer{sessions: []fakeSession{
+			{timestamps: []int64{100, 101}, err: status.Error(codes.FailedPrecondition, "disabled")},
+		}}
+		client := startFakeServer(t, srv)
+
+		snapshots, err := client.Subscribe(context.Background(), request)
+		require.NoError(t, err)
+
+		var received []Snapshot
+		for snapshot := range snapshots {
+			received = append(received, snapshot)
+		}
+		require.Len(t, received, 3)
+		require.Equal(t, time.Unix(100, 0), received[0].Timestamp)
+		require.Equal(t, time.Unix(101, 0), received[1].Timestamp)
+		require.Equal(t, codes.FailedPrecondition, status.Code(received[2].Err))
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		client := startFakeServer(t, &fakeServer{})
+		_, err := client.Subscribe(context.Background(), Request{})
+		require.ErrorIs(t, err, ErrInvalidRequest)
+	})
+
+	t.Run("closed after cancel", func(t *testing.T) {
+		srv := &fakeServer{sessions: []fakeSession{{timestamps: []int64{100, 101, 102}}}}
+		client := startFakeServer(t, srv)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		snapshots, err := client.Subscribe(ctx, request)
+		require.NoError(t, err)
+		<-snapshots
+		cancel()
+		for snapshot := range snapshots {
+			require.NoError(t, snapshot.Err)
+		}
+	})
+}
+
+func TestSnapshot(t *testing.T) {
+	request := Request{Stats: []StatType{StatCPU}}
+
+	t.Run("first snapshot", func(t *testing.T) {
+		srv := &fakeServer{sessions: []fakeSession{{timestamps: []int64{100, 101}}}}
+		client := startFakeServer(t, srv)
+
+		snapshot, err := client.Snapshot(context.Background(), request)
+		require.NoError(t, err)
+		require.Equal(t, time.Unix(100, 0), snapshot.Timestamp)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		client := startFakeServer(t, &fakeServer{})
+		_, err := client.Snapshot(context.Background(), request)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		srv := &fakeServer{sessions: []fakeSession{{err: status.Error(codes.Unavailable, "down")}}}
+		client := startFakeServer(t, srv, WithTimeout(50*time.Millisecond), WithBackoff(time.Second, time.Second))
+		_, err := client.Snapshot(context.Background(), request)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestSnapshotFromProto(t *testing.T) {
+	snapshot := SnapshotFromProto(&pb.StatsResponse{
+		Timestamp:   100,
+		Aggregation: pb.Aggregation_MAX,
+		LoadAverage: &pb.LoadAverage{Load1Min: 1.5},
+		TopProcesses: &pb.TopProcesses{
+			ByCpu: []*pb.Process{{Pid: 1, Command: "init"}},
+		},
+		Coverage:          []*pb.StatCoverage{{StatType: pb.StatType_LOAD_AVERAGE, SampleCount: 3, Coverage: 0.9}},
+		DisabledStatTypes: []pb.StatType{pb.StatType_CPU_STATS},
+		Errors: []*pb.CollectionError{{
+			StatType: pb.StatType_TOP_PROCESSES, Message: "boom", Since: 90, ConsecutiveFailures: 2,
+		}},
+		Backfill: true,
+	})
+
+	require.Equal(t, time.Unix(100, 0), snapshot.Timestamp)
+	require.Equal(t, AggregationMax, snapshot.Aggregation)
+	require.Equal(t, &LoadAverage{Load1Min: 1.5}, snapshot.LoadAverage)
+	require.Nil(t, snapshot.CPU)
+	require.Equal(t, []Process{{Pid: 1, Command: "init"}}, snapshot.TopProcesses.ByCPU)
+	require.Equal(t, []Coverage{{StatType: StatLoadAverage, SampleCount: 3, Coverage: 0.9}}, snapshot.Coverage)
+	require.Equal(t, []StatType{StatCPU}, snapshot.Disabled)
+	require.Equal(t, []CollectionError{{
+		StatType: StatTopProcesses, Message: "boom", Since: time.Unix(90, 0), ConsecutiveFailures: 2,
+	}}, snapshot.Errors)
+	require.True(t, snapshot.Backfill)
+}
@@ -12,7 +12,9 @@ import (
 	"github.com/cepmap/otus-system-monitoring/internal/network/server"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 func getFreePort() (int, error) {
@@ -29,23 +31,34 @@ func getFreePort() (int, error) {
 	return l.Addr().(*net.TCPAddr).Port, nil
 }
 
-func initConfig() {
-	config.DaemonConfig = &config.Config{}
-	config.DaemonConfig.Server.Host = "localhost"
+func testStats() config.Stats {
+	return config.Stats{
+		Limit:       100,
+		LoadAverage: true,
+		Cpu:         true,
+		DiskInfo:    true,
+		DiskLoad:    true,
+	}
+}
+
+func initConfig() *config.Config {
+	cfg := &config.Config{Stats: testStats()}
+	cfg.Server.Host = "localhost"
 	port, _ := getFreePort()
-	config.DaemonConfig.Server.Port = fmt.Sprintf("%d", port)
-	config.DaemonConfig.Stats.Limit = 100
-	config.DaemonConfig.Stats.LoadAverage = true
-	config.DaemonConfig.Stats.Cpu = true
-	config.DaemonConfig.Stats.DiskInfo = true
-	config.DaemonConfig.Stats.DiskLoad = true
+	cfg.Server.Port = fmt.Sprintf("%d", port)
+	return cfg
 }
 
 func setupServer(t *testing.T) (pb.StatsServiceClient, func()) {
 	t.Helper()
-	initConfig()
+	client, _, cleanup := startServer(t, initConfig())
+	return client, cleanup
+}
 
-	srv := server.NewStatsDaemonServer(context.Background())
+func startServer(t *testing.T, cfg *config.Config) (pb.StatsServiceClient, *server.StatsDaemonServer, func()) {
+	t.Helper()
+
+	srv := server.NewStatsDaemonServer(context.Background(), cfg)
 	require.NotNil(t, srv)
 
 	errCh := make(chan error, 1)
@@ -61,7 +74,7 @@ func setupServer(t *testing.T) (pb.StatsServiceClient, func()) {
 	default:
 	}
 
-	addr := fmt.Sprintf("%s:%s", config.DaemonConfig.Server.Host, config.DaemonConfig.Server.Port)
+	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
 	conn, err := grpc.NewClient(addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	)
@@ -73,10 +86,11 @@ func setupServer(t *testing.T) (pb.StatsServiceClient, func()) {
 	}
 
 	client := pb.NewStatsServiceClient(conn)
-	return client, cleanup
+	return client, srv, cleanup
 }
 
 func TestIntegration(t *testing.T) {
+	stats := testStats()
 	tests := []struct {
 		name           string
 		intervalN      int32
@@ -87,7 +101,7 @@ func TestIntegration(t *testing.T) {
 		{
 			name:       "all metrics",
 			intervalN:  1,
-			avgPeriodM: 1,
+			avgPeriodM: 2,
 			statTypes: []pb.StatType{
 				pb.StatType_LOAD_AVERAGE,
 				pb.StatType_CPU_STATS,
@@ -99,26 +113,26 @@ func TestIntegration(t *testing.T) {
 				require.NotNil(t, resp)
 				require.NotZero(t, resp.GetTimestamp())
 
-				if config.DaemonConfig.Stats.LoadAverage {
+				if stats.LoadAverage {
 					require.NotNil(t, resp.GetLoadAverage())
 					require.True(t, resp.GetLoadAverage().Load1Min >= 0)
 					require.True(t, resp.GetLoadAverage().Load5Min >= 0)
 					require.True(t, resp.GetLoadAverage().Load15Min >= 0)
 				}
 
-				if config.DaemonConfig.Stats.Cpu {
+				if stats.Cpu {
 					require.NotNil(t, resp.GetCpuStats())
 					require.True(t, resp.GetCpuStats().User >= 0)
 					require.True(t, resp.GetCpuStats().System >= 0)
 					require.True(t, resp.GetCpuStats().Idle >= 0)
 				}
 
-				if config.DaemonConfig.Stats.DiskInfo {
+				if stats.DiskInfo {
 					require.NotNil(t, resp.GetDiskStats())
 					require.NotEmpty(t, resp.GetDiskStats().GetDiskStats())
 				}
 
-				if config.DaemonConfig.Stats.DiskLoad {
+				if stats.DiskLoad {
 					require.NotNil(t, resp.GetDisksLoad())
 					require.NotEmpty(t, resp.GetDisksLoad().GetDisksLoad())
 				}
@@ -127,7 +141,7 @@ func TestIntegration(t *testing.T) {
 		{
 			name:       "only CPU stats",
 			intervalN:  1,
-			avgPeriodM: 1,
+			avgPeriodM: 2,
 			statTypes:  []pb.StatType{pb.StatType_CPU_STATS},
 			expectedChecks: func(t *testing.T, resp *pb.StatsResponse) {
 				t.Helper()
@@ -147,7 +161,7 @@ func TestIntegration(t *testing.T) {
 		{
 			name:       "streaming metrics",
 			intervalN:  2,
-			avgPeriodM: 1,
+			avgPeriodM: 2,
 			statTypes:  []pb.StatType{pb.StatType_CPU_STATS},
 			expectedChecks: func(t *testing.T, resp *pb.StatsResponse) {
 				t.Helper()
@@ -211,7 +225,7 @@ func TestServerRestart(t *testing.T) {
 
 	stream, err := client.GetStats(ctx, &pb.StatsRequest{
 		IntervalN:        1,
-		AveragingPeriodM: 1,
+		AveragingPeriodM: 2,
 		StatTypes:        []pb.StatType{pb.StatType_CPU_STATS},
 	})
 	require.NoError(t, err)
@@ -228,7 +242,7 @@ func TestServerRestart(t *testing.T) {
 
 	stream, err = client.GetStats(ctx, &pb.StatsRequest{
 		IntervalN:        1,
-		AveragingPeriodM: 1,
+		AveragingPeriodM: 2,
 		StatTypes:        []pb.StatType{pb.StatType_CPU_STATS},
 	})
 	require.NoError(t, err)
@@ -239,6 +253,42 @@ func TestServerRestart(t *testing.T) {
 	require.NotNil(t, resp.GetCpuStats())
 }
 
+func TestBackfillAfterReconnect(t *testing.T) {
+	client, cleanup := setupServer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	request := &pb.StatsRequest{
+		IntervalN:        1,
+		AveragingPeriodM: 2,
+		StatTypes:        []pb.StatType{pb.StatType_LOAD_AVERAGE},
+	}
+	streamCtx, streamCancel := context.WithCancel(ctx)
+	stream, err := client.GetStats(streamCtx, request)
+	require.NoError(t, err)
+
+	var last *pb.StatsResponse
+	for range 2 {
+		last, err = stream.Recv()
+		require.NoError(t, err)
+		require.False(t, last.GetBackfill())
+	}
+	streamCancel()
+	time.Sleep(2 * time.Second)
+
+	request.Since = last.GetTimestamp()
+	stream, err = client.GetStats(ctx, request)
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.True(t, resp.GetBackfill())
+	require.Greater(t, resp.GetTimestamp(), last.GetTimestamp())
+	require.NotNil(t, resp.GetLoadAverage())
+}
+
 func TestInvalidRequests(t *testing.T) {
 	client, cleanup := setupServer(t)
 	defer cleanup()
@@ -274,6 +324,24 @@ func TestInvalidRequests(t *testing.T) {
 				StatTypes:        []pb.StatType{},
 			},
 		},
+		{
+			name: "top n over limit",
+			request: &pb.StatsRequest{
+				IntervalN:        1,
+				AveragingPeriodM: 1,
+				StatTypes:        []pb.StatType{pb.StatType_TOP_PROCESSES},
+				TopN:             1000,
+			},
+		},
+		{
+			name: "since in the future",
+			request: &pb.StatsRequest{
+				IntervalN:        1,
+				AveragingPeriodM: 1,
+				StatTypes:        []pb.StatType{pb.StatType_CPU_STATS},
+				Since:            time.Now().Add(time.Hour).Unix(),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -286,3 +354,249 @@ func TestInvalidRequests(t *testing.T) {
 		})
 	}
 }
+
+func TestWatchAlerts(t *testing.T) {
+	cfg := initConfig()
+	cfg.Alerts.Interval = 200 * time.Millisecond
+	cfg.Alerts.Rules = []config.AlertRule{{
+		Name:      "load_always",
+		Metric:    "load_average.load1",
+		Operator:  ">=",
+		Threshold: 0,
+		Window:    time.Second,
+	}}
+
+	client, _, cleanup := startServer(t, cfg)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchAlerts(ctx, &pb.WatchAlertsRequest{})
+	require.NoError(t, err)
+
+	alert, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "load_always", alert.GetRule())
+	require.Equal(t, pb.AlertState_FIRING, alert.GetState())
+	require.NotZero(t, alert.GetActiveSince())
+}
+
+func TestConfigReloadDisablesStats(t *testing.T) {
+	cfg := initConfig()
+	client, srv, cleanup := startServer(t, cfg)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	stream, err := client.GetStats(ctx, &pb.StatsRequest{
+		IntervalN:        1,
+		AveragingPeriodM: 2,
+		StatTypes:        []pb.StatType{pb.StatType_LOAD_AVERAGE, pb.StatType_DISK_USAGE},
+	})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetDiskStats())
+	require.Empty(t, resp.GetDisabledStatTypes())
+
+	reloaded := *cfg
+	reloaded.Stats.DiskInfo = false
+	srv.ApplyConfig(&reloaded)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetLoadAverage())
+	require.Nil(t, resp.GetDiskStats())
+	require.Equal(t, []pb.StatType{pb.StatType_DISK_USAGE}, resp.GetDisabledStatTypes())
+
+	disabled := reloaded
+	disabled.Stats.LoadAverage = false
+	srv.ApplyConfig(&disabled)
+
+	for {
+		_, err = stream.Recv()
+		if err != nil {
+			break
+		}
+	}
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestListStatTypes(t *testing.T) {
+	cfg := initConfig()
+	client, srv, cleanup := startServer(t, cfg)
+	defer cleanup()
+
+	enabled := func() map[pb.StatType]bool {
+		resp, err := client.ListStatTypes(context.Background(), &pb.ListStatTypesRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.GetStatTypes(), len(pb.StatType_name))
+
+		result := make(map[pb.StatType]bool)
+		for _, info := range resp.GetStatTypes() {
+			require.NotEmpty(t, info.GetName())
+			result[info.GetStatType()] = info.GetEnabled()
+		}
+		return result
+	}
+
+	types := enabled()
+	require.True(t, types[pb.StatType_CPU_STATS])
+	require.True(t, types[pb.StatType_DISK_USAGE])
+	require.False(t, types[pb.StatType_TOP_PROCESSES])
+
+	reloaded := *cfg
+	reloaded.Stats.Cpu = false
+	srv.ApplyConfig(&reloaded)
+	require.False(t, enabled()[pb.StatType_CPU_STATS])
+}
+
+func TestCapabilities(t *testing.T) {
+	cfg := initConfig()
+	cfg.Server.MaxStreams = 5
+	client, srv, cleanup := startServer(t, cfg)
+	defer cleanup()
+	srv.SetVersion(server.Version{Release: "v1.0.0", BuildDate: "2026-10-01", GitHash: "abc123"})
+
+	resp, err := client.GetCapabilities(context.Background(), &pb.GetCapabilitiesRequest{})
+	require.NoError(t, err)
+
+	require.Len(t, resp.GetStatTypes(), len(pb.StatType_name))
+	require.Equal(t, int64(100), resp.GetLimits().GetMaxAveragingPeriod())
+	require.Equal(t, int32(1), resp.GetLimits().GetMinInterval())
+	require.Equal(t, int32(5), resp.GetLimits().GetMaxStreams())
+	require.Len(t, resp.GetAggregations(), len(pb.Aggregation_name))
+
+	require.NotEmpty(t, resp.GetHost().GetHostname())
+	require.NotEmpty(t, resp.GetHost().GetKernel())
+	require.Positive(t, resp.GetHost().GetCpuCount())
+	require.Positive(t, resp.GetHost().GetBootTime())
+
+	require.Equal(t, "v1.0.0", resp.GetVersion().GetRelease())
+	require.Equal(t, "abc123", resp.GetVersion().GetGitHash())
+}
+
+func TestMaxStreams(t *testing.T) {
+	cfg := initConfig()
+	cfg.Server.MaxStreams = 1
+	client, _, cleanup := startServer(t, cfg)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	request := &pb.StatsRequest{
+		IntervalN:        1,
+		AveragingPeriodM: 2,
+		StatTypes:        []pb.StatType{pb.StatType_LOAD_AVERAGE},
+	}
+	stream, err := client.GetStats(ctx, request)
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	rejected, err := client.GetStats(ctx, request)
+	require.NoError(t, err)
+	_, err = rejected.Recv()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	alerts, err := client.WatchAlerts(ctx, &pb.WatchAlertsRequest{})
+	require.NoError(t, err)
+	_, err = alerts.Recv()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestPluginMetrics(t *testing.T) {
+	cfg := initConfig()
+	cfg.Stats.Plugins = []config.Plugin{
+		{Name: "queue", Command: "sh", Args: []string{"-c", "echo depth=7; echo lag=0.5"}},
+		{Name: "broken", Command: "false"},
+	}
+	client, _, cleanup := startServer(t, cfg)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.GetStats(ctx, &pb.StatsRequest{
+		IntervalN:        1,
+		AveragingPeriodM: 2,
+		StatTypes:        []pb.StatType{pb.StatType_CUSTOM},
+	})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Len(t, resp.GetCustomMetrics(), 2)
+	require.Equal(t, "queue", resp.GetCustomMetrics()[0].GetPlugin())
+	require.Equal(t, "depth", resp.GetCustomMetrics()[0].GetName())
+	require.Equal(t, 7.0, resp.GetCustomMetrics()[0].GetValue())
+
+	// Ошибка одного плагина не скрывает метрики остальных, но передаётся клиенту.
+	require.Len(t, resp.GetErrors(), 1)
+	require.Equal(t, pb.StatType_CUSTOM, resp.GetErrors()[0].GetStatType())
+	require.Contains(t, resp.GetErrors()[0].GetMessage(), "plugin broken:")
+	require.Positive(t, resp.GetErrors()[0].GetConsecutiveFailures())
+	require.NotZero(t, resp.GetErrors()[0].GetSince())
+}
+
+func TestTopProcesses(t *testing.T) {
+	cfg := initConfig()
+	cfg.Stats.Collectors = map[string]config.Collection{"top_processes": {Enabled: true}}
+	client, _, cleanup := startServer(t, cfg)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.GetStats(ctx, &pb.StatsRequest{
+		IntervalN:        1,
+		AveragingPeriodM: 2,
+		StatTypes:        []pb.StatType{pb.StatType_TOP_PROCESSES},
+		TopN:             3,
+	})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.GetTopProcesses().GetByMemory())
+	require.LessOrEqual(t, len(resp.GetTopProcesses().GetByMemory()), 3)
+	require.LessOrEqual(t, len(resp.GetTopProcesses().GetByCpu()), 3)
+	require.NotEmpty(t, resp.GetTopProcesses().GetByMemory()[0].GetCommand())
+}
+
+func TestDaemonSelf(t *testing.T) {
+	cfg := initConfig()
+	cfg.Stats.Collectors = map[string]config.Collection{"daemon_self": {Enabled: true}}
+	client, _, cleanup := startServer(t, cfg)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.GetStats(ctx, &pb.StatsRequest{
+		IntervalN:        1,
+		AveragingPeriodM: 2,
+		StatTypes:        []pb.StatType{pb.StatType_DAEMON_SELF},
+	})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	self := resp.GetDaemonSelf()
+	require.NotNil(t, self)
+	require.Positive(t, self.GetGoroutines())
+	require.Positive(t, self.GetHeapBytes())
+	require.GreaterOrEqual(t, self.GetActiveStreams(), int32(1))
+
+	// Сбор учитывается после замера, поэтому в последнем замере виден предыдущий.
+	require.NotEmpty(t, self.GetCollectors())
+	collector := self.GetCollectors()[0]
+	require.Equal(t, pb.StatType_DAEMON_SELF, collector.GetStatType())
+	require.Positive(t, collector.GetRuns())
+	require.Positive(t, collector.GetStoredSamples())
+	require.Empty(t, resp.GetErrors())
+}
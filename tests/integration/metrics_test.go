@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -10,34 +11,31 @@ import (
 	"github.com/cepmap/otus-system-monitoring/internal/converter"
 	"github.com/cepmap/otus-system-monitoring/internal/metrics"
 	"github.com/cepmap/otus-system-monitoring/internal/models"
+	"github.com/cepmap/otus-system-monitoring/internal/stats"
 	"github.com/stretchr/testify/require"
 )
 
 func TestMetricsIntegration(t *testing.T) {
-	config.DaemonConfig = &config.Config{}
-	config.DaemonConfig.Stats.LoadAverage = true
-	config.DaemonConfig.Stats.Cpu = true
-	config.DaemonConfig.Stats.DiskInfo = true
-	config.DaemonConfig.Stats.DiskLoad = true
+	cfg := config.NewStore(&config.Config{Stats: testStats()})
 
 	t.Run("full metrics pipeline", func(t *testing.T) {
-		storage := metrics.New()
+		storage := metrics.New(cfg.Get().Stats)
 		require.NotNil(t, storage)
 
 		now := time.Now()
 
 		loadAvg := &models.LoadAverage{Load1Min: 1.0, Load5Min: 2.0, Load15Min: 3.0}
-		storage.StoreLoadAverage(loadAvg, now)
+		storage.Store(config.StatLoadAverage, loadAvg, now)
 
 		cpuStats := &models.CPUStat{User: 10.0, System: 20.0, Idle: 70.0}
-		storage.StoreCPUStats(cpuStats, now)
+		storage.Store(config.StatCPU, cpuStats, now)
 
 		disksLoad := &models.DisksLoad{
 			DisksLoad: []models.DiskLoad{
 				{FSName: "sda1", Tps: 10.0, Kps: 100.0},
 			},
 		}
-		storage.StoreDisksLoad(disksLoad, now)
+		storage.Store(config.StatDiskLoad, disksLoad, now)
 
 		diskStats := &models.DiskStats{
 			DiskStats: []models.DiskStat{
@@ -54,7 +52,7 @@ func TestMetricsIntegration(t *testing.T) {
 				},
 			},
 		}
-		storage.StoreDiskUsage(diskStats, now)
+		storage.Store(config.StatDiskInfo, diskStats, now)
 
 		statTypes := []pb.StatType{
 			pb.StatType_LOAD_AVERAGE,
@@ -63,7 +61,7 @@ func TestMetricsIntegration(t *testing.T) {
 			pb.StatType_DISK_USAGE,
 		}
 		avgPeriod := 5 * time.Second
-		col := collector.New(storage, statTypes, avgPeriod)
+		col := collector.New(storage, cfg, statTypes, avgPeriod, metrics.AggregationMean, stats.Options{})
 		require.NotNil(t, col)
 
 		protoLoadAvg := converter.LoadAverageToProto(loadAvg)
@@ -94,7 +92,7 @@ func TestMetricsIntegration(t *testing.T) {
 		require.Equal(t, diskStats.DiskStats[0].Inodes.Used, protoDiskStats.DiskStats[0].Inodes.Used)
 		require.Equal(t, diskStats.DiskStats[0].Inodes.Usage, protoDiskStats.DiskStats[0].Inodes.Usage)
 
-		col.CollectMetrics(now)
+		col.CollectMetrics(context.Background(), now)
 		response := col.PrepareResponse()
 		require.NotNil(t, response)
 		require.NotZero(t, response.GetTimestamp())
@@ -106,19 +104,19 @@ func TestMetricsIntegration(t *testing.T) {
 	})
 
 	t.Run("metrics pipeline with partial data", func(t *testing.T) {
-		storage := metrics.New()
+		storage := metrics.New(cfg.Get().Stats)
 		require.NotNil(t, storage)
 
 		now := time.Now()
 
 		cpuStats := &models.CPUStat{User: 10.0, System: 20.0, Idle: 70.0}
-		storage.StoreCPUStats(cpuStats, now)
+		storage.Store(config.StatCPU, cpuStats, now)
 
 		statTypes := []pb.StatType{
 			pb.StatType_CPU_STATS,
 		}
 		avgPeriod := 5 * time.Second
-		col := collector.New(storage, statTypes, avgPeriod)
+		col := collector.New(storage, cfg, statTypes, avgPeriod, metrics.AggregationMean, stats.Options{})
 		require.NotNil(t, col)
 
 		protoCPUStats := converter.CPUStatToProto(cpuStats)
@@ -127,7 +125,7 @@ func TestMetricsIntegration(t *testing.T) {
 		require.Equal(t, cpuStats.System, protoCPUStats.System)
 		require.Equal(t, cpuStats.Idle, protoCPUStats.Idle)
 
-		col.CollectMetrics(now)
+		col.CollectMetrics(context.Background(), now)
 		response := col.PrepareResponse()
 		require.NotNil(t, response)
 		require.NotZero(t, response.GetTimestamp())
@@ -139,22 +137,22 @@ func TestMetricsIntegration(t *testing.T) {
 	})
 
 	t.Run("metrics pipeline with averaging", func(t *testing.T) {
-		storage := metrics.New()
+		storage := metrics.New(cfg.Get().Stats)
 		require.NotNil(t, storage)
 		now := time.Now()
 		avgPeriod := 3 * time.Second
 		stats1 := &models.CPUStat{User: 10.0, System: 20.0, Idle: 70.0}
 		stats2 := &models.CPUStat{User: 20.0, System: 30.0, Idle: 50.0}
 		stats3 := &models.CPUStat{User: 30.0, System: 40.0, Idle: 30.0}
-		storage.StoreCPUStats(stats1, now.Add(-avgPeriod+time.Second))
-		storage.StoreCPUStats(stats2, now.Add(-avgPeriod+2*time.Second))
-		storage.StoreCPUStats(stats3, now)
+		storage.Store(config.StatCPU, stats1, now.Add(-avgPeriod+time.Second))
+		storage.Store(config.StatCPU, stats2, now.Add(-avgPeriod+2*time.Second))
+		storage.Store(config.StatCPU, stats3, now)
 		statTypes := []pb.StatType{
 			pb.StatType_CPU_STATS,
 		}
-		col := collector.New(storage, statTypes, avgPeriod)
+		col := collector.New(storage, cfg, statTypes, avgPeriod, metrics.AggregationMean, stats.Options{})
 		require.NotNil(t, col)
-		col.CollectMetrics(now)
+		col.CollectMetrics(context.Background(), now)
 		response := col.PrepareResponse()
 		require.NotNil(t, response)
 		require.NotZero(t, response.GetTimestamp())
@@ -164,4 +162,50 @@ func TestMetricsIntegration(t *testing.T) {
 		require.InDelta(t, 30.0, avgCPU.System, 30)
 		require.InDelta(t, 50.0, avgCPU.Idle, 50)
 	})
+
+	t.Run("metrics pipeline with max aggregation", func(t *testing.T) {
+		storage := metrics.New(cfg.Get().Stats)
+		now := time.Now()
+		storage.Store(config.StatCPU, &models.CPUStat{User: 10.0, System: 20.0, Idle: 70.0}, now.Add(-time.Second))
+		storage.Store(config.StatCPU, &models.CPUStat{User: 30.0, System: 5.0, Idle: 65.0}, now)
+
+		col := collector.New(storage, cfg, []pb.StatType{pb.StatType_CPU_STATS}, 3*time.Second, metrics.AggregationMax, stats.Options{})
+		response := col.PrepareResponse()
+		require.Equal(t, pb.Aggregation_MAX, response.GetAggregation())
+		require.Equal(t, 30.0, response.GetCpuStats().User)
+		require.Equal(t, 20.0, response.GetCpuStats().System)
+		require.Equal(t, 70.0, response.GetCpuStats().Idle)
+	})
+
+	t.Run("metrics pipeline reports window coverage", func(t *testing.T) {
+		storage := metrics.New(cfg.Get().Stats)
+		now := time.Now()
+		storage.Store(config.StatCPU, &models.CPUStat{User: 10.0, System: 20.0, Idle: 70.0}, now.Add(-2*time.Second))
+		storage.Store(config.StatCPU, &models.CPUStat{User: 10.0, System: 20.0, Idle: 70.0}, now.Add(-time.Second))
+
+		col := collector.New(storage, cfg, []pb.StatType{pb.StatType_CPU_STATS}, 4*time.Second, metrics.AggregationMean, stats.Options{})
+		response := col.PrepareResponse()
+		require.Len(t, response.GetCoverage(), 1)
+		require.Equal(t, pb.StatType_CPU_STATS, response.GetCoverage()[0].GetStatType())
+		require.Equal(t, int32(2), response.GetCoverage()[0].GetSampleCount())
+		require.InDelta(t, 0.5, response.GetCoverage()[0].GetCoverage(), 0.05)
+	})
+
+	t.Run("collector uses per-stat interval", func(t *testing.T) {
+		statsCfg := testStats()
+		statsCfg.Collectors = map[string]config.Collection{
+			config.StatLoadAverage: {Interval: 100 * time.Millisecond},
+		}
+		store := config.NewStore(&config.Config{Stats: statsCfg})
+		storage := metrics.New(statsCfg)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 550*time.Millisecond)
+		defer cancel()
+		col := collector.New(storage, store, []pb.StatType{pb.StatType_LOAD_AVERAGE}, time.Second, metrics.AggregationMean, stats.Options{})
+		col.Start(ctx)
+		<-ctx.Done()
+
+		require.GreaterOrEqual(t, len(storage.Samples(config.StatLoadAverage, time.Now().Add(-time.Second))), 4)
+		require.Empty(t, col.Errors())
+	})
 }